@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,8 +12,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/sukhera/uptime-monitor/internal/application/handlers"
-	"github.com/sukhera/uptime-monitor/internal/application/middleware"
-	mongodb "github.com/sukhera/uptime-monitor/internal/infrastructure/database/mongo"
+	"github.com/sukhera/uptime-monitor/internal/application/routes"
+	"github.com/sukhera/uptime-monitor/internal/container"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
 )
@@ -68,16 +67,8 @@ func runAPI(cmd *cobra.Command, args []string) {
 		log.Fatal(ctx, "Invalid configuration", err, logger.Fields{})
 	}
 
-	// Initialize database
-	db, err := mongodb.NewConnection(cfg.Database.URI, cfg.Database.Name)
-	if err != nil {
-		log.Fatal(ctx, "Failed to connect to database", err, logger.Fields{"db_url": cfg.Database.URI, "db_name": cfg.Database.Name})
-	}
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Error(ctx, "Error closing database connection", err, nil)
-		}
-	}()
+	// Export request and database spans when an endpoint is configured
+	defer setupTracing(ctx, cfg.Tracing, "uptime-api", log)()
 
 	// Get build info
 	version, commit, buildDate := GetBuildInfo()
@@ -90,77 +81,35 @@ func runAPI(cmd *cobra.Command, args []string) {
 		buildInfo.Version = "dev"
 	}
 
-	// Initialize handlers
-	statusHandler := handlers.NewStatusHandler(db, buildInfo)
-
-	// Setup routes using gorilla/mux
-	router := http.NewServeMux()
-
-	// Add versioned routes (v1)
-	router.HandleFunc("/api/v1/status", statusHandler.GetStatus)
-	router.HandleFunc("/api/v1/health", statusHandler.HealthCheck)
-	router.HandleFunc("/api/v1/incidents", statusHandler.GetIncidents)
-	router.HandleFunc("/api/v1/maintenance", statusHandler.GetMaintenance)
-
-	// Backward compatibility - redirect old routes to v1
-	router.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/status", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/health", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/incidents", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/incidents", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/maintenance", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/maintenance", http.StatusMovedPermanently)
-	})
-
-	// Test endpoint (versioned)
-	router.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(map[string]string{"message": "test route works"}); err != nil {
-			log.Error(ctx, "Error encoding test response", err, nil)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
+	// Initialize storage for the configured driver
+	deps, err := container.New(cfg, container.WithBuildInfo(buildInfo))
+	if err != nil {
+		log.Fatal(ctx, "Failed to create container", err, logger.Fields{})
+	}
+	defer func() {
+		if err := deps.Shutdown(ctx); err != nil {
+			log.Error(ctx, "Error closing database connection", err, nil)
 		}
-	})
-	// Debug endpoint (versioned)
-	router.HandleFunc("/api/v1/debug", statusHandler.GetDebug)
-
-	// Backward compatibility - redirect old debug route to v1
-	router.HandleFunc("/debug", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/debug", http.StatusMovedPermanently)
-	})
-
-	// Debug: Print registered routes
-	log.Info(ctx, "Registered routes", logger.Fields{
-		"v1_routes": []string{
-			"GET /api/v1/status",
-			"GET /api/v1/health",
-			"GET /api/v1/incidents",
-			"GET /api/v1/maintenance",
-			"GET /api/v1/test",
-			"GET /api/v1/debug",
-		},
-		"legacy_redirects": []string{
-			"GET /api/status → /api/v1/status",
-			"GET /api/health → /api/v1/health",
-			"GET /api/incidents → /api/v1/incidents",
-			"GET /api/maintenance → /api/v1/maintenance",
-			"GET /debug → /api/v1/debug",
-		},
-	})
+	}()
 
-	// Add middleware chain
-	var handler http.Handler = router
+	if _, err := deps.GetServiceRepository(); err != nil {
+		log.Fatal(ctx, "Failed to connect to database", err, logger.Fields{"db_driver": cfg.Database.Driver, "db_name": cfg.Database.Name})
+	}
 
-	// Add API versioning middleware
-	handler = middleware.APIVersion("v1")(handler)
+	// Serve the API route table shared with the standalone API server
+	router, err := deps.GetRouter()
+	if err != nil {
+		log.Fatal(ctx, "Failed to set up routes", err, logger.Fields{})
+	}
+	log.Info(ctx, "Registered routes", logger.Fields{"routes": routes.Patterns()})
 
-	// CORS middleware is available but not enabled by default
-	// corsMiddleware := middleware.NewCORS()
-	// handler = corsMiddleware.Handler(handler)
+	// Apply the configured middleware pipeline
+	pipeline, err := deps.NewMiddlewarePipeline(router)
+	if err != nil {
+		log.Fatal(ctx, "Failed to create middleware pipeline", err, logger.Fields{})
+	}
+	handler := pipeline.Then(router)
+	log.Info(ctx, "Configured API middleware", logger.Fields{"middleware": pipeline.Names()})
 
 	// Create server
 	apiPort := viper.GetString("api.port")
@@ -192,11 +141,15 @@ func runAPI(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	// Reload the IP allow and deny lists on SIGHUP
+	go deps.WatchReloads(ctx)
+
 	// Start server
 	log.Info(ctx, "Starting API server", logger.Fields{
 		"port":              apiPort,
 		"health_check_url":  "http://localhost:" + apiPort + "/api/v1/health",
 		"status_url":        "http://localhost:" + apiPort + "/api/v1/status",
+		"metrics_url":       "http://localhost:" + apiPort + "/metrics",
 		"legacy_health_url": "http://localhost:" + apiPort + "/api/health (redirects to v1)",
 		"legacy_status_url": "http://localhost:" + apiPort + "/api/status (redirects to v1)",
 	})
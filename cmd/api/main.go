@@ -6,11 +6,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/application/routes"
 	"github.com/sukhera/uptime-monitor/internal/container"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 )
 
 func main() {
@@ -33,6 +35,20 @@ func main() {
 		"database": cfg.Database.URI,
 	})
 
+	// Export request and database spans when an endpoint is configured
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, "uptime-api")
+	if err != nil {
+		log.Fatal(ctx, "Failed to set up tracing", err, logger.Fields{"endpoint": cfg.Tracing.Endpoint})
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Error(shutdownCtx, "Error flushing traces", err, nil)
+		}
+	}()
+
 	// Create dependency injection container using libnexus-style patterns
 	container, err := container.New(cfg)
 	if err != nil {
@@ -48,6 +64,9 @@ func main() {
 	// Log registered routes
 	logRoutes(log)
 
+	// Reload the IP allow and deny lists on SIGHUP
+	go container.WatchReloads(ctx)
+
 	// Start server with context
 	log.Info(ctx, "Starting HTTP server", logger.Fields{
 		"address": httpServer.GetAddr(),
@@ -61,14 +80,14 @@ func main() {
 // logRoutes logs all registered routes for debugging
 func logRoutes(log logger.Logger) {
 	ctx := context.Background()
-	routes := routes.GetRoutes()
+	listing := routes.GetRoutes()
 	log.Info(ctx, "Registered routes", logger.Fields{
-		"count": len(routes),
+		"count": len(listing),
 	})
-	for route, description := range routes {
+	for _, pattern := range routes.Patterns() {
 		log.Info(ctx, "Route registered", logger.Fields{
-			"route":       route,
-			"description": description,
+			"route":       pattern,
+			"description": listing[pattern],
 		})
 	}
 }
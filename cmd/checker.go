@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,7 +11,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/sukhera/uptime-monitor/internal/checker"
-	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/mongo"
+	"github.com/sukhera/uptime-monitor/internal/container"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
 )
@@ -35,6 +37,7 @@ var (
 	checkInterval string
 	checkerDBURL  string
 	checkerDBName string
+	metricsAddr   string
 )
 
 func init() {
@@ -44,11 +47,13 @@ func init() {
 	checkerCmd.Flags().StringVarP(&checkInterval, "interval", "i", "30s", "Health check interval")
 	checkerCmd.Flags().StringVar(&checkerDBURL, "db-url", "mongodb://localhost:27017", "MongoDB connection URL")
 	checkerCmd.Flags().StringVar(&checkerDBName, "db-name", "status_page", "MongoDB database name")
+	checkerCmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":9091", "Address to serve Prometheus metrics on (empty disables)")
 
 	// Bind flags to viper
 	bindFlagToViper(checkerCmd, "checker.interval", "interval")
 	bindFlagToViper(checkerCmd, "database.url", "db-url")
 	bindFlagToViper(checkerCmd, "database.name", "db-name")
+	bindFlagToViper(checkerCmd, "checker.metrics_addr", "metrics-addr")
 }
 
 func runChecker(cmd *cobra.Command, args []string) {
@@ -63,19 +68,49 @@ func runChecker(cmd *cobra.Command, args []string) {
 		log.Fatal(ctx, "Invalid configuration", err, logger.Fields{})
 	}
 
-	// Initialize database
-	db, err := mongo.NewConnection(cfg.Database.URI, cfg.Database.Name)
+	// Export health check and database spans when an endpoint is configured
+	defer setupTracing(ctx, cfg.Tracing, "uptime-checker", log)()
+
+	// Initialize storage for the configured driver
+	deps, err := container.New(cfg)
 	if err != nil {
-		log.Fatal(ctx, "Failed to connect to database", err, logger.Fields{"db_url": cfg.Database.URI, "db_name": cfg.Database.Name})
+		log.Fatal(ctx, "Failed to create container", err, logger.Fields{})
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
-			log.Error(ctx, "Error closing database connection", err, nil)
+		// Use a fresh context so buffered status logs are flushed after cancellation
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Database.Timeout)
+		defer cancel()
+
+		if err := deps.Shutdown(shutdownCtx); err != nil {
+			log.Error(shutdownCtx, "Error closing database connection", err, nil)
 		}
 	}()
 
-	// Initialize checker service
-	service := checker.NewService(db)
+	// Initialize checker service with buffered status log writes
+	service, err := deps.GetCheckerService()
+	if err != nil {
+		log.Fatal(ctx, "Failed to connect to database", err, logger.Fields{"db_driver": cfg.Database.Driver, "db_name": cfg.Database.Name})
+	}
+
+	// Record every check in the Prometheus collectors
+	subject := checker.NewHealthCheckSubject()
+	subject.Attach(checker.NewMetricsObserver(deps.GetMetrics()))
+	serveMetrics(ctx, deps.GetMetricsServer(), log)
+
+	// Deliver state changes to the configured notification channels
+	router, err := deps.GetNotifier()
+	if err != nil {
+		log.Fatal(ctx, "Failed to create notifier", err, logger.Fields{})
+	}
+	if router.Len() > 0 {
+		// Seed the last status of services from their open alerts, so
+		// failures that began before a restart still recover
+		alerts, err := deps.GetAlertRepository()
+		if err != nil {
+			log.Fatal(ctx, "Failed to get alert repository", err, logger.Fields{})
+		}
+		subject.Attach(notifier.NewObserver(router, log, notifier.WithObserverAlerts(alerts)))
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -93,16 +128,18 @@ func runChecker(cmd *cobra.Command, args []string) {
 
 	// Start health checking loop
 	log.Info(ctx, "Starting health checker", logger.Fields{
-		"interval": cfg.Checker.Interval.String(),
-		"db_url":   cfg.Database.URI,
-		"db_name":  cfg.Database.Name,
+		"interval":       cfg.Checker.Interval.String(),
+		"db_url":         cfg.Database.URI,
+		"db_name":        cfg.Database.Name,
+		"batch_size":     cfg.Checker.BatchSize,
+		"flush_interval": cfg.Checker.FlushInterval.String(),
 	})
 
 	ticker := time.NewTicker(cfg.Checker.Interval)
 	defer ticker.Stop()
 
 	// Run initial check
-	if err := service.RunHealthChecks(ctx); err != nil {
+	if err := service.RunHealthChecksWithObservers(ctx, subject); err != nil {
 		log.Error(ctx, "Initial health check failed", err, nil)
 	}
 
@@ -110,7 +147,7 @@ func runChecker(cmd *cobra.Command, args []string) {
 	for {
 		select {
 		case <-ticker.C:
-			if err := service.RunHealthChecks(ctx); err != nil {
+			if err := service.RunHealthChecksWithObservers(ctx, subject); err != nil {
 				log.Error(ctx, "Health check failed", err, nil)
 			}
 		case <-ctx.Done():
@@ -119,3 +156,18 @@ func runChecker(cmd *cobra.Command, args []string) {
 		}
 	}
 }
+
+// serveMetrics exposes /metrics in the background; the container closes the
+// server on shutdown
+func serveMetrics(ctx context.Context, srv *http.Server, log logger.Logger) {
+	if srv == nil {
+		return
+	}
+
+	log.Info(ctx, "Serving metrics", logger.Fields{"address": srv.Addr})
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error(ctx, "Metrics server failed", err, logger.Fields{"address": srv.Addr})
+		}
+	}()
+}
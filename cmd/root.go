@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 )
 
 var (
@@ -116,6 +119,7 @@ func setupEnvBindings() {
 	_ = viper.BindEnv("server.idle_timeout", "IDLE_TIMEOUT")
 
 	// Database environment variables
+	_ = viper.BindEnv("database.driver", "DB_DRIVER")
 	_ = viper.BindEnv("database.url", "MONGO_URI", "DB_URL", "DATABASE_URL")
 	_ = viper.BindEnv("database.name", "DB_NAME", "DATABASE_NAME")
 	_ = viper.BindEnv("database.timeout", "DB_TIMEOUT")
@@ -126,6 +130,66 @@ func setupEnvBindings() {
 
 	// Checker environment variables
 	_ = viper.BindEnv("checker.interval", "CHECK_INTERVAL", "CHECKER_INTERVAL")
+	_ = viper.BindEnv("checker.batch_size", "CHECKER_BATCH_SIZE")
+	_ = viper.BindEnv("checker.flush_interval", "CHECKER_FLUSH_INTERVAL")
+	_ = viper.BindEnv("checker.metrics_addr", "CHECKER_METRICS_ADDR")
+
+	// Notifier environment variables
+	_ = viper.BindEnv("notifier.webhook_url", "WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.webhook_secret", "WEBHOOK_SECRET")
+	_ = viper.BindEnv("notifier.slack_webhook_url", "SLACK_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.slack_template_file", "SLACK_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.teams_webhook_url", "TEAMS_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.teams_template_file", "TEAMS_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.discord_webhook_url", "DISCORD_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.discord_template_file", "DISCORD_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.smtp_host", "SMTP_HOST")
+	_ = viper.BindEnv("notifier.smtp_port", "SMTP_PORT")
+	_ = viper.BindEnv("notifier.smtp_username", "SMTP_USERNAME")
+	_ = viper.BindEnv("notifier.smtp_password", "SMTP_PASSWORD")
+	_ = viper.BindEnv("notifier.smtp_from", "SMTP_FROM")
+	_ = viper.BindEnv("notifier.smtp_to", "SMTP_TO")
+	_ = viper.BindEnv("notifier.smtp_tls", "SMTP_TLS")
+	_ = viper.BindEnv("notifier.smtp_template_dir", "SMTP_TEMPLATE_DIR")
+	_ = viper.BindEnv("notifier.pagerduty_routing_key", "PAGERDUTY_ROUTING_KEY")
+	_ = viper.BindEnv("notifier.pagerduty_events_url", "PAGERDUTY_EVENTS_URL")
+	_ = viper.BindEnv("notifier.opsgenie_api_key", "OPSGENIE_API_KEY")
+	_ = viper.BindEnv("notifier.opsgenie_api_url", "OPSGENIE_API_URL")
+	_ = viper.BindEnv("notifier.telegram_bot_token", "TELEGRAM_BOT_TOKEN")
+	_ = viper.BindEnv("notifier.telegram_chat_id", "TELEGRAM_CHAT_ID")
+	_ = viper.BindEnv("notifier.telegram_api_url", "TELEGRAM_API_URL")
+	_ = viper.BindEnv("notifier.ntfy_url", "NTFY_URL")
+	_ = viper.BindEnv("notifier.ntfy_topic", "NTFY_TOPIC")
+	_ = viper.BindEnv("notifier.ntfy_token", "NTFY_TOKEN")
+	_ = viper.BindEnv("notifier.pushover_app_token", "PUSHOVER_APP_TOKEN")
+	_ = viper.BindEnv("notifier.pushover_user_key", "PUSHOVER_USER_KEY")
+	_ = viper.BindEnv("notifier.pushover_api_url", "PUSHOVER_API_URL")
+	_ = viper.BindEnv("notifier.outbox_interval", "NOTIFIER_OUTBOX_INTERVAL")
+	_ = viper.BindEnv("notifier.outbox_max_attempts", "NOTIFIER_OUTBOX_MAX_ATTEMPTS")
+	_ = viper.BindEnv("notifier.group_by", "NOTIFIER_GROUP_BY")
+	_ = viper.BindEnv("notifier.group_wait", "NOTIFIER_GROUP_WAIT")
+	_ = viper.BindEnv("notifier.group_interval", "NOTIFIER_GROUP_INTERVAL")
+	_ = viper.BindEnv("notifier.reminder_interval", "NOTIFIER_REMINDER_INTERVAL")
+	_ = viper.BindEnv("notifier.incident_url", "NOTIFIER_INCIDENT_URL")
+
+	// Tracing environment variables
+	_ = viper.BindEnv("tracing.endpoint", "TRACING_ENDPOINT")
+	_ = viper.BindEnv("tracing.sample_ratio", "TRACING_SAMPLE_RATIO")
+
+	// Middleware environment variables
+	_ = viper.BindEnv("middleware.recovery", "MIDDLEWARE_RECOVERY")
+	_ = viper.BindEnv("middleware.request_id", "MIDDLEWARE_REQUEST_ID")
+	_ = viper.BindEnv("middleware.request_logging", "MIDDLEWARE_REQUEST_LOGGING")
+	_ = viper.BindEnv("middleware.security_headers", "MIDDLEWARE_SECURITY_HEADERS")
+	_ = viper.BindEnv("middleware.cors", "MIDDLEWARE_CORS")
+	_ = viper.BindEnv("middleware.rate_limit", "MIDDLEWARE_RATE_LIMIT")
+	_ = viper.BindEnv("middleware.request_timeout", "MIDDLEWARE_REQUEST_TIMEOUT")
+	_ = viper.BindEnv("middleware.rate_limit_burst", "MIDDLEWARE_RATE_LIMIT_BURST")
+	_ = viper.BindEnv("middleware.rate_limit_key_header", "MIDDLEWARE_RATE_LIMIT_KEY_HEADER")
+	_ = viper.BindEnv("middleware.trusted_proxies", "MIDDLEWARE_TRUSTED_PROXIES")
+	_ = viper.BindEnv("middleware.ip_allowlist", "MIDDLEWARE_IP_ALLOWLIST")
+	_ = viper.BindEnv("middleware.ip_denylist", "MIDDLEWARE_IP_DENYLIST")
+	_ = viper.BindEnv("middleware.ip_filter_paths", "MIDDLEWARE_IP_FILTER_PATHS")
 
 	// Web environment variables
 	_ = viper.BindEnv("web.port", "WEB_PORT")
@@ -133,6 +197,27 @@ func setupEnvBindings() {
 	_ = viper.BindEnv("web.static_dir", "STATIC_DIR")
 }
 
+// setupTracing starts exporting spans for serviceName when an endpoint is
+// configured. The returned function flushes pending spans on shutdown.
+func setupTracing(ctx context.Context, cfg config.TracingConfig, serviceName string, log logger.Logger) func() {
+	shutdown, err := tracing.Setup(ctx, cfg, serviceName)
+	if err != nil {
+		log.Fatal(ctx, "Failed to set up tracing", err, logger.Fields{"endpoint": cfg.Endpoint})
+	}
+	if cfg.Endpoint != "" {
+		log.Info(ctx, "Exporting traces", logger.Fields{"endpoint": cfg.Endpoint, "sample_ratio": cfg.SampleRatio})
+	}
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdown(shutdownCtx); err != nil {
+			log.Error(shutdownCtx, "Error flushing traces", err, nil)
+		}
+	}
+}
+
 // bindFlagToViper binds a cobra command flag to viper with error handling
 func bindFlagToViper(cmd *cobra.Command, viperKey, flagName string) {
 	ctx := context.Background()
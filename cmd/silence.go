@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sukhera/uptime-monitor/internal/container"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
+)
+
+var silenceCmd = &cobra.Command{
+	Use:   "silence",
+	Short: "Manage notification silences",
+	Long: `Manage silences that mute notifications for matching services.
+
+Silenced services keep being monitored and stay visible on the status page;
+only their notifications are held back while the silence is active.
+
+Examples:
+  status-page silence add --service checkout --duration 2h --comment "database migration"
+  status-page silence add --group platform --channel pagerduty --start 2024-01-15T22:00:00Z --end 2024-01-16T02:00:00Z --comment "network maintenance"
+  status-page silence list --state active
+  status-page silence expire 65a1c2f0e4b0a1b2c3d4e5f6`,
+}
+
+var silenceAddCmd = &cobra.Command{
+	Use:          "add",
+	Short:        "Create a silence",
+	Args:         cobra.NoArgs,
+	RunE:         runSilenceAdd,
+	SilenceUsage: true,
+}
+
+var silenceListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List silences",
+	Args:         cobra.NoArgs,
+	RunE:         runSilenceList,
+	SilenceUsage: true,
+}
+
+var silenceExpireCmd = &cobra.Command{
+	Use:          "expire <id>",
+	Short:        "End a silence now",
+	Args:         cobra.ExactArgs(1),
+	RunE:         runSilenceExpire,
+	SilenceUsage: true,
+}
+
+var (
+	silenceServices []string
+	silenceGroups   []string
+	silenceTags     []string
+	silenceChannels []string
+	silenceStart    string
+	silenceEnd      string
+	silenceDuration time.Duration
+	silenceBy       string
+	silenceComment  string
+	silenceState    string
+)
+
+func init() {
+	rootCmd.AddCommand(silenceCmd)
+	silenceCmd.AddCommand(silenceAddCmd, silenceListCmd, silenceExpireCmd)
+
+	silenceAddCmd.Flags().StringSliceVar(&silenceServices, "service", nil, "Service slugs to silence")
+	silenceAddCmd.Flags().StringSliceVar(&silenceGroups, "group", nil, "Service groups to silence")
+	silenceAddCmd.Flags().StringSliceVar(&silenceTags, "tag", nil, "Service tags to silence")
+	silenceAddCmd.Flags().StringSliceVar(&silenceChannels, "channel", nil, "Channels to mute (default: every channel)")
+	silenceAddCmd.Flags().StringVar(&silenceStart, "start", "", "Start time in RFC 3339 format (default: now)")
+	silenceAddCmd.Flags().StringVar(&silenceEnd, "end", "", "End time in RFC 3339 format")
+	silenceAddCmd.Flags().DurationVar(&silenceDuration, "duration", 0, "How long the silence lasts, instead of --end")
+	silenceAddCmd.Flags().StringVar(&silenceBy, "by", os.Getenv("USER"), "Who is creating the silence")
+	silenceAddCmd.Flags().StringVar(&silenceComment, "comment", "", "Why notifications are silenced")
+
+	silenceListCmd.Flags().StringVar(&silenceState, "state", "", "Only list silences in this state: pending, active or expired")
+}
+
+func runSilenceAdd(cmd *cobra.Command, args []string) error {
+	now := time.Now().UTC()
+
+	silence := &alert.Silence{
+		Match: alert.Matcher{
+			Services: silenceServices,
+			Groups:   silenceGroups,
+			Tags:     silenceTags,
+		},
+		Channels:  silenceChannels,
+		StartsAt:  now,
+		CreatedBy: silenceBy,
+		Comment:   silenceComment,
+	}
+
+	if silenceStart != "" {
+		start, err := time.Parse(time.RFC3339, silenceStart)
+		if err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+		silence.StartsAt = start.UTC()
+	}
+
+	switch {
+	case silenceEnd != "" && silenceDuration != 0:
+		return fmt.Errorf("set either --end or --duration, not both")
+	case silenceEnd != "":
+		end, err := time.Parse(time.RFC3339, silenceEnd)
+		if err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+		silence.EndsAt = end.UTC()
+	case silenceDuration > 0:
+		silence.EndsAt = silence.StartsAt.Add(silenceDuration)
+	default:
+		return fmt.Errorf("set --end or --duration")
+	}
+
+	return withSilenceRepository(func(ctx context.Context, repo alert.SilenceRepository) error {
+		if err := repo.Create(ctx, silence); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Created silence %s (%s until %s)\n",
+			silence.ID, silence.State(now), silence.EndsAt.Format(time.RFC3339))
+		return nil
+	})
+}
+
+func runSilenceList(cmd *cobra.Command, args []string) error {
+	switch silenceState {
+	case "", alert.SilencePending, alert.SilenceActive, alert.SilenceExpired:
+	default:
+		return fmt.Errorf("invalid --state %q: must be one of pending, active, expired", silenceState)
+	}
+
+	return withSilenceRepository(func(ctx context.Context, repo alert.SilenceRepository) error {
+		silences, err := repo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		printSilences(cmd.OutOrStdout(), silences, silenceState, time.Now().UTC())
+		return nil
+	})
+}
+
+func runSilenceExpire(cmd *cobra.Command, args []string) error {
+	return withSilenceRepository(func(ctx context.Context, repo alert.SilenceRepository) error {
+		silence, err := repo.Expire(ctx, args[0], time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Expired silence %s\n", silence.ID)
+		return nil
+	})
+}
+
+// withSilenceRepository runs fn against the configured silence storage
+func withSilenceRepository(fn func(ctx context.Context, repo alert.SilenceRepository) error) error {
+	ctx := context.Background()
+
+	cfg := config.LoadFromViper()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	deps, err := container.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	defer func() {
+		_ = deps.Shutdown(ctx)
+	}()
+
+	repo, err := deps.GetSilenceRepository()
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, repo)
+}
+
+// printSilences writes the silences in the given state, or all of them, as a table
+func printSilences(out io.Writer, silences []*alert.Silence, state string, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATE\tMATCH\tCHANNELS\tSTARTS\tENDS\tCREATED BY\tCOMMENT")
+	for _, silence := range silences {
+		current := silence.State(now)
+		if state != "" && current != state {
+			continue
+		}
+
+		channels := strings.Join(silence.Channels, ",")
+		if channels == "" {
+			channels = "all"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			silence.ID,
+			current,
+			describeMatcher(silence.Match),
+			channels,
+			silence.StartsAt.Format(time.RFC3339),
+			silence.EndsAt.Format(time.RFC3339),
+			silence.CreatedBy,
+			silence.Comment,
+		)
+	}
+	_ = w.Flush()
+}
+
+// describeMatcher renders a matcher as space-separated key=values pairs
+func describeMatcher(m alert.Matcher) string {
+	var parts []string
+	if len(m.Services) > 0 {
+		parts = append(parts, "service="+strings.Join(m.Services, ","))
+	}
+	if len(m.Groups) > 0 {
+		parts = append(parts, "group="+strings.Join(m.Groups, ","))
+	}
+	if len(m.Tags) > 0 {
+		parts = append(parts, "tag="+strings.Join(m.Tags, ","))
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
@@ -3,13 +3,19 @@ package main
 import (
 	"context"
 	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/go-co-op/gocron"
 	"github.com/sukhera/uptime-monitor/internal/checker"
 	"github.com/sukhera/uptime-monitor/internal/container"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 )
 
 func main() {
@@ -17,6 +23,7 @@ func main() {
 		intervalMinutes = flag.Int("interval", 0, "Check interval in minutes (overrides env var)")
 		mongoURI        = flag.String("mongo-uri", "", "MongoDB connection URI (overrides env var)")
 		dbName          = flag.String("db-name", "", "Database name (overrides env var)")
+		metricsAddr     = flag.String("metrics-addr", "", "Address to serve Prometheus metrics on (overrides env var)")
 		verbose         = flag.Bool("verbose", false, "Enable verbose logging")
 	)
 	flag.Parse()
@@ -46,6 +53,9 @@ func main() {
 	if *dbName != "" {
 		options = append(options, config.WithDatabase(cfg.Database.URI, *dbName, cfg.Database.Timeout))
 	}
+	if *metricsAddr != "" {
+		options = append(options, config.WithCheckerMetricsAddr(*metricsAddr))
+	}
 
 	// Apply all options
 	if len(options) > 0 {
@@ -63,6 +73,12 @@ func main() {
 		})
 	}
 
+	// Export health check and database spans when an endpoint is configured
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, "uptime-checker")
+	if err != nil {
+		log.Fatal(ctx, "Failed to set up tracing", err, logger.Fields{"endpoint": cfg.Tracing.Endpoint})
+	}
+
 	// Initialize dependency injection container
 	container, err := container.New(cfg)
 	if err != nil {
@@ -83,15 +99,43 @@ func main() {
 	subject.Attach(loggingObserver)
 
 	// Add metrics observer
-	metricsObserver := checker.NewMetricsObserver()
+	metricsObserver := checker.NewMetricsObserver(container.GetMetrics())
 	subject.Attach(metricsObserver)
 
-	// Add alerting observer
-	alertingObserver := checker.NewAlertingObserver(5000) // 5 second threshold
-	subject.Attach(alertingObserver)
+	// Expose the collected metrics for scraping
+	if metricsServer := container.GetMetricsServer(); metricsServer != nil {
+		log.Info(ctx, "Serving metrics", logger.Fields{"address": metricsServer.Addr})
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Error(ctx, "Metrics server failed", err, logger.Fields{"address": metricsServer.Addr})
+			}
+		}()
+	}
+
+	// Cancelled on shutdown so the alert feed below stops with the scheduler
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
 
-	// Start alert processing goroutine
-	go processAlerts(ctx, alertingObserver.GetAlertChannel(), log)
+	// Deliver state changes to the configured notification channels. Without
+	// any, slow and failing checks are only logged from the in-process feed.
+	router, err := container.GetNotifier()
+	if err != nil {
+		log.Fatal(ctx, "Failed to create notifier", err, logger.Fields{})
+	}
+	var alertingObserver *checker.AlertingObserver
+	if router.Len() > 0 {
+		// Seed the last status of services from their open alerts, so
+		// failures that began before a restart still recover
+		alerts, err := container.GetAlertRepository()
+		if err != nil {
+			log.Fatal(ctx, "Failed to get alert repository", err, logger.Fields{})
+		}
+		subject.Attach(notifier.NewObserver(router, log, notifier.WithObserverAlerts(alerts)))
+	} else {
+		alertingObserver = checker.NewAlertingObserver(5000) // 5 second threshold
+		subject.Attach(alertingObserver)
+		go processAlerts(runCtx, alertingObserver.GetAlertChannel(), log)
+	}
 
 	log.Info(ctx, "Starting status checker", logger.Fields{
 		"interval": cfg.Checker.Interval.String(),
@@ -107,15 +151,40 @@ func main() {
 		log.Fatal(ctx, "Failed to schedule health checks", err, logger.Fields{})
 	}
 
+	// Stop the scheduler on SIGINT/SIGTERM so buffered status logs are flushed
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+
+		log.Info(ctx, "Shutting down status checker", logger.Fields{})
+		scheduler.Stop()
+		stop()
+	}()
+
 	log.Info(ctx, "Status checker started successfully", logger.Fields{})
 	scheduler.StartBlocking()
+
+	if alertingObserver != nil && alertingObserver.Dropped() > 0 {
+		log.Warn(ctx, "Alerts were dropped from the in-process alert feed", logger.Fields{"dropped": alertingObserver.Dropped()})
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Database.Timeout)
+	defer cancel()
+
+	if err := container.Shutdown(shutdownCtx); err != nil {
+		log.Error(shutdownCtx, "Error shutting down container", err, logger.Fields{})
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error(shutdownCtx, "Error flushing traces", err, logger.Fields{})
+	}
 }
 
 // runHealthChecks runs health checks with enhanced logging and metrics
 func runHealthChecks(ctx context.Context, service checker.ServiceInterface, subject *checker.HealthCheckSubject, log logger.Logger) {
 	log.Info(ctx, "Running health checks", logger.Fields{})
 
-	if err := service.RunHealthChecks(ctx); err != nil {
+	if err := service.RunHealthChecksWithObservers(ctx, subject); err != nil {
 		log.Error(ctx, "Error running health checks", err, logger.Fields{})
 	}
 }
@@ -133,9 +202,6 @@ func processAlerts(ctx context.Context, alertCh <-chan checker.HealthCheckEvent,
 				"error":        alert.Error,
 			})
 
-			// Here you could integrate with external alerting systems
-			// like PagerDuty, Slack, email, etc.
-
 		case <-ctx.Done():
 			return
 		}
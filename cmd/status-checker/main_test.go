@@ -7,11 +7,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/sukhera/uptime-monitor/internal/checker"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
 )
 
 func TestMain_ConfigurationLoading(t *testing.T) {
@@ -323,8 +325,8 @@ func TestMain_Integration_Observers(t *testing.T) {
 			subject := checker.NewHealthCheckSubject()
 
 			// Add metrics observer
-			metricsObserver := checker.NewMetricsObserver()
-			subject.Attach(metricsObserver)
+			m := metrics.New()
+			subject.Attach(checker.NewMetricsObserver(m))
 
 			// Add alerting observer
 			alertingObserver := checker.NewAlertingObserver(5000)
@@ -341,8 +343,9 @@ func TestMain_Integration_Observers(t *testing.T) {
 			time.Sleep(10 * time.Millisecond)
 
 			// Check metrics
-			metrics := metricsObserver.GetMetrics()
-			assert.NotEmpty(t, metrics)
+			count, err := testutil.GatherAndCount(m.Registry(), "uptime_checks_total")
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
 		})
 	}
 }
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// Alert request limits
+const (
+	defaultAlertLimit = 50
+	maxAlertLimit     = 500
+	maxAckBodyBytes   = 4 << 10
+)
+
+// AlertHandler serves the alert API
+type AlertHandler struct {
+	*BaseHandler
+	repo alert.AlertRepository
+	now  func() time.Time
+}
+
+// AckRequest is the optional body of an acknowledgement
+type AckRequest struct {
+	By string `json:"by"`
+}
+
+// NewAlertHandler creates an alert handler backed by repo
+func NewAlertHandler(repo alert.AlertRepository, buildInfo BuildInfo) *AlertHandler {
+	return &AlertHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+		now:         time.Now,
+	}
+}
+
+// ListAlerts lists alerts, newest first, on GET /api/v1/alerts. The state
+// and limit query parameters filter the list.
+func (h *AlertHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	switch state {
+	case "", alert.StateTriggered, alert.StateAcknowledged, alert.StateResolved:
+	default:
+		err := errors.NewValidationError("state must be one of: triggered, acknowledged, resolved")
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	limit := defaultAlertLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAlertLimit {
+			err := errors.NewValidationError("limit must be between 1 and " + strconv.Itoa(maxAlertLimit))
+			h.WriteBadRequestError(w, r, err.Error(), err)
+			return
+		}
+		limit = parsed
+	}
+
+	alerts, err := h.repo.List(r.Context(), state, limit)
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to list alerts", err)
+		return
+	}
+	if alerts == nil {
+		alerts = []*alert.Alert{}
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, alerts, "failed to encode alerts")
+}
+
+// GetAlert reads the alert on GET /api/v1/alerts/{id}
+func (h *AlertHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	a, err := h.repo.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to get alert", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, a, "failed to encode alert")
+}
+
+// AcknowledgeAlert marks the alert on POST /api/v1/alerts/{id}/ack as
+// acknowledged by the person named in the optional request body
+func (h *AlertHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	var req AckRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxAckBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil && err != io.EOF {
+		err = errors.NewWithCause("invalid acknowledgement body", errors.ErrorKindValidation, err)
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	a, err := h.repo.Acknowledge(r.Context(), r.PathValue("id"), req.By, h.now().UTC())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to acknowledge alert", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, a, "failed to encode alert")
+}
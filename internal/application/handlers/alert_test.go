@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+// newTestAlertHandler returns an alert handler whose repository holds the
+// resolved alert 1 of api and the triggered alert 2 of web
+func newTestAlertHandler(t *testing.T) (*AlertHandler, *memory.AlertRepository) {
+	t.Helper()
+	repo := memory.NewAlertRepository()
+	resolved := createTestAlert(t, repo, "api")
+	require.NoError(t, repo.Resolve(context.Background(), resolved.ID, time.Date(2024, 1, 2, 12, 1, 0, 0, time.UTC)))
+	createTestAlert(t, repo, "web")
+
+	handler := NewAlertHandler(repo, BuildInfo{Version: "test"})
+	handler.now = func() time.Time { return time.Date(2024, 1, 2, 12, 5, 0, 0, time.UTC) }
+	return handler, repo
+}
+
+// alertRouter serves the routes of h as the API does
+func alertRouter(h *AlertHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /api/v1/alerts":           h.ListAlerts,
+		"GET /api/v1/alerts/{id}":      h.GetAlert,
+		"POST /api/v1/alerts/{id}/ack": h.AcknowledgeAlert,
+	})
+}
+
+func createTestAlert(t *testing.T, repo *memory.AlertRepository, slug string) *alert.Alert {
+	t.Helper()
+	a := alert.NewAlert("Service "+slug, slug, alert.SeverityCritical, "timeout", time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, repo.Create(context.Background(), a))
+	return a
+}
+
+func TestAlertHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedSlugs  []string
+	}{
+		{name: "newest first", expectedStatus: http.StatusOK, expectedSlugs: []string{"web", "api"}},
+		{name: "by state", query: "?state=triggered", expectedStatus: http.StatusOK, expectedSlugs: []string{"web"}},
+		{name: "with limit", query: "?limit=1", expectedStatus: http.StatusOK, expectedSlugs: []string{"web"}},
+		{name: "unknown state", query: "?state=open", expectedStatus: http.StatusBadRequest},
+		{name: "zero limit", query: "?limit=0", expectedStatus: http.StatusBadRequest},
+		{name: "non-numeric limit", query: "?limit=many", expectedStatus: http.StatusBadRequest},
+		{name: "limit too large", query: "?limit=501", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestAlertHandler(t)
+
+			w := serve(alertRouter(handler), http.MethodGet, "/api/v1/alerts"+tt.query, "")
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var alerts []alert.Alert
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &alerts))
+			var slugs []string
+			for _, a := range alerts {
+				slugs = append(slugs, a.ServiceSlug)
+			}
+			assert.Equal(t, tt.expectedSlugs, slugs)
+		})
+	}
+}
+
+func TestAlertHandler_Routes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "get", method: http.MethodGet, path: "/api/v1/alerts/1", expectedStatus: http.StatusOK, expectedBody: `"state":"resolved"`},
+		{name: "get unknown ID", method: http.MethodGet, path: "/api/v1/alerts/99", expectedStatus: http.StatusNotFound},
+		{name: "get invalid ID", method: http.MethodGet, path: "/api/v1/alerts/not-an-id", expectedStatus: http.StatusNotFound},
+		{name: "acknowledge", method: http.MethodPost, path: "/api/v1/alerts/2/ack", body: `{"by":"alice"}`, expectedStatus: http.StatusOK, expectedBody: `"acknowledged_by":"alice"`},
+		{name: "acknowledge without body", method: http.MethodPost, path: "/api/v1/alerts/2/ack", expectedStatus: http.StatusOK, expectedBody: `"state":"acknowledged"`},
+		{name: "acknowledge resolved alert", method: http.MethodPost, path: "/api/v1/alerts/1/ack", expectedStatus: http.StatusConflict},
+		{name: "acknowledge unknown ID", method: http.MethodPost, path: "/api/v1/alerts/99/ack", expectedStatus: http.StatusNotFound},
+		{name: "acknowledge invalid ID", method: http.MethodPost, path: "/api/v1/alerts/not-an-id/ack", expectedStatus: http.StatusNotFound},
+		{name: "acknowledge with unknown field", method: http.MethodPost, path: "/api/v1/alerts/2/ack", body: `{"who":"bob"}`, expectedStatus: http.StatusBadRequest},
+		{name: "unknown action", method: http.MethodPost, path: "/api/v1/alerts/2/close", expectedStatus: http.StatusNotFound},
+		{name: "post to collection", method: http.MethodPost, path: "/api/v1/alerts", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD"},
+		{name: "get acknowledgement", method: http.MethodGet, path: "/api/v1/alerts/2/ack", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestAlertHandler(t)
+
+			w := serve(alertRouter(handler), tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestAlertHandler_Acknowledge(t *testing.T) {
+	handler, _ := newTestAlertHandler(t)
+	router := alertRouter(handler)
+
+	w := serve(router, http.MethodPost, "/api/v1/alerts/2/ack", `{"by":"alice"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var acked alert.Alert
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &acked))
+	assert.Equal(t, alert.StateAcknowledged, acked.State)
+	assert.Equal(t, "alice", acked.AcknowledgedBy)
+	require.NotNil(t, acked.AcknowledgedAt)
+	assert.Equal(t, handler.now(), *acked.AcknowledgedAt)
+
+	// A repeated acknowledgement is harmless
+	w = serve(router, http.MethodPost, "/api/v1/alerts/2/ack", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"acknowledged_by":"alice"`)
+}
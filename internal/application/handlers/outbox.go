@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// Outbox request limits
+const (
+	defaultOutboxLimit = 50
+	maxOutboxLimit     = 500
+)
+
+// OutboxHandler serves the notification outbox API
+type OutboxHandler struct {
+	*BaseHandler
+	repo alert.OutboxRepository
+	now  func() time.Time
+}
+
+// OutboxResponse lists outbox deliveries together with the number of
+// deliveries in each state
+type OutboxResponse struct {
+	Counts     map[string]int64  `json:"counts"`
+	Deliveries []*alert.Delivery `json:"deliveries"`
+}
+
+// NewOutboxHandler creates an outbox handler backed by repo
+func NewOutboxHandler(repo alert.OutboxRepository, buildInfo BuildInfo) *OutboxHandler {
+	return &OutboxHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+		now:         time.Now,
+	}
+}
+
+// ListDeliveries lists deliveries, newest first, on GET /api/v1/outbox. The
+// state and limit query parameters filter the list.
+func (h *OutboxHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	switch state {
+	case "", alert.DeliveryPending, alert.DeliveryDelivered, alert.DeliveryDead:
+	default:
+		err := errors.NewValidationError("state must be one of: pending, delivered, dead")
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	limit := defaultOutboxLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxOutboxLimit {
+			err := errors.NewValidationError("limit must be between 1 and " + strconv.Itoa(maxOutboxLimit))
+			h.WriteBadRequestError(w, r, err.Error(), err)
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.repo.List(r.Context(), state, limit)
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to list outbox deliveries", err)
+		return
+	}
+	if deliveries == nil {
+		deliveries = []*alert.Delivery{}
+	}
+
+	counts, err := h.repo.Count(r.Context())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to count outbox deliveries", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, OutboxResponse{Counts: counts, Deliveries: deliveries}, "failed to encode outbox")
+}
+
+// RetryDelivery requeues the dead-lettered delivery on
+// POST /api/v1/outbox/{id}/retry
+func (h *OutboxHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.repo.Retry(r.Context(), r.PathValue("id"), h.now().UTC())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to retry delivery", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, delivery, "failed to encode delivery")
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+// newTestOutboxHandler returns an outbox handler whose repository holds the
+// dead-lettered pagerduty delivery 1 and the pending slack delivery 2
+func newTestOutboxHandler(t *testing.T) (*OutboxHandler, *memory.OutboxRepository) {
+	t.Helper()
+	ctx := context.Background()
+	repo := memory.NewOutboxRepository()
+	at := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	n := alert.Notification{EventID: "evt-1", Type: "service.down", ServiceName: "API", Status: "down", Timestamp: at}
+
+	dead := alert.NewDelivery("pagerduty", n, at)
+	pending := alert.NewDelivery("slack", n, at.Add(time.Hour))
+	require.NoError(t, repo.Enqueue(ctx, []*alert.Delivery{dead, pending}))
+
+	claimed, err := repo.Claim(ctx, at, time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.NoError(t, repo.Bury(ctx, dead.ID, "HTTP 400"))
+
+	handler := NewOutboxHandler(repo, BuildInfo{Version: "test"})
+	handler.now = func() time.Time { return time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC) }
+	return handler, repo
+}
+
+// outboxRouter serves the routes of h as the API does
+func outboxRouter(h *OutboxHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /api/v1/outbox":             h.ListDeliveries,
+		"POST /api/v1/outbox/{id}/retry": h.RetryDelivery,
+	})
+}
+
+func TestOutboxHandler_List(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedChannels []string
+	}{
+		{name: "all states", expectedStatus: http.StatusOK, expectedChannels: []string{"slack", "pagerduty"}},
+		{name: "dead letters", query: "?state=dead", expectedStatus: http.StatusOK, expectedChannels: []string{"pagerduty"}},
+		{name: "with limit", query: "?limit=1", expectedStatus: http.StatusOK, expectedChannels: []string{"slack"}},
+		{name: "unknown state", query: "?state=failed", expectedStatus: http.StatusBadRequest},
+		{name: "zero limit", query: "?limit=0", expectedStatus: http.StatusBadRequest},
+		{name: "limit too large", query: "?limit=501", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestOutboxHandler(t)
+
+			w := serve(outboxRouter(handler), http.MethodGet, "/api/v1/outbox"+tt.query, "")
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var resp OutboxResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			var channels []string
+			for _, d := range resp.Deliveries {
+				channels = append(channels, d.Channel)
+			}
+			assert.Equal(t, tt.expectedChannels, channels)
+			assert.Equal(t, int64(1), resp.Counts[alert.DeliveryPending])
+			assert.Equal(t, int64(1), resp.Counts[alert.DeliveryDead])
+		})
+	}
+}
+
+func TestOutboxHandler_Routes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "retry dead letter", method: http.MethodPost, path: "/api/v1/outbox/1/retry", expectedStatus: http.StatusOK, expectedBody: `"state":"pending"`},
+		{name: "retry pending delivery", method: http.MethodPost, path: "/api/v1/outbox/2/retry", expectedStatus: http.StatusConflict},
+		{name: "retry unknown ID", method: http.MethodPost, path: "/api/v1/outbox/99/retry", expectedStatus: http.StatusNotFound},
+		{name: "retry invalid ID", method: http.MethodPost, path: "/api/v1/outbox/not-an-id/retry", expectedStatus: http.StatusNotFound},
+		{name: "delivery without action", method: http.MethodPost, path: "/api/v1/outbox/1", expectedStatus: http.StatusNotFound},
+		{name: "post to collection", method: http.MethodPost, path: "/api/v1/outbox", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD"},
+		{name: "get retry", method: http.MethodGet, path: "/api/v1/outbox/1/retry", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestOutboxHandler(t)
+
+			w := serve(outboxRouter(handler), tt.method, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestOutboxHandler_Retry(t *testing.T) {
+	handler, repo := newTestOutboxHandler(t)
+
+	w := serve(outboxRouter(handler), http.MethodPost, "/api/v1/outbox/1/retry", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got alert.Delivery
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, alert.DeliveryPending, got.State)
+	assert.Zero(t, got.Attempts)
+	assert.Equal(t, handler.now(), got.NextAttemptAt)
+
+	dead, err := repo.List(context.Background(), alert.DeliveryDead, 10)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+}
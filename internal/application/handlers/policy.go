@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// maxPolicyBodyBytes bounds policy request bodies
+const maxPolicyBodyBytes = 1 << 20
+
+// PolicyHandler serves the notification policy API
+type PolicyHandler struct {
+	*BaseHandler
+	repo alert.PolicyRepository
+}
+
+// NewPolicyHandler creates a policy handler backed by repo
+func NewPolicyHandler(repo alert.PolicyRepository, buildInfo BuildInfo) *PolicyHandler {
+	return &PolicyHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+	}
+}
+
+// ListPolicies lists the policies on GET /api/v1/policies
+func (h *PolicyHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to list policies", err)
+		return
+	}
+	if policies == nil {
+		policies = []*alert.Policy{}
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, policies, "failed to encode policies")
+}
+
+// CreatePolicy creates a policy on POST /api/v1/policies
+func (h *PolicyHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.decode(r)
+	if err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), policy); err != nil {
+		h.WriteDomainError(w, r, "failed to create policy", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	w.Header().Set("Location", "/api/v1/policies/"+policy.ID)
+	w.WriteHeader(http.StatusCreated)
+	h.WriteJSON(w, r, policy, "failed to encode policy")
+}
+
+// GetPolicy reads the policy on GET /api/v1/policies/{id}
+func (h *PolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.repo.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to get policy", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, policy, "failed to encode policy")
+}
+
+// UpdatePolicy replaces the policy on PUT /api/v1/policies/{id}
+func (h *PolicyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.decode(r)
+	if err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+	policy.ID = r.PathValue("id")
+
+	if err := h.repo.Update(r.Context(), policy); err != nil {
+		h.WriteDomainError(w, r, "failed to update policy", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, policy, "failed to encode policy")
+}
+
+// DeletePolicy deletes the policy on DELETE /api/v1/policies/{id}
+func (h *PolicyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	if err := h.repo.Delete(r.Context(), r.PathValue("id")); err != nil {
+		h.WriteDomainError(w, r, "failed to delete policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decode reads a policy from the request body, rejecting unknown fields
+func (h *PolicyHandler) decode(r *http.Request) (*alert.Policy, error) {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxPolicyBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	var policy alert.Policy
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, errors.NewWithCause("invalid policy body", errors.ErrorKindValidation, err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+const testPolicyBody = `{
+	"name": "platform pages",
+	"enabled": true,
+	"match": {"groups": ["platform"]},
+	"severities": ["critical"],
+	"channels": ["pagerduty"],
+	"repeat_interval": "30m",
+	"quiet_hours": {"start": "22:00", "end": "07:00", "timezone": "Europe/Berlin"}
+}`
+
+// newTestPolicyHandler returns a policy handler whose repository holds the
+// policy with ID 1
+func newTestPolicyHandler(t *testing.T) (*PolicyHandler, *memory.PolicyRepository) {
+	t.Helper()
+	repo := memory.NewPolicyRepository()
+	require.NoError(t, repo.Create(context.Background(), &alert.Policy{
+		Name:     "platform chat",
+		Enabled:  true,
+		Channels: []string{"slack"},
+	}))
+	return NewPolicyHandler(repo, BuildInfo{Version: "test"}), repo
+}
+
+// policyRouter serves the routes of h as the API does
+func policyRouter(h *PolicyHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /api/v1/policies":         h.ListPolicies,
+		"POST /api/v1/policies":        h.CreatePolicy,
+		"GET /api/v1/policies/{id}":    h.GetPolicy,
+		"PUT /api/v1/policies/{id}":    h.UpdatePolicy,
+		"DELETE /api/v1/policies/{id}": h.DeletePolicy,
+	})
+}
+
+func TestPolicyHandler_Routes(t *testing.T) {
+	update := `{"name":"platform email","enabled":true,"channels":["email"]}`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "list", method: http.MethodGet, path: "/api/v1/policies", expectedStatus: http.StatusOK, expectedBody: `"name":"platform chat"`},
+		{name: "create", method: http.MethodPost, path: "/api/v1/policies", body: testPolicyBody, expectedStatus: http.StatusCreated, expectedBody: `"name":"platform pages"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/policies/1", expectedStatus: http.StatusOK, expectedBody: `"id":"1"`},
+		{name: "get unknown ID", method: http.MethodGet, path: "/api/v1/policies/99", expectedStatus: http.StatusNotFound},
+		{name: "get invalid ID", method: http.MethodGet, path: "/api/v1/policies/not-an-id", expectedStatus: http.StatusNotFound},
+		{name: "update", method: http.MethodPut, path: "/api/v1/policies/1", body: update, expectedStatus: http.StatusOK, expectedBody: `"name":"platform email"`},
+		{name: "update unknown ID", method: http.MethodPut, path: "/api/v1/policies/99", body: update, expectedStatus: http.StatusNotFound},
+		{name: "update invalid body", method: http.MethodPut, path: "/api/v1/policies/1", body: `{"name":`, expectedStatus: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/policies/1", expectedStatus: http.StatusNoContent},
+		{name: "delete unknown ID", method: http.MethodDelete, path: "/api/v1/policies/99", expectedStatus: http.StatusNotFound},
+		{name: "nested path", method: http.MethodGet, path: "/api/v1/policies/1/channels", expectedStatus: http.StatusNotFound},
+		{name: "delete collection", method: http.MethodDelete, path: "/api/v1/policies", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD, POST"},
+		{name: "post to policy", method: http.MethodPost, path: "/api/v1/policies/1", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, HEAD, PUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestPolicyHandler(t)
+
+			w := serve(policyRouter(handler), tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestPolicyHandler_RejectsInvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "malformed json", body: `{"name":`},
+		{name: "unknown field", body: `{"name":"x","channels":["slack"],"route":"y"}`},
+		{name: "missing channels", body: `{"name":"x"}`},
+		{name: "bad repeat interval", body: `{"name":"x","channels":["slack"],"repeat_interval":"often"}`},
+		{name: "bad quiet hours", body: `{"name":"x","channels":["slack"],"quiet_hours":{"start":"9am","end":"5pm"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestPolicyHandler(t)
+
+			w := serve(policyRouter(handler), http.MethodPost, "/api/v1/policies", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), `"error"`)
+		})
+	}
+}
+
+func TestPolicyHandler_CreateAndUpdate(t *testing.T) {
+	handler, repo := newTestPolicyHandler(t)
+	router := policyRouter(handler)
+
+	w := serve(router, http.MethodPost, "/api/v1/policies", testPolicyBody)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created alert.Policy
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "/api/v1/policies/"+created.ID, w.Header().Get("Location"))
+	assert.Equal(t, []string{"platform"}, created.Match.Groups)
+	assert.Equal(t, alert.Duration(30*time.Minute), created.RepeatInterval)
+	assert.Contains(t, w.Body.String(), `"repeat_interval":"30m0s"`)
+
+	w = serve(router, http.MethodPut, "/api/v1/policies/"+created.ID, `{"name":"platform chat","enabled":true,"channels":["slack","email"]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	updated, err := repo.GetByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slack", "email"}, updated.Channels)
+	assert.Nil(t, updated.QuietHours)
+	assert.WithinDuration(t, created.CreatedAt, updated.CreatedAt, time.Millisecond)
+
+	w = serve(router, http.MethodGet, "/api/v1/policies", "")
+	var policies []alert.Policy
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policies))
+	assert.Len(t, policies, 2)
+}
+
+func TestPolicyHandler_ListEmpty(t *testing.T) {
+	handler := NewPolicyHandler(memory.NewPolicyRepository(), BuildInfo{Version: "test"})
+
+	w := serve(policyRouter(handler), http.MethodGet, "/api/v1/policies", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const (
+	// defaultProbeTimeout bounds probes when Prometheus does not send its scrape timeout
+	defaultProbeTimeout = 10 * time.Second
+	// probeTimeoutOffset leaves time to answer the scrape, as blackbox_exporter does
+	probeTimeoutOffset = 500 * time.Millisecond
+)
+
+// ProbeHandler serves blackbox_exporter-style probes of registered services
+type ProbeHandler struct {
+	*BaseHandler
+	repo   service.Repository
+	prober *checker.Prober
+}
+
+// NewProbeHandler creates a probe handler checking the services of repo with prober
+func NewProbeHandler(repo service.Repository, prober *checker.Prober, buildInfo BuildInfo) *ProbeHandler {
+	return &ProbeHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+		prober:      prober,
+	}
+}
+
+// Probe checks a service on /probe?target=...&module=... and reports the
+// result in the Prometheus text format. The target is the slug or URL of a
+// registered service, so only configured endpoints can be probed.
+func (h *ProbeHandler) Probe(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	target := query.Get("target")
+	if target == "" {
+		h.WriteBadRequestError(w, r, "target parameter is missing", errors.NewValidationError("target parameter is missing"))
+		return
+	}
+
+	moduleName := query.Get("module")
+	if moduleName == "" {
+		moduleName = checker.DefaultProbeModule
+	}
+	module, ok := h.prober.Module(moduleName)
+	if !ok {
+		message := fmt.Sprintf("unknown module %q", moduleName)
+		h.WriteBadRequestError(w, r, message, errors.NewValidationError(message))
+		return
+	}
+
+	svc, err := h.findService(r.Context(), target)
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to find probe target", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), probeTimeout(r))
+	defer cancel()
+
+	result := h.prober.Probe(ctx, *svc, module)
+	promhttp.HandlerFor(probeRegistry(result), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// findService resolves a target by slug, then by URL
+func (h *ProbeHandler) findService(ctx context.Context, target string) (*service.Service, error) {
+	svc, err := h.repo.GetBySlug(ctx, target)
+	if err == nil {
+		return svc, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	services, err := h.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		if svc.URL == target {
+			return svc, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError(fmt.Sprintf("no service with slug or URL %q", target))
+}
+
+// probeTimeout derives the probe deadline from the scrape timeout Prometheus sends
+func probeTimeout(r *http.Request) time.Duration {
+	seconds, err := strconv.ParseFloat(r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds"), 64)
+	if err != nil || seconds <= 0 {
+		return defaultProbeTimeout
+	}
+
+	timeout := time.Duration(seconds*float64(time.Second)) - probeTimeoutOffset
+	if timeout <= 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return timeout
+}
+
+// probeRegistry reports a probe result under the blackbox_exporter metric names
+func probeRegistry(result checker.ProbeResult) *prometheus.Registry {
+	gauge := func(name, help string, value float64) prometheus.Gauge {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help})
+		g.Set(value)
+		return g
+	}
+
+	success := 0.0
+	if result.Success {
+		success = 1
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		gauge("probe_success", "Displays whether or not the probe was a success", success),
+		gauge("probe_duration_seconds", "Returns how long the probe took to complete in seconds", result.Duration.Seconds()),
+		gauge("probe_http_status_code", "Response HTTP status code", float64(result.Log.StatusCode)),
+		gauge("probe_http_latency_seconds", "Latency of the HTTP request the result is based on in seconds", (time.Duration(result.Log.Latency)*time.Millisecond).Seconds()),
+	)
+	return registry
+}
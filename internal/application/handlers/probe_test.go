@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+func newTestProbeHandler(t *testing.T) (*ProbeHandler, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	repo := memory.NewServiceRepository()
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, &service.Service{Name: "API", Slug: "api", URL: server.URL, ExpectedStatus: 200, Enabled: true}))
+	require.NoError(t, repo.Create(ctx, &service.Service{Name: "Login", Slug: "login", URL: server.URL + "/login", ExpectedStatus: 401, Enabled: true}))
+
+	return NewProbeHandler(repo, checker.NewProber(), BuildInfo{Version: "test"}), server
+}
+
+// probeRouter serves the routes of h as the API does
+func probeRouter(h *ProbeHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /probe": h.Probe,
+	})
+}
+
+func TestProbeHandler_Probe(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		expectedStatus int
+		expectedBody   []string
+		expectedAllow  string
+	}{
+		{
+			name:           "target by slug with the default module",
+			method:         http.MethodGet,
+			query:          "target=api",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"probe_success 1", "probe_http_status_code 200", "probe_duration_seconds ", "probe_http_latency_seconds "},
+		},
+		{
+			name:           "target by URL of a registered service",
+			method:         http.MethodGet,
+			query:          "module=http_2xx&target={server}/login",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"probe_success 0", "probe_http_status_code 401"},
+		},
+		{
+			name:           "service module applies the expected status",
+			method:         http.MethodGet,
+			query:          "module=service&target=login",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"probe_success 1"},
+		},
+		{
+			name:           "missing target",
+			method:         http.MethodGet,
+			query:          "module=http_2xx",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{"target parameter is missing"},
+		},
+		{
+			name:           "unknown module",
+			method:         http.MethodGet,
+			query:          "target=api&module=icmp",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`unknown module \"icmp\"`},
+		},
+		{
+			name:           "unregistered target",
+			method:         http.MethodGet,
+			query:          "target=" + url.QueryEscape("http://169.254.169.254/latest/meta-data"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "post",
+			method:         http.MethodPost,
+			query:          "target=api",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedAllow:  "GET, HEAD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, server := newTestProbeHandler(t)
+			query := strings.ReplaceAll(tt.query, "{server}", url.QueryEscape(server.URL))
+
+			w := serve(probeRouter(handler), tt.method, "/probe?"+query, "")
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			for _, expected := range tt.expectedBody {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+			}
+		})
+	}
+}
+
+func TestProbeTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+	}{
+		{name: "no header", header: "", expected: defaultProbeTimeout},
+		{name: "invalid header", header: "nope", expected: defaultProbeTimeout},
+		{name: "leaves a margin", header: "5", expected: 4500 * time.Millisecond},
+		{name: "short timeout", header: "0.25", expected: 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/probe", nil)
+			if tt.header != "" {
+				r.Header.Set("X-Prometheus-Scrape-Timeout-Seconds", tt.header)
+			}
+			assert.Equal(t, tt.expected, probeTimeout(r))
+		})
+	}
+}
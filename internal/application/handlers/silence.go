@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// maxSilenceBodyBytes bounds silence request bodies
+const maxSilenceBodyBytes = 64 << 10
+
+// SilenceHandler serves the silence API
+type SilenceHandler struct {
+	*BaseHandler
+	repo alert.SilenceRepository
+	now  func() time.Time
+}
+
+// SilenceRequest creates a silence. StartsAt defaults to now, and the end
+// is given either as EndsAt or as a Duration from the start.
+type SilenceRequest struct {
+	Match     alert.Matcher  `json:"match"`
+	Channels  []string       `json:"channels,omitempty"`
+	StartsAt  time.Time      `json:"starts_at,omitempty"`
+	EndsAt    time.Time      `json:"ends_at,omitempty"`
+	Duration  alert.Duration `json:"duration,omitempty"`
+	CreatedBy string         `json:"created_by"`
+	Comment   string         `json:"comment"`
+}
+
+// SilenceResponse is a silence with its state at the time of the request
+type SilenceResponse struct {
+	*alert.Silence
+	State string `json:"state"`
+}
+
+// NewSilenceHandler creates a silence handler backed by repo
+func NewSilenceHandler(repo alert.SilenceRepository, buildInfo BuildInfo) *SilenceHandler {
+	return &SilenceHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+		now:         time.Now,
+	}
+}
+
+// ListSilences lists silences on GET /api/v1/silences. The state query
+// parameter filters the list.
+func (h *SilenceHandler) ListSilences(w http.ResponseWriter, r *http.Request) {
+	state := r.URL.Query().Get("state")
+	switch state {
+	case "", alert.SilencePending, alert.SilenceActive, alert.SilenceExpired:
+	default:
+		err := errors.NewValidationError("state must be one of: pending, active, expired")
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	silences, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to list silences", err)
+		return
+	}
+
+	now := h.now().UTC()
+	resp := []SilenceResponse{}
+	for _, silence := range silences {
+		if state == "" || silence.State(now) == state {
+			resp = append(resp, h.response(silence, now))
+		}
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, resp, "failed to encode silences")
+}
+
+// CreateSilence creates a silence on POST /api/v1/silences
+func (h *SilenceHandler) CreateSilence(w http.ResponseWriter, r *http.Request) {
+	now := h.now().UTC()
+	silence, err := h.decode(r, now)
+	if err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), silence); err != nil {
+		h.WriteDomainError(w, r, "failed to create silence", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	w.Header().Set("Location", "/api/v1/silences/"+silence.ID)
+	w.WriteHeader(http.StatusCreated)
+	h.WriteJSON(w, r, h.response(silence, now), "failed to encode silence")
+}
+
+// GetSilence reads the silence on GET /api/v1/silences/{id}
+func (h *SilenceHandler) GetSilence(w http.ResponseWriter, r *http.Request) {
+	silence, err := h.repo.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to get silence", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, h.response(silence, h.now().UTC()), "failed to encode silence")
+}
+
+// ExpireSilence ends the silence on DELETE /api/v1/silences/{id} now
+func (h *SilenceHandler) ExpireSilence(w http.ResponseWriter, r *http.Request) {
+	now := h.now().UTC()
+	silence, err := h.repo.Expire(r.Context(), r.PathValue("id"), now)
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to expire silence", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, h.response(silence, now), "failed to encode silence")
+}
+
+// decode reads a silence request from the body, rejecting unknown fields,
+// and resolves its window relative to now
+func (h *SilenceHandler) decode(r *http.Request, now time.Time) (*alert.Silence, error) {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxSilenceBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	var req SilenceRequest
+	if err := decoder.Decode(&req); err != nil {
+		return nil, errors.NewWithCause("invalid silence body", errors.ErrorKindValidation, err)
+	}
+
+	if !req.EndsAt.IsZero() && req.Duration != 0 {
+		return nil, errors.NewValidationError("set either ends_at or duration, not both")
+	}
+	if req.Duration < 0 {
+		return nil, errors.NewValidationError("duration cannot be negative")
+	}
+
+	silence := &alert.Silence{
+		Match:     req.Match,
+		Channels:  req.Channels,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: req.CreatedBy,
+		Comment:   req.Comment,
+	}
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = now
+	}
+	if req.Duration > 0 {
+		silence.EndsAt = silence.StartsAt.Add(time.Duration(req.Duration))
+	}
+
+	return silence, nil
+}
+
+func (h *SilenceHandler) response(silence *alert.Silence, now time.Time) SilenceResponse {
+	return SilenceResponse{Silence: silence, State: silence.State(now)}
+}
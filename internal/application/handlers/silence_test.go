@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+// newTestSilenceHandler returns a silence handler whose repository holds
+// the active silence 1, the pending silence 2 and the expired silence 3
+func newTestSilenceHandler(t *testing.T) (*SilenceHandler, *memory.SilenceRepository) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	repo := memory.NewSilenceRepository()
+	for _, silence := range []*alert.Silence{
+		{Match: alert.Matcher{Groups: []string{"platform"}}, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour)},
+		{Channels: []string{"pagerduty"}, StartsAt: now.Add(12 * time.Hour), EndsAt: now.Add(16 * time.Hour)},
+		{Match: alert.Matcher{Services: []string{"web"}}, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+	} {
+		silence.CreatedBy = "alice"
+		silence.Comment = "migration"
+		require.NoError(t, repo.Create(ctx, silence))
+	}
+
+	handler := NewSilenceHandler(repo, BuildInfo{Version: "test"})
+	handler.now = func() time.Time { return now }
+	return handler, repo
+}
+
+// silenceRouter serves the routes of h as the API does
+func silenceRouter(h *SilenceHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /api/v1/silences":         h.ListSilences,
+		"POST /api/v1/silences":        h.CreateSilence,
+		"GET /api/v1/silences/{id}":    h.GetSilence,
+		"DELETE /api/v1/silences/{id}": h.ExpireSilence,
+	})
+}
+
+func TestSilenceHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedIDs    []string
+	}{
+		{name: "all states", expectedStatus: http.StatusOK, expectedIDs: []string{"1", "2", "3"}},
+		{name: "active", query: "?state=active", expectedStatus: http.StatusOK, expectedIDs: []string{"1"}},
+		{name: "pending", query: "?state=pending", expectedStatus: http.StatusOK, expectedIDs: []string{"2"}},
+		{name: "expired", query: "?state=expired", expectedStatus: http.StatusOK, expectedIDs: []string{"3"}},
+		{name: "unknown state", query: "?state=muted", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestSilenceHandler(t)
+
+			w := serve(silenceRouter(handler), http.MethodGet, "/api/v1/silences"+tt.query, "")
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var silences []SilenceResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &silences))
+			var ids []string
+			for _, silence := range silences {
+				ids = append(ids, silence.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestSilenceHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedState  string
+		expectedEndsAt time.Time
+	}{
+		{
+			name:           "for a duration from now",
+			body:           `{"match":{"services":["api"]},"duration":"2h","created_by":"alice","comment":"migration"}`,
+			expectedStatus: http.StatusCreated,
+			expectedState:  alert.SilenceActive,
+			expectedEndsAt: time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "scheduled window",
+			body:           `{"channels":["pagerduty"],"starts_at":"2024-01-03T00:00:00Z","ends_at":"2024-01-03T04:00:00Z","created_by":"bob","comment":"night work"}`,
+			expectedStatus: http.StatusCreated,
+			expectedState:  alert.SilencePending,
+			expectedEndsAt: time.Date(2024, 1, 3, 4, 0, 0, 0, time.UTC),
+		},
+		{name: "missing creator", body: `{"match":{"services":["api"]},"duration":"2h","comment":"no creator"}`, expectedStatus: http.StatusBadRequest},
+		{name: "missing end", body: `{"match":{"services":["api"]},"created_by":"alice","comment":"no end"}`, expectedStatus: http.StatusBadRequest},
+		{name: "matches everything", body: `{"duration":"2h","created_by":"alice","comment":"matches everything"}`, expectedStatus: http.StatusBadRequest},
+		{
+			name:           "both end and duration",
+			body:           `{"match":{"services":["api"]},"duration":"2h","ends_at":"2024-01-02T14:00:00Z","created_by":"alice","comment":"both"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "unknown field", body: `{"match":{"services":["api"]},"duration":"2h","created_by":"alice","comment":"x","reason":"unknown"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestSilenceHandler(t)
+
+			w := serve(silenceRouter(handler), http.MethodPost, "/api/v1/silences", tt.body)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+			var created SilenceResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+			assert.Equal(t, "/api/v1/silences/"+created.ID, w.Header().Get("Location"))
+			assert.Equal(t, tt.expectedState, created.State)
+			assert.Equal(t, tt.expectedEndsAt, created.EndsAt.UTC())
+		})
+	}
+}
+
+func TestSilenceHandler_Routes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "get", method: http.MethodGet, path: "/api/v1/silences/1", expectedStatus: http.StatusOK, expectedBody: `"state":"active"`},
+		{name: "get unknown ID", method: http.MethodGet, path: "/api/v1/silences/99", expectedStatus: http.StatusNotFound},
+		{name: "get invalid ID", method: http.MethodGet, path: "/api/v1/silences/not-an-id", expectedStatus: http.StatusNotFound},
+		{name: "expire", method: http.MethodDelete, path: "/api/v1/silences/1", expectedStatus: http.StatusOK, expectedBody: `"state":"expired"`},
+		{name: "expire pending", method: http.MethodDelete, path: "/api/v1/silences/2", expectedStatus: http.StatusOK, expectedBody: `"state":"expired"`},
+		{name: "expire expired", method: http.MethodDelete, path: "/api/v1/silences/3", expectedStatus: http.StatusConflict},
+		{name: "expire unknown ID", method: http.MethodDelete, path: "/api/v1/silences/99", expectedStatus: http.StatusNotFound},
+		{name: "nested path", method: http.MethodGet, path: "/api/v1/silences/1/services", expectedStatus: http.StatusNotFound},
+		{name: "delete collection", method: http.MethodDelete, path: "/api/v1/silences", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD, POST"},
+		{name: "replace silence", method: http.MethodPut, path: "/api/v1/silences/1", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, HEAD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestSilenceHandler(t)
+
+			w := serve(silenceRouter(handler), tt.method, tt.path, "")
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestSilenceHandler_Expire(t *testing.T) {
+	handler, repo := newTestSilenceHandler(t)
+
+	w := serve(silenceRouter(handler), http.MethodDelete, "/api/v1/silences/1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var expired SilenceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &expired))
+	assert.Equal(t, handler.now(), expired.EndsAt)
+
+	stored, err := repo.GetByID(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, alert.SilenceExpired, stored.State(handler.now()))
+}
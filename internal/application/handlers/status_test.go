@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
 	"github.com/sukhera/uptime-monitor/testutil"
 )
 
@@ -125,3 +129,48 @@ func TestStatusHandler_GetStatus(t *testing.T) {
 		})
 	}
 }
+
+// unreachableRepository is a repository whose storage cannot be reached
+type unreachableRepository struct {
+	*memory.ServiceRepository
+}
+
+func (r *unreachableRepository) Ping(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestStatusHandler_GetStatus_WithRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewServiceRepository()
+	require.NoError(t, repo.Create(ctx, testutil.CreateTestService()))
+	require.NoError(t, repo.SaveStatusLog(ctx, testutil.CreateTestStatusLog()))
+
+	handler := NewStatusHandler(repo, BuildInfo{Version: "test"})
+	req := testutil.CreateTestHTTPRequest("GET", "/api/v1/status", nil)
+	w := testutil.CreateTestHTTPResponse()
+
+	handler.GetStatus(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []service.ServiceStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "Test Service", response[0].Name)
+	assert.Equal(t, "operational", response[0].Status)
+}
+
+func TestStatusHandler_HealthCheck_Unhealthy(t *testing.T) {
+	repo := &unreachableRepository{ServiceRepository: memory.NewServiceRepository()}
+	handler := NewStatusHandler(repo, BuildInfo{Version: "test"})
+	req := testutil.CreateTestHTTPRequest("GET", "/api/v1/health", nil)
+	w := testutil.CreateTestHTTPResponse()
+
+	handler.HealthCheck(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "unhealthy", response["status"])
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// maxTemplateBodyBytes bounds template request bodies
+const maxTemplateBodyBytes = 64 << 10
+
+// TemplateHandler serves the message template API
+type TemplateHandler struct {
+	*BaseHandler
+	repo  alert.TemplateRepository
+	store *notifier.TemplateStore
+}
+
+// TemplateRequest replaces the template of a channel
+type TemplateRequest struct {
+	Source    string `json:"source"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
+
+// PreviewRequest renders a template source for a channel. The check
+// defaults to a failed check of an example service; fields set in the
+// request replace the example's.
+type PreviewRequest struct {
+	Channel string        `json:"channel"`
+	Source  string        `json:"source"`
+	Check   *PreviewCheck `json:"check,omitempty"`
+}
+
+// PreviewCheck is the health check a preview is rendered for, and the
+// status of the service before it
+type PreviewCheck struct {
+	ServiceName    string `json:"service_name,omitempty"`
+	ServiceSlug    string `json:"service_slug,omitempty"`
+	Status         string `json:"status,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Latency        int64  `json:"latency_ms,omitempty"`
+	StatusCode     int    `json:"status_code,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// PreviewResponse is a rendered message
+type PreviewResponse struct {
+	Channel string             `json:"channel"`
+	Event   notifier.EventType `json:"event"`
+	Title   string             `json:"title"`
+	Text    string             `json:"text"`
+}
+
+// NewTemplateHandler creates a template handler storing templates in repo
+// and rendering them with the channel defaults of store
+func NewTemplateHandler(repo alert.TemplateRepository, store *notifier.TemplateStore, buildInfo BuildInfo) *TemplateHandler {
+	return &TemplateHandler{
+		BaseHandler: NewBaseHandler(buildInfo),
+		repo:        repo,
+		store:       store,
+	}
+}
+
+// ListTemplates lists the stored templates on GET /api/v1/templates
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.repo.GetAll(r.Context())
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to list templates", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, templates, "failed to encode templates")
+}
+
+// GetTemplate reads the template of the channel on
+// GET /api/v1/templates/{channel}
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	template, err := h.repo.Get(r.Context(), r.PathValue("channel"))
+	if err != nil {
+		h.WriteDomainError(w, r, "failed to get template", err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, template, "failed to encode template")
+}
+
+// UpdateTemplate replaces the template of the channel on
+// PUT /api/v1/templates/{channel}
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req TemplateRequest
+	if err := h.decode(r, &req); err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	channel := r.PathValue("channel")
+	template := &alert.Template{Channel: channel, Source: req.Source, UpdatedBy: req.UpdatedBy}
+	if err := template.Validate(); err != nil {
+		h.WriteDomainError(w, r, "invalid template", err)
+		return
+	}
+	if err := h.check(channel, req.Source); err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	if err := h.repo.Save(r.Context(), template); err != nil {
+		h.WriteDomainError(w, r, "failed to save template", err)
+		return
+	}
+	h.store.Invalidate(channel)
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, template, "failed to encode template")
+}
+
+// DeleteTemplate deletes the template of the channel on
+// DELETE /api/v1/templates/{channel}
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	channel := r.PathValue("channel")
+	if err := h.repo.Delete(r.Context(), channel); err != nil {
+		h.WriteDomainError(w, r, "failed to delete template", err)
+		return
+	}
+	h.store.Invalidate(channel)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Preview renders a template source against a sample health check without
+// storing it, on POST /api/v1/templates/preview
+func (h *TemplateHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req PreviewRequest
+	if err := h.decode(r, &req); err != nil {
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	template := &alert.Template{Channel: req.Channel, Source: req.Source}
+	if err := template.Validate(); err != nil {
+		h.WriteDomainError(w, r, "invalid template", err)
+		return
+	}
+
+	check, previous := previewCheck(req.Check)
+	event, ok := notifier.PreviewEvent(check, previous)
+	if !ok {
+		err := errors.NewValidationError("check must change the status of the service or repeat a failure")
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	msg, err := h.store.Preview(req.Channel, req.Source, event)
+	if err != nil {
+		err = errors.NewWithCause("invalid template", errors.ErrorKindValidation, err)
+		h.WriteBadRequestError(w, r, err.Error(), err)
+		return
+	}
+
+	h.SetJSONHeaders(w)
+	h.WriteJSON(w, r, PreviewResponse{
+		Channel: req.Channel,
+		Event:   event.Type,
+		Title:   msg.Title,
+		Text:    msg.Text,
+	}, "failed to encode preview")
+}
+
+// check renders source for a sample failure, repeat and recovery, so that
+// templates failing on any of them are rejected before they are stored
+func (h *TemplateHandler) check(channel, source string) error {
+	sample := notifier.SampleHealthCheckEvent()
+	recovery := sample
+	recovery.Status = service.StatusOperational
+	recovery.StatusCode = http.StatusOK
+	recovery.Error = ""
+
+	samples := []struct {
+		check    checker.HealthCheckEvent
+		previous string
+	}{
+		{sample, service.StatusOperational},
+		{sample, sample.Status},
+		{recovery, sample.Status},
+	}
+	for _, s := range samples {
+		event, _ := notifier.PreviewEvent(s.check, s.previous)
+		if _, err := h.store.Preview(channel, source, event); err != nil {
+			return errors.NewWithCause("invalid template", errors.ErrorKindValidation, err)
+		}
+	}
+	return nil
+}
+
+// decode reads a JSON request body into v, rejecting unknown fields
+func (h *TemplateHandler) decode(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxTemplateBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return errors.NewWithCause("invalid template body", errors.ErrorKindValidation, err)
+	}
+	return nil
+}
+
+// previewCheck applies the fields set in req over the sample check
+func previewCheck(req *PreviewCheck) (checker.HealthCheckEvent, string) {
+	check := notifier.SampleHealthCheckEvent()
+	previous := service.StatusOperational
+	if req == nil {
+		return check, previous
+	}
+
+	if req.ServiceName != "" {
+		check.ServiceName = req.ServiceName
+	}
+	if req.ServiceSlug != "" {
+		check.ServiceSlug = req.ServiceSlug
+	}
+	if req.Status != "" {
+		check.Status = req.Status
+	}
+	if req.PreviousStatus != "" {
+		previous = req.PreviousStatus
+	}
+	if req.Latency != 0 {
+		check.Latency = req.Latency
+	}
+	if req.StatusCode != 0 {
+		check.StatusCode = req.StatusCode
+	}
+	if req.Error != "" {
+		check.Error = req.Error
+	}
+
+	// A recovered check does not keep the sample's failure
+	if check.Status == service.StatusOperational && req.Error == "" {
+		check.Error = ""
+		if req.StatusCode == 0 {
+			check.StatusCode = http.StatusOK
+		}
+	}
+
+	return check, previous
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
+)
+
+// newTestTemplateHandler returns a template handler whose repository holds
+// a template for slack
+func newTestTemplateHandler(t *testing.T) (*TemplateHandler, *memory.TemplateRepository, *notifier.TemplateStore) {
+	t.Helper()
+	repo := memory.NewTemplateRepository()
+	require.NoError(t, repo.Save(context.Background(), &alert.Template{
+		Channel:   "slack",
+		Source:    `{{define "title"}}{{.ServiceName}} is {{.Status}}{{end}}`,
+		UpdatedBy: "alice",
+	}))
+	store := notifier.NewTemplateStore(repo, notifier.WithTemplateIncidentURL("https://status.example.com/alerts/{alert_id}"))
+	return NewTemplateHandler(repo, store, BuildInfo{Version: "test"}), repo, store
+}
+
+// templateRouter serves the routes of h as the API does
+func templateRouter(h *TemplateHandler) http.Handler {
+	return newTestRouter(map[string]http.HandlerFunc{
+		"GET /api/v1/templates":              h.ListTemplates,
+		"GET /api/v1/templates/{channel}":    h.GetTemplate,
+		"PUT /api/v1/templates/{channel}":    h.UpdateTemplate,
+		"DELETE /api/v1/templates/{channel}": h.DeleteTemplate,
+		"POST /api/v1/templates/preview":     h.Preview,
+	})
+}
+
+func TestTemplateHandler_Routes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}{
+		{name: "list", method: http.MethodGet, path: "/api/v1/templates", expectedStatus: http.StatusOK, expectedBody: `"channel":"slack"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/templates/slack", expectedStatus: http.StatusOK, expectedBody: `"updated_by":"alice"`},
+		{name: "get unknown channel", method: http.MethodGet, path: "/api/v1/templates/email", expectedStatus: http.StatusNotFound},
+		{name: "get preview", method: http.MethodGet, path: "/api/v1/templates/preview", expectedStatus: http.StatusNotFound},
+		{
+			name:           "replace",
+			method:         http.MethodPut,
+			path:           "/api/v1/templates/slack",
+			body:           `{"source":"{{define \"title\"}}{{.ServiceName}}: {{.Status}}{{end}}","updated_by":"bob"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"updated_by":"bob"`,
+		},
+		{
+			name:           "create",
+			method:         http.MethodPut,
+			path:           "/api/v1/templates/email",
+			body:           `{"source":"{{define \"title\"}}{{.ServiceName}}{{end}}"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"channel":"email"`,
+		},
+		{
+			name:           "replace with a template failing to render",
+			method:         http.MethodPut,
+			path:           "/api/v1/templates/slack",
+			body:           `{"source":"{{define \"text\"}}{{.Grouped.Missing}}{{end}}"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "replace with malformed body", method: http.MethodPut, path: "/api/v1/templates/slack", body: `{"source":`, expectedStatus: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/templates/slack", expectedStatus: http.StatusNoContent},
+		{name: "delete unknown channel", method: http.MethodDelete, path: "/api/v1/templates/email", expectedStatus: http.StatusNotFound},
+		{name: "nested path", method: http.MethodGet, path: "/api/v1/templates/slack/title", expectedStatus: http.StatusNotFound},
+		{name: "post to collection", method: http.MethodPost, path: "/api/v1/templates", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD"},
+		{name: "post to channel", method: http.MethodPost, path: "/api/v1/templates/slack", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, HEAD, PUT"},
+		{name: "patch preview", method: http.MethodPatch, path: "/api/v1/templates/preview", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "DELETE, GET, HEAD, POST, PUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _ := newTestTemplateHandler(t)
+
+			w := serve(templateRouter(handler), tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestTemplateHandler_Preview(t *testing.T) {
+	handler, repo, _ := newTestTemplateHandler(t)
+
+	tests := []struct {
+		name      string
+		body      string
+		wantEvent notifier.EventType
+		wantTitle string
+		wantText  string
+	}{
+		{
+			name:      "sample failure",
+			body:      `{"channel":"slack","source":"{{define \"title\"}}[{{upper .Status}}] {{.ServiceName}} HTTP {{.StatusCode}} in {{.Latency}}ms{{end}}"}`,
+			wantEvent: notifier.EventDown,
+			wantTitle: "[DOWN] API HTTP 503 in 1532ms",
+			wantText:  "API changed from operational to down. Error: unexpected status code: 503\nhttps://status.example.com/alerts/preview",
+		},
+		{
+			name:      "custom recovery",
+			body:      `{"channel":"telegram","source":"{{define \"text\"}}{{.ServiceName}} back after {{formatDuration .OutageDuration}}{{end}}","check":{"service_name":"Web","status":"operational","previous_status":"down"}}`,
+			wantEvent: notifier.EventRecovered,
+			wantTitle: "Resolved: Web is operational again",
+			wantText:  "Web back after 25m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(templateRouter(handler), http.MethodPost, "/api/v1/templates/preview", tt.body)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var preview PreviewResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+			assert.Equal(t, tt.wantEvent, preview.Event)
+			assert.Equal(t, tt.wantTitle, preview.Title)
+			assert.Equal(t, tt.wantText, preview.Text)
+		})
+	}
+
+	// Previews are not stored
+	templates, err := repo.GetAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, templates, 1)
+}
+
+func TestTemplateHandler_PreviewErrors(t *testing.T) {
+	handler, _, _ := newTestTemplateHandler(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "syntax error", body: `{"channel":"slack","source":"{{define \"title\"}}{{.Status}"}`},
+		{name: "unknown field", body: `{"channel":"slack","source":"{{define \"title\"}}{{.Missing}}{{end}}"}`},
+		{name: "missing channel", body: `{"source":"{{define \"title\"}}x{{end}}"}`},
+		{name: "no notification", body: `{"channel":"slack","source":"x","check":{"status":"operational"}}`},
+		{name: "unknown request field", body: `{"channel":"slack","source":"x","extra":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(templateRouter(handler), http.MethodPost, "/api/v1/templates/preview", tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestTemplateHandler_SaveAndDeleteTakeEffect(t *testing.T) {
+	handler, _, store := newTestTemplateHandler(t)
+	router := templateRouter(handler)
+	ctx := context.Background()
+
+	event, ok := notifier.PreviewEvent(notifier.SampleHealthCheckEvent(), "operational")
+	require.True(t, ok)
+	msg, err := store.Template(ctx, "slack").Render(event)
+	require.NoError(t, err)
+	assert.Equal(t, "API is down", msg.Title)
+
+	w := serve(router, http.MethodPut, "/api/v1/templates/slack", `{"source":"{{define \"title\"}}{{.ServiceName}}: {{.Status}}{{end}}","updated_by":"bob"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var saved alert.Template
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &saved))
+	assert.Equal(t, "slack", saved.Channel)
+	assert.Equal(t, "bob", saved.UpdatedBy)
+	assert.False(t, saved.UpdatedAt.IsZero())
+
+	msg, err = store.Template(ctx, "slack").Render(event)
+	require.NoError(t, err)
+	assert.Equal(t, "API: down", msg.Title)
+
+	w = serve(router, http.MethodDelete, "/api/v1/templates/slack", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	msg, err = store.Template(ctx, "slack").Render(event)
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: API is down", msg.Title)
+}
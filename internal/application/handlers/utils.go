@@ -3,9 +3,11 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"net/http"
 	"time"
 
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
 )
 
@@ -21,8 +23,12 @@ func NewBaseHandler(buildInfo BuildInfo) *BaseHandler {
 
 // LogError logs an error with structured logging
 func (h *BaseHandler) LogError(message string, err error) {
+	h.logError(context.Background(), message, err)
+}
+
+// logError logs an error with the request values of ctx
+func (h *BaseHandler) logError(ctx context.Context, message string, err error) {
 	log := logger.Get()
-	ctx := context.Background()
 	log.Error(ctx, message, err, nil)
 }
 
@@ -46,43 +52,74 @@ func (h *BaseHandler) SetHealthJSONHeaders(w http.ResponseWriter) {
 	w.Header().Set("Cache-Control", "no-cache")
 }
 
-// WriteJSONError writes a JSON error response with logging
-func (h *BaseHandler) WriteJSONError(w http.ResponseWriter, message string, err error, statusCode int) {
-	h.LogError(message, err)
+// WriteJSONError writes a JSON error response with logging. The ID given to
+// the request by middleware.RequestID is included so clients can quote it
+// when reporting the error.
+func (h *BaseHandler) WriteJSONError(w http.ResponseWriter, r *http.Request, message string, err error, statusCode int) {
+	ctx := r.Context()
+	requestID := logger.RequestIDFromContext(ctx)
+
+	h.logError(ctx, message, err)
 	h.SetJSONHeaders(w)
 	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+
+	body := map[string]interface{}{
 		"error":     message,
 		"timestamp": time.Now().UTC(),
-	}); err != nil {
-		h.LogError("Failed to encode error response", err)
+	}
+	if requestID != "" {
+		body["request_id"] = requestID
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.logError(ctx, "Failed to encode error response", err)
 	}
 }
 
 // WriteInternalServerError writes an internal server error with logging
-func (h *BaseHandler) WriteInternalServerError(w http.ResponseWriter, message string, err error) {
-	h.WriteJSONError(w, message, err, http.StatusInternalServerError)
+func (h *BaseHandler) WriteInternalServerError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	h.WriteJSONError(w, r, message, err, http.StatusInternalServerError)
 }
 
 // WriteBadRequestError writes a bad request error with logging
-func (h *BaseHandler) WriteBadRequestError(w http.ResponseWriter, message string, err error) {
-	h.WriteJSONError(w, message, err, http.StatusBadRequest)
+func (h *BaseHandler) WriteBadRequestError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	h.WriteJSONError(w, r, message, err, http.StatusBadRequest)
 }
 
 // WriteNotFoundError writes a not found error with logging
-func (h *BaseHandler) WriteNotFoundError(w http.ResponseWriter, message string, err error) {
-	h.WriteJSONError(w, message, err, http.StatusNotFound)
+func (h *BaseHandler) WriteNotFoundError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	h.WriteJSONError(w, r, message, err, http.StatusNotFound)
+}
+
+// WriteDomainError writes err with the status code matching its kind. Client
+// errors carry the error text; anything else is reported as an internal error.
+func (h *BaseHandler) WriteDomainError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	var domainErr errors.Error
+	if !stderrors.As(err, &domainErr) {
+		h.WriteInternalServerError(w, r, message, err)
+		return
+	}
+
+	switch domainErr.Kind() {
+	case errors.ErrorKindValidation:
+		h.WriteBadRequestError(w, r, err.Error(), err)
+	case errors.ErrorKindNotFound:
+		h.WriteNotFoundError(w, r, err.Error(), err)
+	case errors.ErrorKindConflict:
+		h.WriteJSONError(w, r, err.Error(), err, http.StatusConflict)
+	default:
+		h.WriteInternalServerError(w, r, message, err)
+	}
 }
 
 // WriteJSON encodes and writes a JSON response with error handling
-func (h *BaseHandler) WriteJSON(w http.ResponseWriter, data interface{}, errorMessage string) {
+func (h *BaseHandler) WriteJSON(w http.ResponseWriter, r *http.Request, data interface{}, errorMessage string) {
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.WriteInternalServerError(w, errorMessage, err)
+		h.WriteInternalServerError(w, r, errorMessage, err)
 	}
 }
 
 // WriteJSONWithHeaders writes JSON response with custom headers
-func (h *BaseHandler) WriteJSONWithHeaders(w http.ResponseWriter, data interface{}, errorMessage string, headerSetter func(http.ResponseWriter)) {
+func (h *BaseHandler) WriteJSONWithHeaders(w http.ResponseWriter, r *http.Request, data interface{}, errorMessage string, headerSetter func(http.ResponseWriter)) {
 	headerSetter(w)
-	h.WriteJSON(w, data, errorMessage)
+	h.WriteJSON(w, r, data, errorMessage)
 }
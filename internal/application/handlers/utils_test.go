@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/testutil"
+)
+
+func TestBaseHandler_WriteJSONError_RequestID(t *testing.T) {
+	h := NewBaseHandler(BuildInfo{Version: "test"})
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{name: "includes the request ID", requestID: "req-42"},
+		{name: "omits a missing request ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testutil.CreateTestHTTPRequest(http.MethodPost, "/api/v1/services", nil)
+			if tt.requestID != "" {
+				r = r.WithContext(logger.WithRequestID(r.Context(), tt.requestID))
+			}
+			w := testutil.CreateTestHTTPResponse()
+			h.WriteBadRequestError(w, r, "invalid service", errors.NewValidationError("invalid service"))
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "invalid service", body["error"])
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, body["request_id"])
+			} else {
+				assert.NotContains(t, body, "request_id")
+			}
+		})
+	}
+}
+
+// newTestRouter registers handlers by ServeMux pattern on a new ServeMux, as
+// the API does, so requests get their path values and unserved methods a 405
+func newTestRouter(routes map[string]http.HandlerFunc) *http.ServeMux {
+	router := http.NewServeMux()
+	for pattern, handler := range routes {
+		router.HandleFunc(pattern, handler)
+	}
+	return router
+}
+
+// serve sends a request with the given method, path and body through router
+func serve(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	w := testutil.CreateTestHTTPResponse()
+	router.ServeHTTP(w, testutil.CreateTestHTTPRequest(method, path, reader))
+	return w
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIPResolver finds the address of the client behind trusted reverse
+// proxies. Forwarded headers are only honoured when the peer is a trusted
+// proxy, as any client can send them.
+type ClientIPResolver struct {
+	trusted []*net.IPNet
+}
+
+// NewClientIPResolver creates a resolver trusting the given proxies, each a
+// CIDR range or a single IP address
+func NewClientIPResolver(trustedProxies []string) (*ClientIPResolver, error) {
+	trusted, err := ParseCIDRs(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	return &ClientIPResolver{trusted: trusted}, nil
+}
+
+// ParseCIDRs parses CIDR ranges, reading single IP addresses as ranges of
+// one address
+func ParseCIDRs(values []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR range", value)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			value = fmt.Sprintf("%s/%d", value, bits)
+		}
+
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR range", value)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// ClientIP returns the client address of r. When the peer is a trusted
+// proxy, X-Forwarded-For is read from the right and the first address that
+// is not a trusted proxy is the client; X-Real-IP is used when there is no
+// X-Forwarded-For. A nil resolver trusts no proxies.
+func (res *ClientIPResolver) ClientIP(r *http.Request) string {
+	peer := remoteIP(r)
+	if res == nil || !res.isTrusted(peer) {
+		return peer
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		client := peer
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := net.ParseIP(strings.TrimSpace(hops[i]))
+			if hop == nil {
+				// A malformed hop was not written by a trusted proxy
+				break
+			}
+			client = hop.String()
+			if !res.isTrusted(client) {
+				break
+			}
+		}
+		return client
+	}
+
+	if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+
+	return peer
+}
+
+// isTrusted reports whether ip belongs to a trusted proxy
+func (res *ClientIPResolver) isTrusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range res.trusted {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the address of the peer that sent r
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return host
+}
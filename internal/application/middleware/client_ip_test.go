@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIPResolver_ClientIP(t *testing.T) {
+	resolver, err := NewClientIPResolver([]string{"10.0.0.0/8", "192.0.2.1", "fd00::/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		expected   string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:51000",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "forwarded headers from untrusted peers are ignored",
+			remoteAddr: "203.0.113.7:51000",
+			forwarded:  []string{"198.51.100.1"},
+			realIP:     "198.51.100.2",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "client behind a trusted proxy",
+			remoteAddr: "10.0.0.2:51000",
+			forwarded:  []string{"198.51.100.1"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "spoofed entries left of the client are ignored",
+			remoteAddr: "10.0.0.2:51000",
+			forwarded:  []string{"1.2.3.4, 198.51.100.1, 192.0.2.1"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "repeated headers are read as one list",
+			remoteAddr: "10.0.0.2:51000",
+			forwarded:  []string{"198.51.100.1", "10.1.1.1"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "only trusted proxies",
+			remoteAddr: "10.0.0.2:51000",
+			forwarded:  []string{"10.0.0.3, 10.0.0.4"},
+			expected:   "10.0.0.3",
+		},
+		{
+			name:       "malformed hop stops the walk",
+			remoteAddr: "10.0.0.2:51000",
+			forwarded:  []string{"198.51.100.1, not-an-ip"},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "X-Real-IP from a trusted proxy",
+			remoteAddr: "[fd00::1]:51000",
+			realIP:     "2001:db8::7",
+			expected:   "2001:db8::7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			assert.Equal(t, tt.expected, resolver.ClientIP(req))
+		})
+	}
+}
+
+func TestClientIPResolver_NilTrustsNoProxies(t *testing.T) {
+	var resolver *ClientIPResolver
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.2:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "10.0.0.2", resolver.ClientIP(req))
+}
+
+func TestParseCIDRs(t *testing.T) {
+	nets, err := ParseCIDRs([]string{"10.0.0.0/8", " 192.0.2.1 ", "2001:db8::1"})
+	require.NoError(t, err)
+	require.Len(t, nets, 3)
+	assert.Equal(t, "192.0.2.1/32", nets[1].String())
+	assert.Equal(t, "2001:db8::1/128", nets[2].String())
+
+	for _, invalid := range []string{"", "10.0.0.0/33", "example.com"} {
+		_, err := ParseCIDRs([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
@@ -7,6 +7,8 @@ import (
 func NewCORS() *cors.Cors {
 	return cors.New(cors.Options{
 		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "OPTIONS"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Content-Type", "X-Requested-With", RequestIDHeader},
+		ExposedHeaders: []string{RequestIDHeader},
 	})
 }
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := NewCORS().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		allowed bool
+	}{
+		{name: "GET", method: http.MethodGet, allowed: true},
+		{name: "POST", method: http.MethodPost, allowed: true},
+		{name: "PUT", method: http.MethodPut, allowed: true},
+		{name: "DELETE", method: http.MethodDelete, allowed: true},
+		{name: "PATCH is not served", method: http.MethodPatch, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/policies/1", nil)
+			req.Header.Set("Origin", "https://status.example.com")
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.allowed {
+				assert.Equal(t, tt.method, rec.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// IPFilterRules lists the client addresses allowed and denied on a set of
+// routes. Addresses are CIDR ranges or single IPs, and paths are route
+// prefixes; the rules apply to every route when Paths is empty.
+type IPFilterRules struct {
+	Allow []string
+	Deny  []string
+	Paths []string
+}
+
+// ipRules are parsed IPFilterRules
+type ipRules struct {
+	allow []*net.IPNet
+	deny  []*net.IPNet
+	paths []string
+}
+
+// IPFilter rejects requests whose client address is denied, or not allowed
+// when an allowlist is set. Denied ranges win over allowed ones. Clients are
+// resolved behind trusted proxies, and the rules can be replaced while
+// serving.
+type IPFilter struct {
+	resolver *ClientIPResolver
+	rules    atomic.Pointer[ipRules]
+}
+
+// IPFilterOption is a function that configures an IPFilter
+type IPFilterOption func(*IPFilter)
+
+// WithIPFilterResolver resolves client IPs behind trusted proxies
+func WithIPFilterResolver(resolver *ClientIPResolver) IPFilterOption {
+	return func(f *IPFilter) {
+		f.resolver = resolver
+	}
+}
+
+// NewIPFilter creates a filter applying rules. It fails when an address is
+// not a CIDR range or IP.
+func NewIPFilter(rules IPFilterRules, options ...IPFilterOption) (*IPFilter, error) {
+	f := &IPFilter{}
+	for _, option := range options {
+		option(f)
+	}
+
+	if err := f.Update(rules); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Update replaces the rules of the filter. The current rules are kept when
+// the new ones are invalid.
+func (f *IPFilter) Update(rules IPFilterRules) error {
+	allow, err := ParseCIDRs(rules.Allow)
+	if err != nil {
+		return fmt.Errorf("invalid IP allowlist: %w", err)
+	}
+	deny, err := ParseCIDRs(rules.Deny)
+	if err != nil {
+		return fmt.Errorf("invalid IP denylist: %w", err)
+	}
+
+	paths := make([]string, 0, len(rules.Paths))
+	for _, p := range rules.Paths {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+
+	f.rules.Store(&ipRules{allow: allow, deny: deny, paths: paths})
+	return nil
+}
+
+// Handler middleware answers 403 to clients the rules reject
+func (f *IPFilter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rules := f.rules.Load()
+		if !rules.appliesTo(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		clientIP := f.resolver.ClientIP(r)
+		if !rules.allows(net.ParseIP(clientIP)) {
+			logger.Get().Warn(r.Context(), "Rejected request from filtered IP", logger.Fields{
+				"client_ip": clientIP,
+				"path":      r.URL.Path,
+			})
+			writeForbidden(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// appliesTo reports whether the rules cover requests to urlPath. A path
+// prefix matches whole segments, so /metrics does not cover /metricsx.
+func (rules *ipRules) appliesTo(urlPath string) bool {
+	if len(rules.allow) == 0 && len(rules.deny) == 0 {
+		return false
+	}
+	if len(rules.paths) == 0 {
+		return true
+	}
+
+	cleaned := path.Clean("/" + urlPath)
+	for _, prefix := range rules.paths {
+		trimmed := strings.TrimSuffix(prefix, "/")
+		if cleaned == trimmed || strings.HasPrefix(cleaned, trimmed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether ip may pass. Unparseable addresses only pass when
+// no allowlist is set.
+func (rules *ipRules) allows(ip net.IP) bool {
+	if ip == nil {
+		return len(rules.allow) == 0
+	}
+	if containsIP(rules.deny, ip) {
+		return false
+	}
+	return len(rules.allow) == 0 || containsIP(rules.allow, ip)
+}
+
+// containsIP reports whether one of nets contains ip
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeForbidden answers 403 in the JSON error format of the API
+func writeForbidden(w http.ResponseWriter, r *http.Request) {
+	body := map[string]interface{}{
+		"error":     "forbidden",
+		"timestamp": time.Now().UTC(),
+	}
+	if requestID := logger.RequestIDFromContext(r.Context()); requestID != "" {
+		body["request_id"] = requestID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFilter_Handler(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      IPFilterRules
+		remoteAddr string
+		path       string
+		expected   int
+	}{
+		{
+			name:       "no lists allow everyone",
+			remoteAddr: "203.0.113.7:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusOK,
+		},
+		{
+			name:       "allowed range",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}},
+			remoteAddr: "203.0.113.7:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusOK,
+		},
+		{
+			name:       "outside the allowlist",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}},
+			remoteAddr: "198.51.100.1:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusForbidden,
+		},
+		{
+			name:       "denylist wins over allowlist",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}, Deny: []string{"203.0.113.7"}},
+			remoteAddr: "203.0.113.7:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusForbidden,
+		},
+		{
+			name:       "denied IPv6 range",
+			rules:      IPFilterRules{Deny: []string{"2001:db8::/32"}},
+			remoteAddr: "[2001:db8::1]:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusForbidden,
+		},
+		{
+			name:       "IPv4-mapped IPv6 client",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}},
+			remoteAddr: "[::ffff:203.0.113.7]:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusOK,
+		},
+		{
+			name:       "route outside the filtered paths",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}, Paths: []string{"/api/v1/debug", "/metrics"}},
+			remoteAddr: "198.51.100.1:1000",
+			path:       "/api/v1/status",
+			expected:   http.StatusOK,
+		},
+		{
+			name:       "route below a filtered path",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}, Paths: []string{"/api/v1/silences/"}},
+			remoteAddr: "198.51.100.1:1000",
+			path:       "/api/v1/silences/abc",
+			expected:   http.StatusForbidden,
+		},
+		{
+			name:       "filtered paths match whole segments",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}, Paths: []string{"/metrics"}},
+			remoteAddr: "198.51.100.1:1000",
+			path:       "/metricsx",
+			expected:   http.StatusOK,
+		},
+		{
+			name:       "unclean paths are matched once cleaned",
+			rules:      IPFilterRules{Allow: []string{"203.0.113.0/24"}, Paths: []string{"/metrics"}},
+			remoteAddr: "198.51.100.1:1000",
+			path:       "/api/../metrics",
+			expected:   http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewIPFilter(tt.rules)
+			require.NoError(t, err)
+			handler := filter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = tt.path
+			req.RemoteAddr = tt.remoteAddr
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestIPFilter_ProxiedRequests(t *testing.T) {
+	resolver, err := NewClientIPResolver([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	filter, err := NewIPFilter(IPFilterRules{Deny: []string{"203.0.113.7"}}, WithIPFilterResolver(resolver))
+	require.NoError(t, err)
+	handler := filter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request("10.0.0.2:1000", "203.0.113.7")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "forbidden", body["error"])
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:1000", "198.51.100.1").Code)
+
+	// Untrusted peers cannot hide behind a forged header
+	assert.Equal(t, http.StatusForbidden, request("203.0.113.7:1000", "198.51.100.1").Code)
+}
+
+func TestNewIPFilter_InvalidRules(t *testing.T) {
+	_, err := NewIPFilter(IPFilterRules{Allow: []string{"10.0.0.0/8", "office"}})
+	assert.Error(t, err)
+
+	_, err = NewIPFilter(IPFilterRules{Deny: []string{"2001:db8::/129"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+// RouteMatcher reports the pattern a request is routed to, as
+// http.ServeMux.Handler does
+type RouteMatcher interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// Metrics records the duration of each request under the route pattern that
+// matched it. Requests that match no route are recorded as "unmatched".
+func Metrics(m *metrics.Metrics, routes RouteMatcher) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			route := routeOf(routes, r)
+
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(ww, r)
+
+			m.ObserveRequest(r.Method, route, ww.statusCode, time.Since(start))
+		})
+	}
+}
+
+// routeOf returns the path pattern r is routed to, without the method of
+// method-aware patterns, or "unmatched"
+func routeOf(routes RouteMatcher, r *http.Request) string {
+	_, pattern := routes.Handler(r)
+	if pattern == "" {
+		return "unmatched"
+	}
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		pattern = strings.TrimLeft(pattern[i:], " \t")
+	}
+	return pattern
+}
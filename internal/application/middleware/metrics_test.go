@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+func TestMetrics_RecordsRoutePattern(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/alerts/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	router.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {})
+
+	m := metrics.New()
+	handler := Metrics(m, router)(router)
+
+	for _, path := range []string{"/api/v1/alerts/1", "/api/v1/alerts/2", "/api/v1/status", "/nope"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="404",method="GET",route="/api/v1/alerts/"} 2`)
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="200",method="GET",route="/api/v1/status"} 1`)
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="404",method="GET",route="unmatched"} 1`)
+	assert.NotContains(t, body, `route="/api/v1/alerts/1"`)
+}
+
+func TestMetrics_RecordsMethodPatternsByPath(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /api/v1/silences/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	m := metrics.New()
+	handler := Metrics(m, router)(router)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/silences/abc", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/silences/abc", nil))
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="200",method="GET",route="/api/v1/silences/{id}"} 1`)
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="405",method="POST",route="unmatched"} 1`)
+}
@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+// Pipeline is the ordered middleware stack of the API server, with each
+// middleware enabled and configured by config.MiddlewareConfig
+type Pipeline struct {
+	config         config.MiddlewareConfig
+	routes         RouteMatcher
+	metrics        *metrics.Metrics
+	tracerProvider trace.TracerProvider
+	apiVersion     string
+	resolver       *ClientIPResolver
+	ipFilter       *IPFilter
+}
+
+// PipelineOption is a function that configures a Pipeline
+type PipelineOption func(*Pipeline)
+
+// WithPipelineMetrics records request durations in m
+func WithPipelineMetrics(m *metrics.Metrics) PipelineOption {
+	return func(p *Pipeline) {
+		p.metrics = m
+	}
+}
+
+// WithPipelineTracerProvider records request spans with tp instead of the
+// global provider
+func WithPipelineTracerProvider(tp trace.TracerProvider) PipelineOption {
+	return func(p *Pipeline) {
+		p.tracerProvider = tp
+	}
+}
+
+// WithPipelineAPIVersion sets the version reported in the API-Version
+// headers; an empty version leaves the headers out
+func WithPipelineAPIVersion(version string) PipelineOption {
+	return func(p *Pipeline) {
+		p.apiVersion = version
+	}
+}
+
+// NewPipeline creates the pipeline for a server whose routes are matched by
+// routes. It fails when the trusted proxies or IP lists are not valid CIDR
+// ranges or IPs.
+func NewPipeline(cfg config.MiddlewareConfig, routes RouteMatcher, options ...PipelineOption) (*Pipeline, error) {
+	resolver, err := NewClientIPResolver(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	ipFilter, err := NewIPFilter(ipFilterRules(cfg), WithIPFilterResolver(resolver))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Pipeline{
+		config:         cfg,
+		routes:         routes,
+		tracerProvider: otel.GetTracerProvider(),
+		apiVersion:     "v1",
+		resolver:       resolver,
+		ipFilter:       ipFilter,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p, nil
+}
+
+// Reload applies the IP allow and deny lists of cfg to the running
+// pipeline. Other settings only take effect on restart.
+func (p *Pipeline) Reload(cfg config.MiddlewareConfig) error {
+	return p.ipFilter.Update(ipFilterRules(cfg))
+}
+
+// ipFilterRules returns the IP lists of cfg
+func ipFilterRules(cfg config.MiddlewareConfig) IPFilterRules {
+	return IPFilterRules{
+		Allow: cfg.IPAllowlist,
+		Deny:  cfg.IPDenylist,
+		Paths: cfg.IPFilterPaths,
+	}
+}
+
+// stage is a pipeline middleware under the name it is reported as
+type stage struct {
+	name       string
+	enabled    bool
+	middleware func() Middleware
+}
+
+// stages lists the middlewares outermost first. Request IDs are assigned
+// before anything logs, and tracing, metrics and request logs wrap recovery
+// so they record the 500 of a panicking handler. IP filtering and rate
+// limiting run after CORS so rejected browser requests can still read the
+// response, and filtered clients do not use up rate limit buckets. The IP
+// filter is always installed so reloads can add lists.
+func (p *Pipeline) stages() []stage {
+	cfg := p.config
+
+	return []stage{
+		{name: "request_id", enabled: cfg.RequestID, middleware: func() Middleware {
+			return RequestID
+		}},
+		{name: "tracing", enabled: true, middleware: func() Middleware {
+			return Tracing(p.tracerProvider, p.routes)
+		}},
+		{name: "metrics", enabled: p.metrics != nil, middleware: func() Middleware {
+			return Metrics(p.metrics, p.routes)
+		}},
+		{name: "request_logging", enabled: cfg.RequestLogging, middleware: func() Middleware {
+			return RequestLogger
+		}},
+		{name: "recovery", enabled: cfg.Recovery, middleware: func() Middleware {
+			return Recovery
+		}},
+		{name: "security_headers", enabled: cfg.SecurityHeaders, middleware: func() Middleware {
+			return SecurityHeaders
+		}},
+		{name: "cors", enabled: cfg.CORS, middleware: func() Middleware {
+			return NewCORS().Handler
+		}},
+		{name: "ip_filter", enabled: true, middleware: func() Middleware {
+			return p.ipFilter.Handler
+		}},
+		{name: "rate_limit", enabled: cfg.RateLimit > 0, middleware: func() Middleware {
+			return NewRateLimiter(cfg.RateLimit,
+				WithRateLimitBurst(cfg.RateLimitBurst),
+				WithRateLimitKeyHeader(cfg.RateLimitKeyHeader),
+				WithClientIPResolver(p.resolver),
+			).RateLimit
+		}},
+		{name: "request_timeout", enabled: cfg.RequestTimeout > 0, middleware: func() Middleware {
+			return RequestTimeout(cfg.RequestTimeout)
+		}},
+		{name: "api_version", enabled: p.apiVersion != "", middleware: func() Middleware {
+			return APIVersion(p.apiVersion)
+		}},
+	}
+}
+
+// Names returns the names of the enabled middlewares, outermost first
+func (p *Pipeline) Names() []string {
+	var names []string
+	for _, s := range p.stages() {
+		if s.enabled {
+			names = append(names, s.name)
+		}
+	}
+	return names
+}
+
+// Middlewares returns the enabled middlewares, outermost first
+func (p *Pipeline) Middlewares() []Middleware {
+	var middlewares []Middleware
+	for _, s := range p.stages() {
+		if s.enabled {
+			middlewares = append(middlewares, s.middleware())
+		}
+	}
+	return middlewares
+}
+
+// Then wraps handler in the enabled middlewares
+func (p *Pipeline) Then(handler http.Handler) http.Handler {
+	return Chain(handler, p.Middlewares()...)
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+func newTestPipeline(t *testing.T, cfg config.MiddlewareConfig, routes RouteMatcher, options ...PipelineOption) *Pipeline {
+	t.Helper()
+	p, err := NewPipeline(cfg, routes, options...)
+	require.NoError(t, err)
+	return p
+}
+
+func TestPipeline_Names(t *testing.T) {
+	defaults := newTestPipeline(t, config.New().Middleware, http.NewServeMux(), WithPipelineMetrics(metrics.New()))
+	assert.Equal(t, []string{
+		"request_id", "tracing", "metrics", "request_logging", "recovery",
+		"security_headers", "cors", "ip_filter", "request_timeout", "api_version",
+	}, defaults.Names())
+
+	minimal := newTestPipeline(t, config.MiddlewareConfig{RateLimit: 5}, http.NewServeMux(), WithPipelineAPIVersion(""))
+	assert.Equal(t, []string{"tracing", "ip_filter", "rate_limit"}, minimal.Names())
+	assert.Len(t, minimal.Middlewares(), 3)
+}
+
+func TestPipeline_RecoversPanics(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/boom", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	m := metrics.New()
+	handler := newTestPipeline(t, config.New().Middleware, router, WithPipelineMetrics(m)).Then(router)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/boom", nil))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "v1", rec.Header().Get("API-Version"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "internal server error", body["error"])
+	assert.Equal(t, rec.Header().Get(RequestIDHeader), body["request_id"])
+
+	// The recovered response is observed by the outer middlewares
+	rec = httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `uptime_http_request_duration_seconds_count{code="500",method="GET",route="/api/v1/boom"} 1`)
+}
+
+func TestPipeline_RateLimit(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {})
+
+	handler := newTestPipeline(t, config.MiddlewareConfig{RateLimit: 0.5}, router).Then(router)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/status", nil))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestPipeline_RateLimitsClientsBehindTrustedProxies(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {})
+
+	handler := newTestPipeline(t, config.MiddlewareConfig{RateLimit: 0.5, TrustedProxies: []string{"10.0.0.0/8"}}, router).Then(router)
+	request := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+		req.RemoteAddr = "10.0.0.2:41000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("203.0.113.7"))
+	assert.Equal(t, http.StatusOK, request("198.51.100.1"), "clients behind the proxy have their own buckets")
+	assert.Equal(t, http.StatusTooManyRequests, request("203.0.113.7"))
+}
+
+func TestNewPipeline_InvalidTrustedProxy(t *testing.T) {
+	_, err := NewPipeline(config.MiddlewareConfig{TrustedProxies: []string{"10.0.0.0/33"}}, http.NewServeMux())
+	assert.Error(t, err)
+
+	_, err = NewPipeline(config.MiddlewareConfig{IPDenylist: []string{"example.com"}}, http.NewServeMux())
+	assert.Error(t, err)
+}
+
+func TestPipeline_ReloadsIPLists(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/api/v1/debug", func(w http.ResponseWriter, r *http.Request) {})
+	router.HandleFunc("/api/v1/status", func(w http.ResponseWriter, r *http.Request) {})
+
+	p := newTestPipeline(t, config.MiddlewareConfig{TrustedProxies: []string{"10.0.0.0/8"}}, router)
+	handler := p.Then(router)
+	request := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.2:41000"
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("/api/v1/debug"))
+
+	// Admin routes are limited to the office network, resolved behind the proxy
+	require.NoError(t, p.Reload(config.MiddlewareConfig{
+		IPAllowlist:   []string{"198.51.100.0/24"},
+		IPFilterPaths: []string{"/api/v1/debug"},
+	}))
+	assert.Equal(t, http.StatusForbidden, request("/api/v1/debug"))
+	assert.Equal(t, http.StatusOK, request("/api/v1/status"))
+
+	// Invalid lists keep the current rules
+	assert.Error(t, p.Reload(config.MiddlewareConfig{IPAllowlist: []string{"nope"}}))
+	assert.Equal(t, http.StatusForbidden, request("/api/v1/debug"))
+}
+
+func TestRecovery_ReraisesAbortHandler(t *testing.T) {
+	handler := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
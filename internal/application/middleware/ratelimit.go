@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"container/list"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultRateLimitClients bounds how many client buckets are kept
+const defaultRateLimitClients = 10000
+
+// RateLimiter gives each client a token bucket, keyed by client IP or by
+// an API key header. The least recently seen clients are evicted once
+// maxClients buckets exist; an evicted client starts again with a full
+// bucket.
+type RateLimiter struct {
+	rate       rate.Limit
+	burst      int
+	maxClients int
+	keyHeader  string
+	resolver   *ClientIPResolver
+	now        func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*list.Element
+	lru     *list.List // front is the most recently seen client
+}
+
+// clientBucket is the token bucket of one client
+type clientBucket struct {
+	key     string
+	limiter *rate.Limiter
+}
+
+// RateLimiterOption is a function that configures a RateLimiter
+type RateLimiterOption func(*RateLimiter)
+
+// WithRateLimitBurst sets how many requests a client may send at once
+func WithRateLimitBurst(burst int) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		if burst > 0 {
+			rl.burst = burst
+		}
+	}
+}
+
+// WithRateLimitMaxClients sets how many client buckets are kept
+func WithRateLimitMaxClients(maxClients int) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		if maxClients > 0 {
+			rl.maxClients = maxClients
+		}
+	}
+}
+
+// WithRateLimitKeyHeader keys clients by the value of header, such as an
+// API key, falling back to the client IP when it is missing. Only use it
+// when the header is authenticated before the limiter, as clients can
+// otherwise rotate values to escape their limit.
+func WithRateLimitKeyHeader(header string) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.keyHeader = header
+	}
+}
+
+// WithClientIPResolver resolves client IPs behind trusted proxies
+func WithClientIPResolver(resolver *ClientIPResolver) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.resolver = resolver
+	}
+}
+
+// NewRateLimiter creates a rate limiter allowing each client
+// requestsPerSecond. Bursts default to one second of requests, and at least
+// one request.
+func NewRateLimiter(requestsPerSecond float64, options ...RateLimiterOption) *RateLimiter {
+	rl := &RateLimiter{
+		rate:       rate.Limit(requestsPerSecond),
+		burst:      int(math.Ceil(requestsPerSecond)),
+		maxClients: defaultRateLimitClients,
+		now:        time.Now,
+		clients:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	if rl.burst < 1 {
+		rl.burst = 1
+	}
+
+	for _, option := range options {
+		option(rl)
+	}
+
+	return rl
+}
+
+// RateLimit middleware limits requests per client. Responses carry the
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers, and
+// rejected requests get a Retry-After in seconds.
+func (rl *RateLimiter) RateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := rl.now()
+		limiter := rl.limiter(rl.key(r))
+		allowed := limiter.AllowN(now, 1)
+		tokens := limiter.TokensAt(now)
+
+		remaining := int(math.Max(0, math.Floor(tokens)))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(rl.burst))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(rl.secondsUntil(float64(rl.burst)-tokens)))
+
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(rl.secondsUntil(1-tokens)))
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// key identifies the client of r
+func (rl *RateLimiter) key(r *http.Request) string {
+	if rl.keyHeader != "" {
+		if value := r.Header.Get(rl.keyHeader); value != "" {
+			return "key:" + value
+		}
+	}
+	return "ip:" + rl.resolver.ClientIP(r)
+}
+
+// limiter returns the bucket of key, creating it and evicting the least
+// recently seen client when needed
+func (rl *RateLimiter) limiter(key string) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if element, ok := rl.clients[key]; ok {
+		rl.lru.MoveToFront(element)
+		return element.Value.(*clientBucket).limiter
+	}
+
+	bucket := &clientBucket{key: key, limiter: rate.NewLimiter(rl.rate, rl.burst)}
+	rl.clients[key] = rl.lru.PushFront(bucket)
+
+	if rl.lru.Len() > rl.maxClients {
+		oldest := rl.lru.Back()
+		rl.lru.Remove(oldest)
+		delete(rl.clients, oldest.Value.(*clientBucket).key)
+	}
+
+	return bucket.limiter
+}
+
+// secondsUntil returns the whole seconds until tokens more tokens accrue
+func (rl *RateLimiter) secondsUntil(tokens float64) int {
+	if tokens <= 0 || rl.rate <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / float64(rl.rate)))
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_PerClientBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := NewRateLimiter(1, WithRateLimitBurst(2))
+	limiter.now = func() time.Time { return now }
+	handler := limiter.RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request("203.0.113.7:1000")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, request("203.0.113.7:1001").Code)
+
+	rec = request("203.0.113.7:1002")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Reset"))
+
+	// Another client is not affected
+	assert.Equal(t, http.StatusOK, request("198.51.100.1:1000").Code)
+
+	// Tokens accrue over time
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, request("203.0.113.7:1003").Code)
+}
+
+func TestRateLimiter_KeyHeader(t *testing.T) {
+	limiter := NewRateLimiter(0.1, WithRateLimitKeyHeader("X-API-Key"))
+	handler := limiter.RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(apiKey string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Clients sharing an address are told apart by their key
+	assert.Equal(t, http.StatusOK, request("team-a"))
+	assert.Equal(t, http.StatusOK, request("team-b"))
+	assert.Equal(t, http.StatusTooManyRequests, request("team-a"))
+
+	// Requests without a key fall back to the client IP
+	assert.Equal(t, http.StatusOK, request(""))
+	assert.Equal(t, http.StatusTooManyRequests, request(""))
+}
+
+func TestRateLimiter_EvictsLeastRecentlySeenClients(t *testing.T) {
+	limiter := NewRateLimiter(0.1, WithRateLimitMaxClients(2))
+	handler := limiter.RateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	request := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("192.0.2.1:1000"))
+	assert.Equal(t, http.StatusOK, request("192.0.2.2:1000"))
+	assert.Equal(t, http.StatusTooManyRequests, request("192.0.2.1:1000"), "seen again, so 192.0.2.2 is now the oldest")
+	assert.Equal(t, http.StatusOK, request("192.0.2.3:1000"))
+
+	assert.Len(t, limiter.clients, 2)
+	assert.Contains(t, limiter.clients, "ip:192.0.2.1")
+	assert.NotContains(t, limiter.clients, "ip:192.0.2.2")
+	assert.Equal(t, http.StatusOK, request("192.0.2.2:1000"), "evicted clients start with a full bucket")
+}
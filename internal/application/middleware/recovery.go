@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// Recovery turns a panicking handler into a 500 response and logs the panic
+// with its stack. http.ErrAbortHandler is re-raised so the server still
+// aborts the response as the handler asked.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			log := logger.Get()
+			log.Error(r.Context(), "Recovered from panic in HTTP handler", fmt.Errorf("panic: %v", recovered), logger.Fields{
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"stack":  string(debug.Stack()),
+			})
+
+			body := map[string]interface{}{
+				"error":     "internal server error",
+				"timestamp": time.Now().UTC(),
+			}
+			if requestID := logger.RequestIDFromContext(r.Context()); requestID != "" {
+				body["request_id"] = requestID
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(body)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestIDFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "accepts the client ID", header: "req-42", expected: "req-42"},
+		{name: "generates a missing ID", header: ""},
+		{name: "replaces IDs with control characters", header: "req\x1b[31m"},
+		{name: "replaces IDs with spaces", header: "req 42"},
+		{name: "replaces oversized IDs", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(RequestIDHeader)
+			assert.Equal(t, id, seen, "handlers see the echoed ID")
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, id)
+			} else {
+				assert.Len(t, id, 32)
+				assert.NotEqual(t, tt.header, id)
+			}
+		})
+	}
+}
+
+func TestRequestID_Unique(t *testing.T) {
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	ids := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		ids[rec.Header().Get(RequestIDHeader)] = true
+	}
+	assert.Len(t, ids, 100)
+}
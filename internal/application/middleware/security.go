@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
-	"golang.org/x/time/rate"
 )
 
 // SecurityHeaders adds security headers to responses
@@ -31,29 +30,6 @@ func SecurityHeaders(next http.Handler) http.Handler {
 	})
 }
 
-// RateLimiter provides rate limiting functionality
-type RateLimiter struct {
-	limiter *rate.Limiter
-}
-
-// NewRateLimiter creates a new rate limiter
-func NewRateLimiter(requestsPerSecond float64) *RateLimiter {
-	return &RateLimiter{
-		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), int(requestsPerSecond)),
-	}
-}
-
-// RateLimit middleware limits requests per IP
-func (rl *RateLimiter) RateLimit(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !rl.limiter.Allow() {
-			http.Error(w, "Too many requests", http.StatusTooManyRequests)
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
-}
-
 // RequestLogger logs incoming requests
 func RequestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -70,14 +46,19 @@ func RequestLogger(next http.Handler) http.Handler {
 		ctx := r.Context()
 		safePath := strings.ReplaceAll(strings.ReplaceAll(r.URL.Path, "\n", ""), "\r", "")
 		safeUserAgent := strings.ReplaceAll(strings.ReplaceAll(r.UserAgent(), "\n", ""), "\r", "")
-		log.Info(ctx, "HTTP request processed", logger.Fields{
+		fields := logger.Fields{
 			"method":      r.Method,
 			"path":        safePath,
 			"status_code": ww.statusCode,
 			"duration_ms": duration.Milliseconds(),
 			"user_agent":  safeUserAgent,
 			"remote_addr": r.RemoteAddr,
-		})
+		}
+		// RequestID may run inside this middleware; it echoes the ID either way
+		if requestID := w.Header().Get(RequestIDHeader); requestID != "" {
+			fields["request_id"] = requestID
+		}
+		log.Info(ctx, "HTTP request processed", fields)
 	})
 }
 
@@ -119,43 +100,6 @@ func RequestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
 	}
 }
 
-// IPWhitelist allows only specific IP addresses
-func IPWhitelist(allowedIPs []string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			clientIP := getClientIP(r)
-
-			allowed := false
-			for _, ip := range allowedIPs {
-				if ip == clientIP {
-					allowed = true
-					break
-				}
-			}
-
-			if !allowed {
-				http.Error(w, "Forbidden", http.StatusForbidden)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
-// getClientIP extracts the real client IP from the request
-func getClientIP(r *http.Request) string {
-	// Check for forwarded headers
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		return ip
-	}
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return ip
-	}
-
-	return r.RemoteAddr
-}
-
 // APIVersion adds API version headers to responses
 func APIVersion(version string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
+)
+
+// Tracing starts a server span for each request, continuing the trace of
+// incoming W3C trace context headers. Spans are named after the route
+// pattern that matched so that they group like the request metrics.
+func Tracing(tp trace.TracerProvider, routes RouteMatcher) Middleware {
+	tracer := tracing.Tracer(tp)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routeOf(routes, r)
+
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", route),
+					attribute.String("url.path", r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			span.SetAttributes(attribute.Int("http.response.status_code", ww.statusCode))
+			if ww.statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(ww.statusCode))
+			}
+		})
+	}
+}
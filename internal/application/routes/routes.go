@@ -2,53 +2,132 @@ package routes
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/sukhera/uptime-monitor/internal/application/handlers"
 )
 
-// SetupRoutes configures and returns the HTTP router with all routes
-func SetupRoutes(statusHandler *handlers.StatusHandler) *http.ServeMux {
-	router := http.NewServeMux()
+// Route is an API endpoint registered on a ServeMux under the Go 1.22
+// pattern "Method Path"
+type Route struct {
+	Method      string
+	Path        string
+	Description string
+	Handler     http.Handler
+}
+
+// Pattern returns the ServeMux pattern of the route
+func (r Route) Pattern() string {
+	return r.Method + " " + r.Path
+}
+
+// Handlers holds the handlers serving the API routes
+type Handlers struct {
+	Status   *handlers.StatusHandler
+	Policy   *handlers.PolicyHandler
+	Alert    *handlers.AlertHandler
+	Outbox   *handlers.OutboxHandler
+	Silence  *handlers.SilenceHandler
+	Template *handlers.TemplateHandler
+	Probe    *handlers.ProbeHandler
+	Metrics  http.Handler
+}
+
+// Routes returns the API routes served by h. It is the single route table
+// of the API: both servers register it and GetRoutes lists it.
+func Routes(h Handlers) []Route {
+	route := func(method, path, description string, handler http.HandlerFunc) Route {
+		return Route{Method: method, Path: path, Description: description, Handler: handler}
+	}
+
+	return []Route{
+		// Versioned routes (v1)
+		route(http.MethodGet, "/api/v1/status", "Get current system status", h.Status.GetStatus),
+		route(http.MethodGet, "/api/v1/health", "Health check endpoint", h.Status.HealthCheck),
+		route(http.MethodGet, "/api/v1/incidents", "Get incidents list", h.Status.GetIncidents),
+		route(http.MethodGet, "/api/v1/maintenance", "Get maintenance schedule", h.Status.GetMaintenance),
+		route(http.MethodGet, "/api/v1/test", "Test endpoint", h.Status.GetTest),
+		route(http.MethodGet, "/api/v1/debug", "Debug endpoint", h.Status.GetDebug),
+
+		route(http.MethodGet, "/api/v1/policies", "List notification policies", h.Policy.ListPolicies),
+		route(http.MethodPost, "/api/v1/policies", "Create a notification policy", h.Policy.CreatePolicy),
+		route(http.MethodGet, "/api/v1/policies/{id}", "Get a notification policy", h.Policy.GetPolicy),
+		route(http.MethodPut, "/api/v1/policies/{id}", "Replace a notification policy", h.Policy.UpdatePolicy),
+		route(http.MethodDelete, "/api/v1/policies/{id}", "Delete a notification policy", h.Policy.DeletePolicy),
+
+		route(http.MethodGet, "/api/v1/alerts", "List alerts", h.Alert.ListAlerts),
+		route(http.MethodGet, "/api/v1/alerts/{id}", "Get an alert", h.Alert.GetAlert),
+		route(http.MethodPost, "/api/v1/alerts/{id}/ack", "Acknowledge an alert", h.Alert.AcknowledgeAlert),
+
+		route(http.MethodGet, "/api/v1/outbox", "List queued notification deliveries", h.Outbox.ListDeliveries),
+		route(http.MethodPost, "/api/v1/outbox/{id}/retry", "Requeue a dead-lettered delivery", h.Outbox.RetryDelivery),
+
+		route(http.MethodGet, "/api/v1/silences", "List silences", h.Silence.ListSilences),
+		route(http.MethodPost, "/api/v1/silences", "Create a silence", h.Silence.CreateSilence),
+		route(http.MethodGet, "/api/v1/silences/{id}", "Get a silence", h.Silence.GetSilence),
+		route(http.MethodDelete, "/api/v1/silences/{id}", "Expire a silence", h.Silence.ExpireSilence),
+
+		route(http.MethodGet, "/api/v1/templates", "List channel message templates", h.Template.ListTemplates),
+		route(http.MethodGet, "/api/v1/templates/{channel}", "Get the message template of a channel", h.Template.GetTemplate),
+		route(http.MethodPut, "/api/v1/templates/{channel}", "Replace the message template of a channel", h.Template.UpdateTemplate),
+		route(http.MethodDelete, "/api/v1/templates/{channel}", "Delete the message template of a channel", h.Template.DeleteTemplate),
+		route(http.MethodPost, "/api/v1/templates/preview", "Render a message template against a sample check", h.Template.Preview),
 
-	// Add versioned routes (v1)
-	router.HandleFunc("/api/v1/status", statusHandler.GetStatus)
-	router.HandleFunc("/api/v1/health", statusHandler.HealthCheck)
-	router.HandleFunc("/api/v1/incidents", statusHandler.GetIncidents)
-	router.HandleFunc("/api/v1/maintenance", statusHandler.GetMaintenance)
-	router.HandleFunc("/api/v1/test", statusHandler.GetTest)
-	router.HandleFunc("/api/v1/debug", statusHandler.GetDebug)
-
-	// Backward compatibility - redirect old routes to v1
-	router.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/status", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/health", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/incidents", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/incidents", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/maintenance", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/maintenance", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/api/test", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/test", http.StatusMovedPermanently)
-	})
-	router.HandleFunc("/debug", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/api/v1/debug", http.StatusMovedPermanently)
-	})
+		// Prometheus scrape endpoints
+		{Method: http.MethodGet, Path: "/metrics", Description: "Prometheus metrics", Handler: h.Metrics},
+		route(http.MethodGet, "/probe", "Probe a service for Prometheus, like blackbox_exporter", h.Probe.Probe),
 
+		// Backward compatibility - redirect old routes to v1
+		redirect("/api/status", "/api/v1/status"),
+		redirect("/api/health", "/api/v1/health"),
+		redirect("/api/incidents", "/api/v1/incidents"),
+		redirect("/api/maintenance", "/api/v1/maintenance"),
+		redirect("/api/test", "/api/v1/test"),
+		redirect("/debug", "/api/v1/debug"),
+	}
+}
+
+// redirect returns a route permanently redirecting path to target
+func redirect(path, target string) Route {
+	return Route{
+		Method:      http.MethodGet,
+		Path:        path,
+		Description: "Redirects to " + target,
+		Handler:     http.RedirectHandler(target, http.StatusMovedPermanently),
+	}
+}
+
+// NewRouter registers routes on a new ServeMux, which answers 405 with an
+// Allow header to methods a path does not serve
+func NewRouter(routes []Route) *http.ServeMux {
+	router := http.NewServeMux()
+	for _, route := range routes {
+		router.Handle(route.Pattern(), route.Handler)
+	}
 	return router
 }
 
-// GetRoutes returns a map of all registered routes for documentation
+// SetupRoutes configures and returns the HTTP router with all routes
+func SetupRoutes(h Handlers) *http.ServeMux {
+	return NewRouter(Routes(h))
+}
+
+// GetRoutes returns the descriptions of all routes keyed by pattern, for
+// documentation
 func GetRoutes() map[string]string {
-	return map[string]string{
-		"GET /api/v1/status":      "Get current system status",
-		"GET /api/v1/health":      "Health check endpoint",
-		"GET /api/v1/incidents":   "Get incidents list",
-		"GET /api/v1/maintenance": "Get maintenance schedule",
-		"GET /api/v1/test":        "Test endpoint",
-		"GET /api/v1/debug":       "Debug endpoint",
+	listing := make(map[string]string)
+	for _, route := range Routes(Handlers{}) {
+		listing[route.Pattern()] = route.Description
+	}
+	return listing
+}
+
+// Patterns returns the patterns of all routes, sorted
+func Patterns() []string {
+	var patterns []string
+	for pattern := range GetRoutes() {
+		patterns = append(patterns, pattern)
 	}
+	sort.Strings(patterns)
+	return patterns
 }
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRoutes returns the route table with handlers that do nothing
+func stubRoutes() []Route {
+	routes := Routes(Handlers{})
+	for i := range routes {
+		if !strings.HasPrefix(routes[i].Description, "Redirects to ") {
+			routes[i].Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		}
+	}
+	return routes
+}
+
+func TestNewRouter_MatchesEachRoute(t *testing.T) {
+	routes := stubRoutes()
+	router := NewRouter(routes)
+
+	replacer := strings.NewReplacer("{id}", "abc123", "{channel}", "slack")
+	for _, route := range routes {
+		req := httptest.NewRequest(route.Method, replacer.Replace(route.Path), nil)
+		_, pattern := router.Handler(req)
+		assert.Equal(t, route.Pattern(), pattern, "request to %s %s", route.Method, route.Path)
+	}
+}
+
+func TestNewRouter_MethodsAndRedirects(t *testing.T) {
+	router := NewRouter(stubRoutes())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/status", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Contains(t, rec.Header().Get("Allow"), http.MethodGet)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/policies/abc123", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		assert.Contains(t, rec.Header().Get("Allow"), method)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/test", nil))
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/api/v1/test", rec.Header().Get("Location"))
+}
+
+func TestGetRoutes_ListsTheRouteTable(t *testing.T) {
+	listing := GetRoutes()
+	require.Len(t, listing, len(Routes(Handlers{})))
+
+	assert.Equal(t, "Test endpoint", listing["GET /api/v1/test"])
+	assert.Equal(t, "Render a message template against a sample check", listing["POST /api/v1/templates/preview"])
+	assert.Equal(t, "Redirects to /api/v1/debug", listing["GET /debug"])
+
+	patterns := Patterns()
+	assert.Len(t, patterns, len(listing))
+	assert.IsIncreasing(t, patterns)
+}
@@ -6,11 +6,19 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"net/http/httptrace"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 )
 
 // HealthCheckCommand represents a health check command
@@ -33,8 +41,36 @@ func NewHTTPHealthCheckCommand(service service.Service, client HTTPClient) *HTTP
 	}
 }
 
-// Execute performs the HTTP health check
+// Execute performs the HTTP health check in a span that nests under the
+// span in ctx
 func (cmd *HTTPHealthCheckCommand) Execute(ctx context.Context) service.StatusLog {
+	ctx, span := tracing.TracerFromContext(ctx).Start(ctx, "health_check",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("uptime.service", cmd.service.Name),
+			attribute.String("url.full", cmd.service.URL),
+		),
+	)
+	defer span.End()
+
+	statusLog := cmd.execute(httptrace.WithClientTrace(ctx, probeClientTrace(ctx)))
+
+	span.SetAttributes(
+		attribute.String("uptime.status", statusLog.Status),
+		attribute.Int64("uptime.latency_ms", statusLog.Latency),
+	)
+	if statusLog.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", statusLog.StatusCode))
+	}
+	if statusLog.Status == statusDown {
+		span.SetStatus(codes.Error, statusLog.Error)
+	}
+
+	return statusLog
+}
+
+// execute sends the request, retrying transport errors
+func (cmd *HTTPHealthCheckCommand) execute(ctx context.Context) service.StatusLog {
 	const maxRetries = 3
 	const retryDelay = 500 * time.Millisecond
 
@@ -141,6 +177,7 @@ func DefaultWorkerPoolConfig() WorkerPoolConfig {
 type HealthCheckInvoker struct {
 	commands []HealthCheckCommand
 	config   WorkerPoolConfig
+	metrics  *metrics.Metrics
 }
 
 // NewHealthCheckInvoker creates a new health check invoker with default config
@@ -161,6 +198,11 @@ func (invoker *HealthCheckInvoker) AddCommand(command HealthCheckCommand) {
 	invoker.commands = append(invoker.commands, command)
 }
 
+// SetMetrics reports the number of commands waiting for a worker to m
+func (invoker *HealthCheckInvoker) SetMetrics(m *metrics.Metrics) {
+	invoker.metrics = m
+}
+
 // ExecuteAll executes all health check commands using bounded worker pool
 func (invoker *HealthCheckInvoker) ExecuteAll(ctx context.Context) []service.StatusLog {
 	if len(invoker.commands) == 0 {
@@ -184,10 +226,15 @@ func (invoker *HealthCheckInvoker) executeWithWorkerPool(ctx context.Context) []
 
 	var wg sync.WaitGroup
 
+	// Every command waits until a worker holds the semaphore for it
+	var queued atomic.Int64
+	queued.Store(int64(len(invoker.commands)))
+	invoker.addQueued(len(invoker.commands))
+
 	// Start workers
 	for i := 0; i < invoker.config.WorkerCount; i++ {
 		wg.Add(1)
-		go invoker.worker(ctx, jobs, results, semaphore, &wg)
+		go invoker.worker(ctx, jobs, results, semaphore, &queued, &wg)
 	}
 
 	// Send jobs
@@ -205,6 +252,8 @@ func (invoker *HealthCheckInvoker) executeWithWorkerPool(ctx context.Context) []
 	// Wait for all workers to finish
 	go func() {
 		wg.Wait()
+		// Commands left behind by a cancelled run are no longer waiting
+		invoker.addQueued(-int(queued.Load()))
 		close(results)
 	}()
 
@@ -218,7 +267,7 @@ func (invoker *HealthCheckInvoker) executeWithWorkerPool(ctx context.Context) []
 }
 
 // worker processes health check commands with jitter, timeout, and retry logic
-func (invoker *HealthCheckInvoker) worker(ctx context.Context, jobs <-chan HealthCheckCommand, results chan<- service.StatusLog, semaphore chan struct{}, wg *sync.WaitGroup) {
+func (invoker *HealthCheckInvoker) worker(ctx context.Context, jobs <-chan HealthCheckCommand, results chan<- service.StatusLog, semaphore chan struct{}, queued *atomic.Int64, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
@@ -234,6 +283,8 @@ func (invoker *HealthCheckInvoker) worker(ctx context.Context, jobs <-chan Healt
 			case <-ctx.Done():
 				return
 			}
+			queued.Add(-1)
+			invoker.addQueued(-1)
 
 			// Add jitter before executing
 			if invoker.config.JitterMaxDuration > 0 {
@@ -300,6 +351,13 @@ func (invoker *HealthCheckInvoker) executeCommandWithRetry(ctx context.Context,
 	return lastResult
 }
 
+// addQueued updates the queue depth gauge when metrics are configured
+func (invoker *HealthCheckInvoker) addQueued(delta int) {
+	if invoker.metrics != nil {
+		invoker.metrics.AddQueued(delta)
+	}
+}
+
 // ExecuteSequential executes all health check commands sequentially
 func (invoker *HealthCheckInvoker) ExecuteSequential(ctx context.Context) []service.StatusLog {
 	var statusLogs []service.StatusLog
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
 )
 
 // MockHTTPClient is a simple mock for testing
@@ -513,3 +514,60 @@ func TestHealthCheckInvoker_Integration(t *testing.T) {
 	assert.Equal(t, 1, operationalCount)
 	assert.Equal(t, 1, degradedCount)
 }
+
+// gatedCommand blocks until release is closed
+type gatedCommand struct {
+	name    string
+	release chan struct{}
+}
+
+func (c *gatedCommand) Execute(ctx context.Context) service.StatusLog {
+	<-c.release
+	return service.StatusLog{ServiceName: c.name, Status: "operational"}
+}
+
+func (c *gatedCommand) GetServiceName() string {
+	return c.name
+}
+
+func TestHealthCheckInvoker_QueueDepth(t *testing.T) {
+	config := DefaultWorkerPoolConfig()
+	config.JitterMaxDuration = 0
+	config.MaxConcurrent = 1
+	invoker := NewHealthCheckInvokerWithConfig(config)
+
+	m := metrics.New()
+	invoker.SetMetrics(m)
+
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		invoker.AddCommand(&gatedCommand{name: fmt.Sprintf("service-%d", i), release: release})
+	}
+
+	done := make(chan []service.StatusLog)
+	go func() {
+		done <- invoker.ExecuteAll(context.Background())
+	}()
+
+	depth := func() float64 {
+		families, err := m.Registry().Gather()
+		if err != nil {
+			return -1
+		}
+		for _, family := range families {
+			if family.GetName() == "uptime_worker_queue_depth" {
+				return family.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+		return -1
+	}
+
+	// One check holds the semaphore, the other two wait for it
+	assert.Eventually(t, func() bool { return depth() == 2 }, time.Second, 5*time.Millisecond)
+
+	close(release)
+	results := <-done
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, 0.0, depth())
+}
@@ -0,0 +1,69 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
+)
+
+// probeClientTrace records the DNS lookup, connection attempts and TLS
+// handshake of a probe as child spans of the span in ctx
+func probeClientTrace(ctx context.Context) *httptrace.ClientTrace {
+	tracer := tracing.TracerFromContext(ctx)
+
+	// Dialing may race several connection attempts, so spans are keyed
+	var mu sync.Mutex
+	spans := make(map[string]trace.Span)
+
+	start := func(key, name string, attrs ...attribute.KeyValue) {
+		_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		mu.Lock()
+		spans[key] = span
+		mu.Unlock()
+	}
+	end := func(key string, err error) {
+		mu.Lock()
+		span, ok := spans[key]
+		delete(spans, key)
+		mu.Unlock()
+		if !ok {
+			return
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart: func(info httptrace.DNSStartInfo) {
+			start("dns", "dns", attribute.String("server.address", info.Host))
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			end("dns", info.Err)
+		},
+		ConnectStart: func(network, addr string) {
+			start("connect "+addr, "connect",
+				attribute.String("network.transport", network),
+				attribute.String("network.peer.address", addr),
+			)
+		},
+		ConnectDone: func(network, addr string, err error) {
+			end("connect "+addr, err)
+		},
+		TLSHandshakeStart: func() {
+			start("tls", "tls")
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			end("tls", err)
+		},
+	}
+}
@@ -3,13 +3,17 @@ package checker
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
 )
 
 // HealthCheckEvent represents a health check event
 type HealthCheckEvent struct {
 	ServiceName string
+	ServiceSlug string
 	Status      string
 	Latency     int64
 	StatusCode  int
@@ -94,67 +98,28 @@ func (o *LoggingObserver) OnHealthCheckCompleted(ctx context.Context, event Heal
 	}
 }
 
-// MetricsObserver collects metrics from health check events
+// MetricsObserver records health check events in the Prometheus collectors
 type MetricsObserver struct {
-	metrics map[string]interface{}
-	mu      sync.RWMutex
+	metrics *metrics.Metrics
 }
 
-// NewMetricsObserver creates a new metrics observer
-func NewMetricsObserver() *MetricsObserver {
-	return &MetricsObserver{
-		metrics: make(map[string]interface{}),
-	}
+// NewMetricsObserver creates a metrics observer recording into m
+func NewMetricsObserver(m *metrics.Metrics) *MetricsObserver {
+	return &MetricsObserver{metrics: m}
 }
 
-// OnHealthCheckCompleted collects metrics from health check events
+// OnHealthCheckCompleted counts the check and records its latency and status
 func (o *MetricsObserver) OnHealthCheckCompleted(ctx context.Context, event HealthCheckEvent) {
-	o.mu.Lock()
-	defer o.mu.Unlock()
-
-	// Update service-specific metrics
-	serviceKey := "service_" + event.ServiceName
-	o.metrics[serviceKey] = map[string]interface{}{
-		"status":      event.Status,
-		"latency_ms":  event.Latency,
-		"status_code": event.StatusCode,
-		"timestamp":   event.Timestamp,
-	}
-
-	// Update global metrics
-	if globalMetrics, exists := o.metrics["global"]; exists {
-		if global, ok := globalMetrics.(map[string]interface{}); ok {
-			if totalChecks, exists := global["total_checks"]; exists {
-				if total, ok := totalChecks.(int); ok {
-					global["total_checks"] = total + 1
-				}
-			} else {
-				global["total_checks"] = 1
-			}
-		}
-	} else {
-		o.metrics["global"] = map[string]interface{}{
-			"total_checks": 1,
-		}
-	}
+	o.metrics.ObserveCheck(event.ServiceName, event.Status, time.Duration(event.Latency)*time.Millisecond)
 }
 
-// GetMetrics returns the collected metrics
-func (o *MetricsObserver) GetMetrics() map[string]interface{} {
-	o.mu.RLock()
-	defer o.mu.RUnlock()
-
-	metrics := make(map[string]interface{})
-	for k, v := range o.metrics {
-		metrics[k] = v
-	}
-	return metrics
-}
-
-// AlertingObserver handles alerting based on health check events
+// AlertingObserver handles alerting based on health check events. It is a
+// best-effort in-process feed; notifications are delivered through the
+// notifier outbox, which does not drop events.
 type AlertingObserver struct {
 	alertThreshold int64 // latency threshold in milliseconds
 	alertCh        chan HealthCheckEvent
+	dropped        atomic.Int64
 }
 
 // NewAlertingObserver creates a new alerting observer
@@ -173,7 +138,8 @@ func (o *AlertingObserver) OnHealthCheckCompleted(ctx context.Context, event Hea
 		case o.alertCh <- event:
 			// Alert sent successfully
 		default:
-			// Alert channel is full, log the dropped alert
+			// Alert channel is full; count the dropped alert
+			o.dropped.Add(1)
 		}
 	}
 }
@@ -182,3 +148,8 @@ func (o *AlertingObserver) OnHealthCheckCompleted(ctx context.Context, event Hea
 func (o *AlertingObserver) GetAlertChannel() <-chan HealthCheckEvent {
 	return o.alertCh
 }
+
+// Dropped returns how many alerts were dropped because the channel was full
+func (o *AlertingObserver) Dropped() int64 {
+	return o.dropped.Load()
+}
@@ -2,11 +2,16 @@ package checker
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
 )
 
 // MockObserver is a mock observer for testing
@@ -175,15 +180,15 @@ func TestHealthCheckSubject_Notify_NoObservers(t *testing.T) {
 }
 
 func TestMetricsObserver_New(t *testing.T) {
-	observer := NewMetricsObserver()
+	observer := NewMetricsObserver(metrics.New())
 
 	assert.NotNil(t, observer)
 	assert.NotNil(t, observer.metrics)
-	assert.Empty(t, observer.metrics)
 }
 
 func TestMetricsObserver_OnHealthCheckCompleted(t *testing.T) {
-	observer := NewMetricsObserver()
+	m := metrics.New()
+	observer := NewMetricsObserver(m)
 
 	event := HealthCheckEvent{
 		ServiceName: "test-service",
@@ -196,31 +201,25 @@ func TestMetricsObserver_OnHealthCheckCompleted(t *testing.T) {
 	ctx := context.Background()
 	observer.OnHealthCheckCompleted(ctx, event)
 
-	metrics := observer.GetMetrics()
-	assert.NotEmpty(t, metrics)
-
-	// Check service-specific metrics
-	serviceKey := "service_test-service"
-	serviceMetrics, exists := metrics[serviceKey]
-	assert.True(t, exists)
-
-	serviceData, ok := serviceMetrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "operational", serviceData["status"])
-	assert.Equal(t, int64(150), serviceData["latency_ms"])
-	assert.Equal(t, 200, serviceData["status_code"])
-
-	// Check global metrics
-	globalMetrics, exists := metrics["global"]
-	assert.True(t, exists)
-
-	globalData, ok := globalMetrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, 1, globalData["total_checks"])
+	expected := `
+# HELP uptime_checks_total Health checks run, by service and resulting status.
+# TYPE uptime_checks_total counter
+uptime_checks_total{service="test-service",status="operational"} 1
+# HELP uptime_service_up Whether the last check of a service found it up (1) or down (0).
+# TYPE uptime_service_up gauge
+uptime_service_up{service="test-service"} 1
+`
+	err := testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "uptime_checks_total", "uptime_service_up")
+	assert.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(m.Registry(), "uptime_probe_latency_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
 }
 
 func TestMetricsObserver_OnHealthCheckCompleted_Multiple(t *testing.T) {
-	observer := NewMetricsObserver()
+	m := metrics.New()
+	observer := NewMetricsObserver(m)
 
 	events := []HealthCheckEvent{
 		{
@@ -238,6 +237,13 @@ func TestMetricsObserver_OnHealthCheckCompleted_Multiple(t *testing.T) {
 			Error:       "Connection timeout",
 			Timestamp:   time.Now().Unix(),
 		},
+		{
+			ServiceName: "service-1",
+			Status:      "operational",
+			Latency:     120,
+			StatusCode:  200,
+			Timestamp:   time.Now().Unix(),
+		},
 	}
 
 	ctx := context.Background()
@@ -245,45 +251,18 @@ func TestMetricsObserver_OnHealthCheckCompleted_Multiple(t *testing.T) {
 		observer.OnHealthCheckCompleted(ctx, event)
 	}
 
-	metrics := observer.GetMetrics()
-
-	// Check global metrics
-	globalMetrics, exists := metrics["global"]
-	assert.True(t, exists)
-
-	globalData, ok := globalMetrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, 2, globalData["total_checks"])
-
-	// Check service-specific metrics
-	service1Key := "service_service-1"
-	service1Metrics, exists := metrics[service1Key]
-	assert.True(t, exists)
-
-	service1Data, ok := service1Metrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "operational", service1Data["status"])
-
-	service2Key := "service_service-2"
-	service2Metrics, exists := metrics[service2Key]
-	assert.True(t, exists)
-
-	service2Data, ok := service2Metrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "down", service2Data["status"])
-}
-
-func TestMetricsObserver_GetMetrics(t *testing.T) {
-	observer := NewMetricsObserver()
-
-	// Add some metrics
-	observer.metrics["test"] = "value"
-	observer.metrics["number"] = 42
-
-	metrics := observer.GetMetrics()
-
-	assert.Equal(t, "value", metrics["test"])
-	assert.Equal(t, 42, metrics["number"])
+	expected := `
+# HELP uptime_checks_total Health checks run, by service and resulting status.
+# TYPE uptime_checks_total counter
+uptime_checks_total{service="service-1",status="operational"} 2
+uptime_checks_total{service="service-2",status="down"} 1
+# HELP uptime_service_up Whether the last check of a service found it up (1) or down (0).
+# TYPE uptime_service_up gauge
+uptime_service_up{service="service-1"} 1
+uptime_service_up{service="service-2"} 0
+`
+	err := testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "uptime_checks_total", "uptime_service_up")
+	assert.NoError(t, err)
 }
 
 func TestAlertingObserver_New(t *testing.T) {
@@ -391,6 +370,8 @@ func TestAlertingObserver_ChannelFull(t *testing.T) {
 		observer.OnHealthCheckCompleted(ctx, event)
 	}
 
+	assert.Zero(t, observer.Dropped())
+
 	// Should not panic when channel is full
 	assert.NotPanics(t, func() {
 		event := HealthCheckEvent{
@@ -403,6 +384,7 @@ func TestAlertingObserver_ChannelFull(t *testing.T) {
 		ctx := context.Background()
 		observer.OnHealthCheckCompleted(ctx, event)
 	})
+	assert.Equal(t, int64(1), observer.Dropped())
 }
 
 func TestObserver_Integration(t *testing.T) {
@@ -410,8 +392,8 @@ func TestObserver_Integration(t *testing.T) {
 	subject := NewHealthCheckSubject()
 
 	// Add metrics observer
-	metricsObserver := NewMetricsObserver()
-	subject.Attach(metricsObserver)
+	m := metrics.New()
+	subject.Attach(NewMetricsObserver(m))
 
 	// Add alerting observer
 	alertingObserver := NewAlertingObserver(5000)
@@ -447,13 +429,9 @@ func TestObserver_Integration(t *testing.T) {
 	time.Sleep(20 * time.Millisecond)
 
 	// Check metrics
-	metrics := metricsObserver.GetMetrics()
-	globalMetrics, exists := metrics["global"]
-	assert.True(t, exists)
-
-	globalData, ok := globalMetrics.(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, 2, globalData["total_checks"])
+	count, err := testutil.GatherAndCount(m.Registry(), "uptime_checks_total")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
 
 	// Check alerts
 	alertCount := 0
@@ -0,0 +1,135 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
+)
+
+// DefaultProbeModule is used when a probe does not name a module, as in
+// blackbox_exporter
+const DefaultProbeModule = "http_2xx"
+
+// ProbeModule decides how an on-demand probe checks a service and whether
+// the result counts as a success
+type ProbeModule struct {
+	Name    string
+	Command func(svc service.Service, client HTTPClient) HealthCheckCommand
+	Success func(log service.StatusLog) bool
+}
+
+// ProbeResult is the outcome of an on-demand probe
+type ProbeResult struct {
+	Module   string
+	Log      service.StatusLog
+	Success  bool
+	Duration time.Duration
+}
+
+// DefaultProbeModules returns the built-in modules: http_2xx succeeds on
+// any 2xx response, like the blackbox_exporter module of the same name,
+// and service succeeds unless the service's own assertions find it down
+func DefaultProbeModules() []ProbeModule {
+	return []ProbeModule{
+		{
+			Name:    "http_2xx",
+			Command: newHTTPProbeCommand,
+			Success: func(log service.StatusLog) bool {
+				return log.StatusCode >= 200 && log.StatusCode < 300
+			},
+		},
+		{
+			Name:    "service",
+			Command: newHTTPProbeCommand,
+			Success: func(log service.StatusLog) bool {
+				return log.Status != statusDown
+			},
+		},
+	}
+}
+
+// newHTTPProbeCommand adapts NewHTTPHealthCheckCommand to ProbeModule.Command
+func newHTTPProbeCommand(svc service.Service, client HTTPClient) HealthCheckCommand {
+	return NewHTTPHealthCheckCommand(svc, client)
+}
+
+// Prober runs health checks on demand, outside the checker schedule
+type Prober struct {
+	client  HTTPClient
+	modules map[string]ProbeModule
+}
+
+// ProberOption is a function that configures a Prober
+type ProberOption func(*Prober)
+
+// WithProberHTTPClient sets the HTTP client probes are sent with
+func WithProberHTTPClient(client HTTPClient) ProberOption {
+	return func(p *Prober) {
+		p.client = client
+	}
+}
+
+// WithProbeModule adds a module, replacing a built-in one of the same name
+func WithProbeModule(module ProbeModule) ProberOption {
+	return func(p *Prober) {
+		p.modules[module.Name] = module
+	}
+}
+
+// NewProber creates a prober with the default modules
+func NewProber(options ...ProberOption) *Prober {
+	p := &Prober{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		modules: make(map[string]ProbeModule),
+	}
+	for _, module := range DefaultProbeModules() {
+		p.modules[module.Name] = module
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// Module returns the module registered under name
+func (p *Prober) Module(name string) (ProbeModule, bool) {
+	module, ok := p.modules[name]
+	return module, ok
+}
+
+// Probe checks svc once with module, in a span nested under the span in ctx
+func (p *Prober) Probe(ctx context.Context, svc service.Service, module ProbeModule) ProbeResult {
+	ctx, span := tracing.TracerFromContext(ctx).Start(ctx, "probe", trace.WithAttributes(
+		attribute.String("uptime.service", svc.Name),
+		attribute.String("uptime.probe.module", module.Name),
+	))
+	defer span.End()
+
+	start := time.Now()
+	log := module.Command(svc, p.client).Execute(ctx)
+
+	result := ProbeResult{
+		Module:   module.Name,
+		Log:      log,
+		Success:  module.Success(log),
+		Duration: time.Since(start),
+	}
+
+	span.SetAttributes(attribute.Bool("uptime.probe.success", result.Success))
+	if !result.Success {
+		span.SetStatus(codes.Error, "probe failed")
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+func TestProber_Modules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		svc      service.Service
+		http2xx  bool
+		asserted bool
+	}{
+		{
+			name:     "expected 2xx",
+			svc:      service.Service{Name: "api", URL: server.URL, ExpectedStatus: 200},
+			http2xx:  true,
+			asserted: true,
+		},
+		{
+			name:     "unexpected 2xx is degraded",
+			svc:      service.Service{Name: "api", URL: server.URL, ExpectedStatus: 204},
+			http2xx:  true,
+			asserted: true,
+		},
+		{
+			name:     "expected non-2xx",
+			svc:      service.Service{Name: "login", URL: server.URL + "/login", ExpectedStatus: 401},
+			http2xx:  false,
+			asserted: true,
+		},
+		{
+			name:     "server error",
+			svc:      service.Service{Name: "broken", URL: server.URL + "/error", ExpectedStatus: 200},
+			http2xx:  false,
+			asserted: false,
+		},
+	}
+
+	prober := NewProber()
+	http2xx, ok := prober.Module("http_2xx")
+	require.True(t, ok)
+	asserted, ok := prober.Module("service")
+	require.True(t, ok)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := prober.Probe(context.Background(), tt.svc, http2xx)
+			assert.Equal(t, tt.http2xx, result.Success)
+			assert.Equal(t, "http_2xx", result.Module)
+			assert.Equal(t, tt.svc.Name, result.Log.ServiceName)
+			assert.NotZero(t, result.Log.StatusCode)
+			assert.Greater(t, result.Duration, time.Duration(0))
+
+			result = prober.Probe(context.Background(), tt.svc, asserted)
+			assert.Equal(t, tt.asserted, result.Success)
+		})
+	}
+}
+
+func TestProber_WithProbeModule(t *testing.T) {
+	_, ok := NewProber().Module("tcp_connect")
+	assert.False(t, ok)
+
+	prober := NewProber(WithProbeModule(ProbeModule{
+		Name: "tcp_connect",
+		Command: func(svc service.Service, client HTTPClient) HealthCheckCommand {
+			return &gatedCommand{name: svc.Name, release: closedChannel()}
+		},
+		Success: func(log service.StatusLog) bool {
+			return log.Status == statusOperational
+		},
+	}))
+
+	module, ok := prober.Module("tcp_connect")
+	require.True(t, ok)
+
+	result := prober.Probe(context.Background(), service.Service{Name: "db"}, module)
+	assert.True(t, result.Success)
+	assert.Equal(t, "db", result.Log.ServiceName)
+}
+
+func TestProber_TracesUnderCallerSpan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prober := NewProber()
+	module, ok := prober.Module(DefaultProbeModule)
+	require.True(t, ok)
+
+	ctx, request := tp.Tracer("test").Start(context.Background(), "GET /probe")
+	result := prober.Probe(ctx, service.Service{Name: "api", URL: server.URL, ExpectedStatus: 200}, module)
+	request.End()
+	assert.False(t, result.Success)
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	require.Contains(t, spans, "probe")
+	require.Contains(t, spans, "health_check")
+
+	assert.Equal(t, request.SpanContext().SpanID(), spans["probe"].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans["probe"].Status.Code)
+	assert.Equal(t, spans["probe"].SpanContext.SpanID(), spans["health_check"].Parent.SpanID())
+}
+
+func closedChannel() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
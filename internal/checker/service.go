@@ -6,10 +6,15 @@ import (
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
-	mongodb "github.com/sukhera/uptime-monitor/internal/infrastructure/database/mongo"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 )
 
 const (
@@ -26,11 +31,15 @@ type HTTPClient interface {
 // ServiceInterface defines the interface for the health checker service
 type ServiceInterface interface {
 	RunHealthChecks(ctx context.Context) error
+	RunHealthChecksWithObservers(ctx context.Context, subject *HealthCheckSubject) error
 }
 
 type Service struct {
-	db     mongodb.Interface
-	client HTTPClient
+	repo    service.Repository
+	client  HTTPClient
+	writer  StatusLogWriter
+	metrics *metrics.Metrics
+	tracer  trace.Tracer
 }
 
 // ServiceOption is a function that configures a Service
@@ -56,119 +65,166 @@ func WithTimeout(timeout time.Duration) ServiceOption {
 	}
 }
 
+// WithStatusLogWriter routes results through a writer such as BatchWriter
+// instead of saving each run directly
+func WithStatusLogWriter(writer StatusLogWriter) ServiceOption {
+	return func(s *Service) {
+		s.writer = writer
+	}
+}
+
+// WithMetrics reports the depth of the check worker queue to m
+func WithMetrics(m *metrics.Metrics) ServiceOption {
+	return func(s *Service) {
+		s.metrics = m
+	}
+}
+
+// WithTracerProvider records each run and its checks as spans of tp instead
+// of the global provider
+func WithTracerProvider(tp trace.TracerProvider) ServiceOption {
+	return func(s *Service) {
+		s.tracer = tracing.Tracer(tp)
+	}
+}
+
 // NewService creates a new Service with the given options
-func NewService(db mongodb.Interface, options ...ServiceOption) *Service {
-	service := &Service{
-		db: db,
+func NewService(repo service.Repository, options ...ServiceOption) *Service {
+	s := &Service{
+		repo: repo,
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		tracer: tracing.Tracer(otel.GetTracerProvider()),
 	}
 
 	for _, option := range options {
-		option(service)
+		option(s)
 	}
 
-	return service
+	return s
 }
 
 // NewServiceWithClient creates a service with a custom HTTP client (useful for testing)
 // Deprecated: Use NewService with WithHTTPClient option instead
-func NewServiceWithClient(db mongodb.Interface, client HTTPClient) *Service {
-	return NewService(db, WithHTTPClient(client))
+func NewServiceWithClient(repo service.Repository, client HTTPClient) *Service {
+	return NewService(repo, WithHTTPClient(client))
 }
 
 // RunHealthChecks runs health checks using the command pattern
-func (s *Service) RunHealthChecks(ctx context.Context) error {
-	cursor, err := s.db.ServicesCollection().Find(ctx, bson.M{"enabled": true})
+func (s *Service) RunHealthChecks(ctx context.Context) (err error) {
+	ctx, span := s.startRun(ctx)
+	defer func() { endRun(span, err) }()
+
+	_, statusLogs, err := s.executeChecks(ctx)
 	if err != nil {
-		return fmt.Errorf("error querying services: %w", err)
+		return err
 	}
-	defer func() {
-		if err := cursor.Close(ctx); err != nil {
-			log := logger.Get()
-			log.Error(ctx, "Failed to close cursor", err, nil)
-		}
-	}()
 
-	var services []service.Service
-	if err = cursor.All(ctx, &services); err != nil {
-		return fmt.Errorf("error decoding services: %w", err)
-	}
+	// Store results in database
+	s.saveStatusLogs(ctx, statusLogs)
 
-	// Create command invoker
-	invoker := NewHealthCheckInvoker()
+	return nil
+}
 
-	// Create commands for each service
-	for _, service := range services {
-		command := NewHTTPHealthCheckCommand(service, s.client)
-		invoker.AddCommand(command)
+// RunHealthChecksWithObservers runs health checks and notifies observers
+func (s *Service) RunHealthChecksWithObservers(ctx context.Context, subject *HealthCheckSubject) (err error) {
+	ctx, span := s.startRun(ctx)
+	defer func() { endRun(span, err) }()
+
+	services, statusLogs, err := s.executeChecks(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Execute all health check commands concurrently
-	statusLogs := invoker.ExecuteAll(ctx)
+	slugs := make(map[string]string, len(services))
+	for _, svc := range services {
+		slugs[svc.Name] = svc.Slug
+	}
 
 	// Store results in database
-	for _, statusLog := range statusLogs {
-		if _, err := s.db.StatusLogsCollection().InsertOne(ctx, statusLog); err != nil {
-			// Log error but continue with other results
-			log := logger.Get()
-			log.Error(ctx, "Failed to insert status log", err, logger.Fields{"service_name": statusLog.ServiceName})
+	s.saveStatusLogs(ctx, statusLogs)
+
+	// Notify observers
+	for i := range statusLogs {
+		statusLog := &statusLogs[i]
+		event := HealthCheckEvent{
+			ServiceName: statusLog.ServiceName,
+			ServiceSlug: slugs[statusLog.ServiceName],
+			Status:      statusLog.Status,
+			Latency:     statusLog.Latency,
+			StatusCode:  statusLog.StatusCode,
+			Error:       statusLog.Error,
+			Timestamp:   statusLog.Timestamp.Unix(),
 		}
+		subject.Notify(ctx, event)
 	}
 
 	return nil
 }
 
-// RunHealthChecksWithObservers runs health checks and notifies observers
-func (s *Service) RunHealthChecksWithObservers(ctx context.Context, subject *HealthCheckSubject) error {
-	cursor, err := s.db.ServicesCollection().Find(ctx, bson.M{"enabled": true})
+// startRun starts the span covering one health check cycle
+func (s *Service) startRun(ctx context.Context) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "health_checks.run")
+}
+
+// endRun ends a cycle span, marking it failed when err is set
+func endRun(span trace.Span, err error) {
 	if err != nil {
-		return fmt.Errorf("error querying services: %w", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
-	defer func() {
-		if err := cursor.Close(ctx); err != nil {
-			log := logger.Get()
-			log.Error(ctx, "Failed to close cursor", err, nil)
-		}
-	}()
+	span.End()
+}
 
-	var services []service.Service
-	if err = cursor.All(ctx, &services); err != nil {
-		return fmt.Errorf("error decoding services: %w", err)
+// executeChecks loads the enabled services and probes them concurrently
+func (s *Service) executeChecks(ctx context.Context) ([]*service.Service, []service.StatusLog, error) {
+	services, err := s.repo.GetEnabled(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error querying services: %w", err)
 	}
 
 	// Create command invoker
 	invoker := NewHealthCheckInvoker()
+	if s.metrics != nil {
+		invoker.SetMetrics(s.metrics)
+	}
 
 	// Create commands for each service
-	for _, service := range services {
-		command := NewHTTPHealthCheckCommand(service, s.client)
+	for _, svc := range services {
+		command := NewHTTPHealthCheckCommand(*svc, s.client)
 		invoker.AddCommand(command)
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("uptime.services", len(services)))
+
 	// Execute all health check commands concurrently
-	statusLogs := invoker.ExecuteAll(ctx)
-
-	// Store results and notify observers
-	for _, statusLog := range statusLogs {
-		// Store in database
-		if _, err := s.db.StatusLogsCollection().InsertOne(ctx, statusLog); err != nil {
-			log := logger.Get()
-			log.Error(ctx, "Failed to insert status log", err, logger.Fields{"service_name": statusLog.ServiceName})
-		}
+	return services, invoker.ExecuteAll(ctx), nil
+}
 
-		// Notify observers
-		event := HealthCheckEvent{
-			ServiceName: statusLog.ServiceName,
-			Status:      statusLog.Status,
-			Latency:     statusLog.Latency,
-			StatusCode:  statusLog.StatusCode,
-			Error:       statusLog.Error,
-			Timestamp:   statusLog.Timestamp.Unix(),
+// saveStatusLogs persists a run's results, logging failures rather than failing the run
+func (s *Service) saveStatusLogs(ctx context.Context, statusLogs []service.StatusLog) {
+	log := logger.Get()
+
+	if s.writer != nil {
+		for i := range statusLogs {
+			if err := s.writer.Write(ctx, &statusLogs[i]); err != nil {
+				log.Error(ctx, "Failed to queue status log", err, logger.Fields{"service_name": statusLogs[i].ServiceName})
+			}
 		}
-		subject.Notify(ctx, event)
+		return
 	}
 
-	return nil
+	if len(statusLogs) == 0 {
+		return
+	}
+
+	batch := make([]*service.StatusLog, 0, len(statusLogs))
+	for i := range statusLogs {
+		batch = append(batch, &statusLogs[i])
+	}
+
+	if err := s.repo.SaveStatusLogs(ctx, batch); err != nil {
+		log.Error(ctx, "Failed to insert status logs", err, logger.Fields{"count": len(batch)})
+	}
 }
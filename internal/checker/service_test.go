@@ -3,12 +3,19 @@ package checker
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
 	"github.com/sukhera/uptime-monitor/testutil"
 )
 
@@ -122,3 +129,129 @@ func TestHealthCheckInvoker_ExecuteAll(t *testing.T) {
 		})
 	}
 }
+
+func TestService_RunHealthChecks_StoresResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := memory.NewServiceRepository()
+
+	enabled := testutil.CreateTestService()
+	enabled.URL = server.URL
+	require.NoError(t, repo.Create(ctx, enabled))
+
+	disabled := testutil.CreateTestService()
+	disabled.Name = "Disabled Service"
+	disabled.Slug = "disabled-service"
+	disabled.URL = server.URL
+	disabled.Enabled = false
+	require.NoError(t, repo.Create(ctx, disabled))
+
+	checkerService := NewService(repo, WithHTTPClient(server.Client()))
+	require.NoError(t, checkerService.RunHealthChecks(ctx))
+
+	history, err := repo.GetStatusHistory(ctx, enabled.Name, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "operational", history[0].Status)
+	assert.Equal(t, http.StatusOK, history[0].StatusCode)
+
+	history, err = repo.GetStatusHistory(ctx, disabled.Name, 0)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestService_RunHealthChecksWithObservers_NotifiesObservers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := memory.NewServiceRepository()
+
+	svc := testutil.CreateTestService()
+	svc.URL = server.URL
+	require.NoError(t, repo.Create(ctx, svc))
+
+	subject := NewHealthCheckSubject()
+	observer := &MockObserver{}
+	subject.Attach(observer)
+
+	checkerService := NewService(repo, WithHTTPClient(server.Client()))
+	require.NoError(t, checkerService.RunHealthChecksWithObservers(ctx, subject))
+
+	assert.Eventually(t, observer.IsNotified, time.Second, 10*time.Millisecond)
+	events := observer.GetEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, svc.Name, events[0].ServiceName)
+	assert.Equal(t, svc.Slug, events[0].ServiceSlug)
+	assert.Equal(t, "operational", events[0].Status)
+}
+
+func TestService_RunHealthChecks_Traces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := memory.NewServiceRepository()
+
+	up := testutil.CreateTestService()
+	up.URL = server.URL
+	require.NoError(t, repo.Create(ctx, up))
+
+	down := testutil.CreateTestService()
+	down.Name = "Down Service"
+	down.Slug = "down-service"
+	down.URL = server.URL + "/down"
+	require.NoError(t, repo.Create(ctx, down))
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	// A fresh transport so the probes open connections
+	client := &http.Client{Transport: &http.Transport{}, Timeout: 5 * time.Second}
+	checkerService := NewService(repo, WithHTTPClient(client), WithTracerProvider(tp))
+	require.NoError(t, checkerService.RunHealthChecks(ctx))
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStubs)
+	for _, span := range spans {
+		byName[span.Name] = append(byName[span.Name], span)
+	}
+
+	require.Len(t, byName["health_checks.run"], 1)
+	run := byName["health_checks.run"][0]
+	assert.Contains(t, run.Attributes, attribute.Int("uptime.services", 2))
+
+	// Each attempt at the down service is a span of its own
+	checks := byName["health_check"]
+	require.Len(t, checks, 1+DefaultWorkerPoolConfig().RetryAttempts)
+	status := map[string]codes.Code{}
+	checkSpans := map[string]bool{}
+	for _, check := range checks {
+		assert.Equal(t, run.SpanContext.SpanID(), check.Parent.SpanID())
+		for _, attr := range check.Attributes {
+			if attr.Key == "uptime.service" {
+				status[attr.Value.AsString()] = check.Status.Code
+			}
+		}
+		checkSpans[check.SpanContext.SpanID().String()] = true
+	}
+	assert.Equal(t, codes.Unset, status[up.Name])
+	assert.Equal(t, codes.Error, status[down.Name])
+
+	require.NotEmpty(t, byName["connect"])
+	for _, connect := range byName["connect"] {
+		assert.True(t, checkSpans[connect.Parent.SpanID().String()], "connect spans nest under their check")
+	}
+}
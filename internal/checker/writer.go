@@ -0,0 +1,422 @@
+package checker
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+	defaultBufferSize    = 10000
+	defaultMaxRetries    = 3
+	defaultRetryBackoff  = 200 * time.Millisecond
+	defaultWriteTimeout  = 10 * time.Second
+)
+
+// ErrWriterClosed is returned when writing to a closed BatchWriter
+var ErrWriterClosed = stderrors.New("status log writer is closed")
+
+// ErrWriterFull is returned when the BatchWriter buffer is full and the log was dropped
+var ErrWriterFull = stderrors.New("status log writer buffer is full")
+
+// Reasons a BatchWriter drops status logs, as reported to metrics
+const (
+	dropReasonClosed      = "closed"
+	dropReasonBufferFull  = "buffer_full"
+	dropReasonRejected    = "rejected"
+	dropReasonWriteFailed = "write_failed"
+)
+
+// StatusLogWriter persists status logs produced by health checks
+type StatusLogWriter interface {
+	Write(ctx context.Context, log *service.StatusLog) error
+}
+
+// BatchWriterStats is a snapshot of the writer counters
+type BatchWriterStats struct {
+	Written       int64 `json:"written"`
+	Dropped       int64 `json:"dropped"`
+	Retries       int64 `json:"retries"`
+	Flushes       int64 `json:"flushes"`
+	FailedFlushes int64 `json:"failed_flushes"`
+	Pending       int   `json:"pending"`
+}
+
+// queuedLog is a status log waiting in the buffer with the span it was
+// written from, so the flush can link back to the checks it persists
+type queuedLog struct {
+	log  *service.StatusLog
+	span trace.SpanContext
+}
+
+// BatchWriter buffers status logs and writes them with SaveStatusLogs once
+// the batch size is reached or the flush interval elapses. Logs the
+// repository reports as not sent (service.UnsentLogsError) are retried with
+// exponential backoff; other failures are not retried, since the database
+// may have stored the batch, and their logs are counted as dropped.
+type BatchWriter struct {
+	repo          service.Repository
+	batchSize     int
+	flushInterval time.Duration
+	bufferSize    int
+	maxRetries    int
+	retryBackoff  time.Duration
+	writeTimeout  time.Duration
+	tracer        trace.Tracer
+	metrics       *metrics.Metrics
+
+	queue   chan queuedLog
+	flushCh chan chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	written       atomic.Int64
+	dropped       atomic.Int64
+	retries       atomic.Int64
+	flushes       atomic.Int64
+	failedFlushes atomic.Int64
+}
+
+// BatchWriterOption is a function that configures a BatchWriter
+type BatchWriterOption func(*BatchWriter)
+
+// WithBatchSize sets how many logs are written per batch
+func WithBatchSize(size int) BatchWriterOption {
+	return func(w *BatchWriter) {
+		if size > 0 {
+			w.batchSize = size
+		}
+	}
+}
+
+// WithFlushInterval sets the maximum time a log waits in the buffer
+func WithFlushInterval(interval time.Duration) BatchWriterOption {
+	return func(w *BatchWriter) {
+		if interval > 0 {
+			w.flushInterval = interval
+		}
+	}
+}
+
+// WithBufferSize sets how many logs may be queued before writes are dropped
+func WithBufferSize(size int) BatchWriterOption {
+	return func(w *BatchWriter) {
+		if size > 0 {
+			w.bufferSize = size
+		}
+	}
+}
+
+// WithRetry sets the number of retries for unsent logs and the initial backoff
+func WithRetry(maxRetries int, backoff time.Duration) BatchWriterOption {
+	return func(w *BatchWriter) {
+		if maxRetries >= 0 {
+			w.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			w.retryBackoff = backoff
+		}
+	}
+}
+
+// WithWriteTimeout sets the timeout for a single batch write attempt
+func WithWriteTimeout(timeout time.Duration) BatchWriterOption {
+	return func(w *BatchWriter) {
+		if timeout > 0 {
+			w.writeTimeout = timeout
+		}
+	}
+}
+
+// WithWriterTracerProvider records batch writes as spans of tp instead of
+// the global provider
+func WithWriterTracerProvider(tp trace.TracerProvider) BatchWriterOption {
+	return func(w *BatchWriter) {
+		w.tracer = tracing.Tracer(tp)
+	}
+}
+
+// WithWriterMetrics reports written, dropped and retried status logs to m
+func WithWriterMetrics(m *metrics.Metrics) BatchWriterOption {
+	return func(w *BatchWriter) {
+		w.metrics = m
+	}
+}
+
+// NewBatchWriter creates a BatchWriter and starts its flush loop.
+// Close must be called to flush pending logs and stop the loop.
+func NewBatchWriter(repo service.Repository, options ...BatchWriterOption) *BatchWriter {
+	w := &BatchWriter{
+		repo:          repo,
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		bufferSize:    defaultBufferSize,
+		maxRetries:    defaultMaxRetries,
+		retryBackoff:  defaultRetryBackoff,
+		writeTimeout:  defaultWriteTimeout,
+		tracer:        tracing.Tracer(otel.GetTracerProvider()),
+		flushCh:       make(chan chan struct{}),
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	w.queue = make(chan queuedLog, w.bufferSize)
+
+	go w.run()
+
+	return w
+}
+
+// Write queues a status log without blocking. If the buffer is full the log
+// is dropped and ErrWriterFull is returned.
+func (w *BatchWriter) Write(ctx context.Context, log *service.StatusLog) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		w.drop(dropReasonClosed, 1)
+		return ErrWriterClosed
+	}
+
+	select {
+	case w.queue <- queuedLog{log: log, span: trace.SpanContextFromContext(ctx)}:
+		return nil
+	default:
+		w.drop(dropReasonBufferFull, 1)
+		return ErrWriterFull
+	}
+}
+
+// Flush writes all queued logs and waits for the write to finish
+func (w *BatchWriter) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+
+	select {
+	case w.flushCh <- ack:
+	case <-w.doneCh:
+		return ErrWriterClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting writes, flushes queued logs and stops the flush loop
+func (w *BatchWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.stopCh)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the writer counters
+func (w *BatchWriter) Stats() BatchWriterStats {
+	return BatchWriterStats{
+		Written:       w.written.Load(),
+		Dropped:       w.dropped.Load(),
+		Retries:       w.retries.Load(),
+		Flushes:       w.flushes.Load(),
+		FailedFlushes: w.failedFlushes.Load(),
+		Pending:       len(w.queue),
+	}
+}
+
+// run is the flush loop; it owns the pending batch
+func (w *BatchWriter) run() {
+	defer close(w.doneCh)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]queuedLog, 0, w.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			w.writeBatch(batch)
+			batch = make([]queuedLog, 0, w.batchSize)
+		}
+	}
+	// drain moves everything currently queued into batches
+	drain := func() {
+		for {
+			select {
+			case log := <-w.queue:
+				batch = append(batch, log)
+				if len(batch) >= w.batchSize {
+					flush()
+				}
+			default:
+				flush()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case log := <-w.queue:
+			batch = append(batch, log)
+			if len(batch) >= w.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case ack := <-w.flushCh:
+			drain()
+			close(ack)
+		case <-w.stopCh:
+			// Writers are locked out once stopCh is closed, so the queue only shrinks
+			drain()
+			return
+		}
+	}
+}
+
+// writeBatch writes a batch, retrying the logs that were not sent with
+// exponential backoff. Once the writer is closing, the backoff is cut short
+// and a last attempt is made. The write is traced in its own span linked to
+// the spans the logs were written from.
+func (w *BatchWriter) writeBatch(queued []queuedLog) {
+	log := logger.Get()
+	backoff := w.retryBackoff
+	closing := false
+
+	batch := make([]*service.StatusLog, 0, len(queued))
+	links := make([]trace.Link, 0, len(queued))
+	for _, q := range queued {
+		batch = append(batch, q.log)
+		if q.span.IsValid() {
+			links = append(links, trace.Link{SpanContext: q.span})
+		}
+	}
+
+	spanCtx, span := w.tracer.Start(context.Background(), "status_logs.write",
+		trace.WithLinks(links...),
+		trace.WithAttributes(attribute.Int("uptime.batch_size", len(batch))),
+	)
+	defer span.End()
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(spanCtx, w.writeTimeout)
+		err := w.repo.SaveStatusLogs(ctx, batch)
+		cancel()
+
+		if err == nil {
+			w.flushes.Add(1)
+			w.stored(len(batch))
+			return
+		}
+
+		// A partial write stored the batch except for the rejected logs
+		var rejected *service.RejectedLogsError
+		if stderrors.As(err, &rejected) && rejected.Rejected <= len(batch) {
+			w.failedFlushes.Add(1)
+			w.stored(len(batch) - rejected.Rejected)
+			w.drop(dropReasonRejected, rejected.Rejected)
+			if w.metrics != nil {
+				w.metrics.StatusLogWriteFailed()
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.Error(spanCtx, "Dropping status logs rejected by database", err, logger.Fields{
+				"batch_size": len(batch),
+				"rejected":   rejected.Rejected,
+			})
+			return
+		}
+
+		var unsent *service.UnsentLogsError
+		retryable := stderrors.As(err, &unsent) && unsent.Stored >= 0 && unsent.Stored < len(batch)
+		if retryable {
+			w.stored(unsent.Stored)
+			batch = batch[unsent.Stored:]
+		}
+
+		if !retryable || attempt >= w.maxRetries || closing {
+			w.failedFlushes.Add(1)
+			w.drop(dropReasonWriteFailed, len(batch))
+			if w.metrics != nil {
+				w.metrics.StatusLogWriteFailed()
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.Error(spanCtx, "Dropping status logs after failed write", err, logger.Fields{
+				"batch_size": len(batch),
+				"attempts":   attempt + 1,
+			})
+			return
+		}
+
+		w.retries.Add(1)
+		if w.metrics != nil {
+			w.metrics.StatusLogWriteRetried()
+		}
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1)))
+		log.Warn(spanCtx, "Retrying status log write", logger.Fields{
+			"batch_size": len(batch),
+			"attempt":    attempt + 1,
+			"backoff":    backoff.String(),
+			"error":      err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-w.stopCh:
+			timer.Stop()
+			closing = true
+		}
+		backoff *= 2
+	}
+}
+
+// stored counts n written logs
+func (w *BatchWriter) stored(n int) {
+	w.written.Add(int64(n))
+	if w.metrics != nil {
+		w.metrics.StatusLogsWritten(n)
+	}
+}
+
+// drop counts n logs dropped for reason
+func (w *BatchWriter) drop(reason string, n int) {
+	w.dropped.Add(int64(n))
+	if w.metrics != nil {
+		w.metrics.StatusLogsDropped(reason, n)
+	}
+}
@@ -0,0 +1,380 @@
+package checker
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+// recordingRepository records batch sizes and lets tests inject failures
+type recordingRepository struct {
+	*memory.ServiceRepository
+
+	mu      sync.Mutex
+	batches []int
+	failFn  func(call int) error
+}
+
+func newRecordingRepository() *recordingRepository {
+	return &recordingRepository{ServiceRepository: memory.NewServiceRepository()}
+}
+
+func (r *recordingRepository) SaveStatusLogs(ctx context.Context, logs []*service.StatusLog) error {
+	r.mu.Lock()
+	call := len(r.batches)
+	r.batches = append(r.batches, len(logs))
+	failFn := r.failFn
+	r.mu.Unlock()
+
+	if failFn != nil {
+		if err := failFn(call); err != nil {
+			return err
+		}
+	}
+	return r.ServiceRepository.SaveStatusLogs(ctx, logs)
+}
+
+func (r *recordingRepository) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.batches...)
+}
+
+func testStatusLog(name string) *service.StatusLog {
+	return &service.StatusLog{ServiceName: name, Status: statusOperational, Timestamp: time.Now()}
+}
+
+func TestBatchWriter_FlushesWhenBatchIsFull(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	writer := NewBatchWriter(repo, WithBatchSize(3), WithFlushInterval(time.Hour))
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(repo.batchSizes()) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, writer.Close(ctx))
+	assert.Equal(t, []int{3, 3, 1}, repo.batchSizes())
+
+	stats := writer.Stats()
+	assert.Equal(t, int64(7), stats.Written)
+	assert.Equal(t, int64(3), stats.Flushes)
+	assert.Zero(t, stats.Dropped)
+
+	history, err := repo.GetStatusHistory(ctx, "api", 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 7)
+}
+
+func TestBatchWriter_FlushesOnInterval(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	writer := NewBatchWriter(repo, WithBatchSize(100), WithFlushInterval(20*time.Millisecond))
+	defer func() { _ = writer.Close(ctx) }()
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	require.NoError(t, writer.Write(ctx, testStatusLog("web")))
+
+	assert.Eventually(t, func() bool {
+		return writer.Stats().Written == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{2}, repo.batchSizes())
+}
+
+func TestBatchWriter_Flush(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	writer := NewBatchWriter(repo, WithBatchSize(100), WithFlushInterval(time.Hour))
+	defer func() { _ = writer.Close(ctx) }()
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	require.NoError(t, writer.Flush(ctx))
+
+	assert.Equal(t, []int{1}, repo.batchSizes())
+	assert.Equal(t, int64(1), writer.Stats().Written)
+}
+
+func TestBatchWriter_RetriesUnsentLogs(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	repo.failFn = func(call int) error {
+		if call < 2 {
+			return &service.UnsentLogsError{Err: stderrors.New("connection refused")}
+		}
+		return nil
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(10), WithRetry(3, time.Millisecond))
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	require.NoError(t, writer.Flush(ctx))
+	require.NoError(t, writer.Close(ctx))
+
+	stats := writer.Stats()
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(1), stats.Written)
+	assert.Zero(t, stats.Dropped)
+	assert.Len(t, repo.batchSizes(), 3)
+}
+
+func TestBatchWriter_RetriesOnlyLogsNotStored(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	repo.failFn = func(call int) error {
+		if call == 0 {
+			return &service.UnsentLogsError{Stored: 2, Err: stderrors.New("connection refused")}
+		}
+		return nil
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(10), WithRetry(3, time.Millisecond))
+
+	for _, name := range []string{"api", "web", "db"} {
+		require.NoError(t, writer.Write(ctx, testStatusLog(name)))
+	}
+	require.NoError(t, writer.Flush(ctx))
+	require.NoError(t, writer.Close(ctx))
+
+	assert.Equal(t, []int{3, 1}, repo.batchSizes())
+	assert.Equal(t, int64(3), writer.Stats().Written)
+	assert.Zero(t, writer.Stats().Dropped)
+}
+
+func TestBatchWriter_DropsAfterRetriesExhausted(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	repo.failFn = func(int) error {
+		return &service.UnsentLogsError{Err: stderrors.New("database unavailable")}
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(10), WithRetry(2, time.Millisecond))
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	require.NoError(t, writer.Write(ctx, testStatusLog("web")))
+	require.NoError(t, writer.Flush(ctx))
+	require.NoError(t, writer.Close(ctx))
+
+	stats := writer.Stats()
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(2), stats.Dropped)
+	assert.Equal(t, int64(1), stats.FailedFlushes)
+	assert.Zero(t, stats.Written)
+}
+
+func TestBatchWriter_DoesNotRetryErrorsThatMayHaveWritten(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "rejected batch",
+			err:  errors.NewValidationError("status logs rejected by database"),
+		},
+		{
+			name: "timeout after send",
+			err:  errors.NewWithCause("failed to create status logs", errors.ErrorKindInternal, context.DeadlineExceeded),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newRecordingRepository()
+			repo.failFn = func(int) error {
+				return tt.err
+			}
+			writer := NewBatchWriter(repo, WithRetry(5, time.Millisecond))
+
+			require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+			require.NoError(t, writer.Close(ctx))
+
+			assert.Len(t, repo.batchSizes(), 1)
+			assert.Zero(t, writer.Stats().Retries)
+			assert.Equal(t, int64(1), writer.Stats().Dropped)
+		})
+	}
+}
+
+func TestBatchWriter_CloseCutsBackoffShort(t *testing.T) {
+	repo := newRecordingRepository()
+	repo.failFn = func(int) error {
+		return &service.UnsentLogsError{Err: stderrors.New("connection refused")}
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(1), WithRetry(5, time.Hour))
+
+	require.NoError(t, writer.Write(context.Background(), testStatusLog("api")))
+	require.Eventually(t, func() bool { return len(repo.batchSizes()) == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, writer.Close(ctx), "close does not wait for the backoff")
+
+	assert.Equal(t, []int{1, 1}, repo.batchSizes(), "a last attempt is made on close")
+	assert.Equal(t, int64(1), writer.Stats().Dropped)
+}
+
+func TestBatchWriter_CountsOnlyRejectedLogsAsDropped(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	repo.failFn = func(int) error {
+		return &service.RejectedLogsError{Rejected: 1, Err: stderrors.New("duplicate key")}
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(10), WithRetry(5, time.Millisecond))
+
+	for _, name := range []string{"api", "web", "db"} {
+		require.NoError(t, writer.Write(ctx, testStatusLog(name)))
+	}
+	require.NoError(t, writer.Close(ctx))
+
+	stats := writer.Stats()
+	assert.Len(t, repo.batchSizes(), 1, "rejected logs are not retried")
+	assert.Equal(t, int64(2), stats.Written)
+	assert.Equal(t, int64(1), stats.Dropped)
+	assert.Equal(t, int64(1), stats.FailedFlushes)
+}
+
+func TestBatchWriter_DropsWhenBufferIsFull(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	repo.failFn = func(call int) error {
+		if call == 0 {
+			close(started)
+			<-release
+		}
+		return nil
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(1), WithBufferSize(1))
+
+	// The first log is taken by the flush loop, which then blocks in the repository
+	require.NoError(t, writer.Write(ctx, testStatusLog("first")))
+	<-started
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("second")))
+	assert.ErrorIs(t, writer.Write(ctx, testStatusLog("third")), ErrWriterFull)
+
+	close(release)
+	require.NoError(t, writer.Close(ctx))
+
+	stats := writer.Stats()
+	assert.Equal(t, int64(2), stats.Written)
+	assert.Equal(t, int64(1), stats.Dropped)
+}
+
+func TestBatchWriter_WriteAfterClose(t *testing.T) {
+	ctx := context.Background()
+	writer := NewBatchWriter(newRecordingRepository())
+	require.NoError(t, writer.Close(ctx))
+	require.NoError(t, writer.Close(ctx))
+
+	assert.ErrorIs(t, writer.Write(ctx, testStatusLog("api")), ErrWriterClosed)
+	assert.ErrorIs(t, writer.Flush(ctx), ErrWriterClosed)
+	assert.Equal(t, int64(1), writer.Stats().Dropped)
+}
+
+func TestBatchWriter_TracesWritesLinkedToChecks(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	repo := newRecordingRepository()
+	repo.failFn = func(call int) error {
+		return errors.NewValidationError("invalid status log")
+	}
+	writer := NewBatchWriter(repo, WithFlushInterval(time.Hour), WithWriterTracerProvider(tp))
+
+	checkCtx, check := tp.Tracer("test").Start(ctx, "health_check")
+	require.NoError(t, writer.Write(checkCtx, testStatusLog("api")))
+	check.End()
+	require.NoError(t, writer.Write(ctx, testStatusLog("web")))
+	require.NoError(t, writer.Flush(ctx))
+	require.NoError(t, writer.Close(ctx))
+
+	var writes []sdktrace.ReadOnlySpan
+	for _, span := range exporter.GetSpans().Snapshots() {
+		if span.Name() == "status_logs.write" {
+			writes = append(writes, span)
+		}
+	}
+	require.Len(t, writes, 1)
+
+	write := writes[0]
+	assert.False(t, write.Parent().IsValid(), "batch writes start their own trace")
+	require.Len(t, write.Links(), 1, "untraced writes are not linked")
+	assert.Equal(t, check.SpanContext(), write.Links()[0].SpanContext)
+	assert.NotEqual(t, trace.SpanContext{}, write.SpanContext())
+	assert.Equal(t, codes.Error, write.Status().Code)
+}
+
+func TestService_RunHealthChecks_WithStatusLogWriter(t *testing.T) {
+	ctx := context.Background()
+	repo := newRecordingRepository()
+	for _, svc := range []*service.Service{
+		{Name: "API", Slug: "api", URL: "http://127.0.0.1:0", ExpectedStatus: 200, Enabled: true},
+		{Name: "Web", Slug: "web", URL: "http://127.0.0.1:0", ExpectedStatus: 200, Enabled: true},
+	} {
+		require.NoError(t, repo.Create(ctx, svc))
+	}
+
+	writer := NewBatchWriter(repo, WithBatchSize(100), WithFlushInterval(time.Hour))
+	svc := NewService(repo, WithTimeout(time.Second), WithStatusLogWriter(writer))
+
+	require.NoError(t, svc.RunHealthChecks(ctx))
+	require.NoError(t, svc.RunHealthChecks(ctx))
+	assert.Empty(t, repo.batchSizes(), "results should be buffered until a flush")
+
+	require.NoError(t, writer.Close(ctx))
+	assert.Equal(t, []int{4}, repo.batchSizes())
+}
+
+func TestBatchWriter_ReportsMetrics(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	repo := newRecordingRepository()
+	repo.failFn = func(call int) error {
+		switch call {
+		case 0:
+			return &service.UnsentLogsError{Err: stderrors.New("connection refused")}
+		case 1:
+			return &service.RejectedLogsError{Rejected: 1, Err: stderrors.New("duplicate key")}
+		}
+		return nil
+	}
+	writer := NewBatchWriter(repo, WithBatchSize(10), WithFlushInterval(time.Hour),
+		WithRetry(3, time.Millisecond), WithWriterMetrics(m))
+
+	require.NoError(t, writer.Write(ctx, testStatusLog("api")))
+	require.NoError(t, writer.Write(ctx, testStatusLog("web")))
+	require.NoError(t, writer.Flush(ctx))
+	require.NoError(t, writer.Close(ctx))
+	assert.ErrorIs(t, writer.Write(ctx, testStatusLog("db")), ErrWriterClosed)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `uptime_status_logs_written_total 1`)
+	assert.Contains(t, body, `uptime_status_logs_dropped_total{reason="rejected"} 1`)
+	assert.Contains(t, body, `uptime_status_logs_dropped_total{reason="closed"} 1`)
+	assert.Contains(t, body, `uptime_status_log_write_retries_total 1`)
+	assert.Contains(t, body, `uptime_status_log_write_failures_total 1`)
+}
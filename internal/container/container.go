@@ -3,18 +3,28 @@ package container
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/application/handlers"
 	"github.com/sukhera/uptime-monitor/internal/application/middleware"
 	"github.com/sukhera/uptime-monitor/internal/application/routes"
 	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
 	"github.com/sukhera/uptime-monitor/internal/infrastructure/database"
 	mongodb "github.com/sukhera/uptime-monitor/internal/infrastructure/database/mongo"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/postgres"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
 	"github.com/sukhera/uptime-monitor/internal/server"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+	"go.opentelemetry.io/otel"
 )
 
 // ContainerOption is a function that configures the container
@@ -22,18 +32,30 @@ type ContainerOption func(*Container) error
 
 // Container manages application dependencies with proper interfaces
 type Container struct {
-	mu       sync.RWMutex
-	services map[string]interface{}
-	config   *config.Config
-	logger   logger.Logger
+	mu        sync.RWMutex
+	services  map[string]interface{}
+	config    *config.Config
+	logger    logger.Logger
+	buildInfo handlers.BuildInfo
+
+	// pipelines are the middleware pipelines created for servers, updated
+	// on reload with the configuration returned by loadConfig
+	pipelines  []*middleware.Pipeline
+	loadConfig func() (*config.Config, error)
 }
 
 // New creates a new dependency injection container
 func New(cfg *config.Config, opts ...ContainerOption) (*Container, error) {
 	container := &Container{
-		services: make(map[string]interface{}),
-		config:   cfg,
-		logger:   logger.Get(),
+		services:   make(map[string]interface{}),
+		config:     cfg,
+		logger:     logger.Get(),
+		loadConfig: config.Reload,
+		buildInfo: handlers.BuildInfo{
+			Version:   "dev",
+			Commit:    "unknown",
+			BuildDate: "unknown",
+		},
 	}
 
 	// Apply all options
@@ -46,6 +68,22 @@ func New(cfg *config.Config, opts ...ContainerOption) (*Container, error) {
 	return container, nil
 }
 
+// WithBuildInfo sets the build information reported by the API handlers
+func WithBuildInfo(info handlers.BuildInfo) ContainerOption {
+	return func(c *Container) error {
+		c.buildInfo = info
+		return nil
+	}
+}
+
+// WithConfigLoader sets how the configuration is re-read on reload
+func WithConfigLoader(load func() (*config.Config, error)) ContainerOption {
+	return func(c *Container) error {
+		c.loadConfig = load
+		return nil
+	}
+}
+
 // WithDatabase adds a database service to the container
 func WithDatabase(db database.Interface) ContainerOption {
 	return func(c *Container) error {
@@ -54,6 +92,14 @@ func WithDatabase(db database.Interface) ContainerOption {
 	}
 }
 
+// WithPostgresDatabase adds a PostgreSQL database to the container
+func WithPostgresDatabase(db postgres.Interface) ContainerOption {
+	return func(c *Container) error {
+		c.Register("postgres_database", db)
+		return nil
+	}
+}
+
 // WithServiceRepository adds a service repository to the container
 func WithServiceRepository(repo service.Repository) ContainerOption {
 	return func(c *Container) error {
@@ -62,6 +108,46 @@ func WithServiceRepository(repo service.Repository) ContainerOption {
 	}
 }
 
+// WithPolicyRepository adds a notification policy repository to the container
+func WithPolicyRepository(repo alert.PolicyRepository) ContainerOption {
+	return func(c *Container) error {
+		c.Register("policy_repository", repo)
+		return nil
+	}
+}
+
+// WithAlertRepository adds an alert repository to the container
+func WithAlertRepository(repo alert.AlertRepository) ContainerOption {
+	return func(c *Container) error {
+		c.Register("alert_repository", repo)
+		return nil
+	}
+}
+
+// WithOutboxRepository adds a notification outbox repository to the container
+func WithOutboxRepository(repo alert.OutboxRepository) ContainerOption {
+	return func(c *Container) error {
+		c.Register("outbox_repository", repo)
+		return nil
+	}
+}
+
+// WithSilenceRepository adds a silence repository to the container
+func WithSilenceRepository(repo alert.SilenceRepository) ContainerOption {
+	return func(c *Container) error {
+		c.Register("silence_repository", repo)
+		return nil
+	}
+}
+
+// WithTemplateRepository adds a message template repository to the container
+func WithTemplateRepository(repo alert.TemplateRepository) ContainerOption {
+	return func(c *Container) error {
+		c.Register("template_repository", repo)
+		return nil
+	}
+}
+
 // WithStatusHandler adds a status handler to the container
 func WithStatusHandler(handler *handlers.StatusHandler) ContainerOption {
 	return func(c *Container) error {
@@ -108,7 +194,10 @@ func (c *Container) GetDatabase() (database.Interface, error) {
 	}
 
 	// Create new database connection using functional options
-	db, err := mongodb.NewConnection(c.config.Database.URI, c.config.Database.Name)
+	db, err := mongodb.NewConnection(c.config.Database.URI, c.config.Database.Name,
+		mongodb.WithWriteErrorHook(c.GetMetrics().MongoWriteError),
+		mongodb.WithTracing(otel.GetTracerProvider()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database connection: %w", err)
 	}
@@ -117,12 +206,38 @@ func (c *Container) GetDatabase() (database.Interface, error) {
 	return db, nil
 }
 
-// GetServiceRepository returns the service repository
+// GetPostgresDatabase returns the PostgreSQL database
+func (c *Container) GetPostgresDatabase() (postgres.Interface, error) {
+	if db, exists := c.Get("postgres_database"); exists {
+		return db.(postgres.Interface), nil
+	}
+
+	db, err := postgres.NewConnectionWithTimeout(c.config.Database.URI, c.config.Database.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create postgres connection: %w", err)
+	}
+
+	c.Register("postgres_database", db)
+	return db, nil
+}
+
+// GetServiceRepository returns the service repository for the configured driver
 func (c *Container) GetServiceRepository() (service.Repository, error) {
 	if repo, exists := c.Get("service_repository"); exists {
 		return repo.(service.Repository), nil
 	}
 
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewServiceRepository(db)
+		c.Register("service_repository", repo)
+		return repo, nil
+	}
+
 	// Get database dependency
 	db, err := c.GetDatabase()
 	if err != nil {
@@ -140,82 +255,788 @@ func (c *Container) GetServiceRepository() (service.Repository, error) {
 	return repo, nil
 }
 
-// GetStatusHandler returns the status handler
-func (c *Container) GetStatusHandler() (*handlers.StatusHandler, error) {
-	if handler, exists := c.Get("status_handler"); exists {
-		return handler.(*handlers.StatusHandler), nil
+// GetPolicyRepository returns the notification policy repository for the
+// configured driver
+func (c *Container) GetPolicyRepository() (alert.PolicyRepository, error) {
+	if repo, exists := c.Get("policy_repository"); exists {
+		return repo.(alert.PolicyRepository), nil
+	}
+
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewPolicyRepository(db)
+		c.Register("policy_repository", repo)
+		return repo, nil
 	}
 
-	// Get database dependency
 	db, err := c.GetDatabase()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database: %w", err)
 	}
 
-	// Create status handler using functional options
 	mongoDB, ok := db.(*mongodb.Database)
 	if !ok {
 		return nil, fmt.Errorf("database is not MongoDB implementation")
 	}
 
-	// Create build info with defaults
-	buildInfo := handlers.BuildInfo{
-		Version:   "dev",
-		Commit:    "unknown",
-		BuildDate: "unknown",
+	repo := mongodb.NewPolicyRepository(mongoDB)
+	c.Register("policy_repository", repo)
+	return repo, nil
+}
+
+// GetPolicyHandler returns the notification policy handler
+func (c *Container) GetPolicyHandler() (*handlers.PolicyHandler, error) {
+	if handler, exists := c.Get("policy_handler"); exists {
+		return handler.(*handlers.PolicyHandler), nil
 	}
 
-	handler := handlers.NewStatusHandler(mongoDB, buildInfo)
+	repo, err := c.GetPolicyRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy repository: %w", err)
+	}
+
+	handler := handlers.NewPolicyHandler(repo, c.buildInfo)
+	c.Register("policy_handler", handler)
+	return handler, nil
+}
+
+// GetAlertRepository returns the alert repository for the configured driver
+func (c *Container) GetAlertRepository() (alert.AlertRepository, error) {
+	if repo, exists := c.Get("alert_repository"); exists {
+		return repo.(alert.AlertRepository), nil
+	}
+
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewAlertRepository(db)
+		c.Register("alert_repository", repo)
+		return repo, nil
+	}
+
+	db, err := c.GetDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	mongoDB, ok := db.(*mongodb.Database)
+	if !ok {
+		return nil, fmt.Errorf("database is not MongoDB implementation")
+	}
+
+	repo := mongodb.NewAlertRepository(mongoDB)
+	c.Register("alert_repository", repo)
+	return repo, nil
+}
+
+// GetAlertHandler returns the alert handler
+func (c *Container) GetAlertHandler() (*handlers.AlertHandler, error) {
+	if handler, exists := c.Get("alert_handler"); exists {
+		return handler.(*handlers.AlertHandler), nil
+	}
+
+	repo, err := c.GetAlertRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert repository: %w", err)
+	}
+
+	handler := handlers.NewAlertHandler(repo, c.buildInfo)
+	c.Register("alert_handler", handler)
+	return handler, nil
+}
+
+// GetOutboxRepository returns the notification outbox for the configured
+// driver
+func (c *Container) GetOutboxRepository() (alert.OutboxRepository, error) {
+	if repo, exists := c.Get("outbox_repository"); exists {
+		return repo.(alert.OutboxRepository), nil
+	}
+
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewOutboxRepository(db)
+		c.Register("outbox_repository", repo)
+		return repo, nil
+	}
+
+	db, err := c.GetDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	mongoDB, ok := db.(*mongodb.Database)
+	if !ok {
+		return nil, fmt.Errorf("database is not MongoDB implementation")
+	}
+
+	repo := mongodb.NewOutboxRepository(mongoDB)
+	c.Register("outbox_repository", repo)
+	return repo, nil
+}
+
+// GetOutboxHandler returns the outbox handler
+func (c *Container) GetOutboxHandler() (*handlers.OutboxHandler, error) {
+	if handler, exists := c.Get("outbox_handler"); exists {
+		return handler.(*handlers.OutboxHandler), nil
+	}
+
+	repo, err := c.GetOutboxRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox repository: %w", err)
+	}
+
+	handler := handlers.NewOutboxHandler(repo, c.buildInfo)
+	c.Register("outbox_handler", handler)
+	return handler, nil
+}
+
+// GetSilenceRepository returns the silence repository for the configured
+// driver
+func (c *Container) GetSilenceRepository() (alert.SilenceRepository, error) {
+	if repo, exists := c.Get("silence_repository"); exists {
+		return repo.(alert.SilenceRepository), nil
+	}
+
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewSilenceRepository(db)
+		c.Register("silence_repository", repo)
+		return repo, nil
+	}
+
+	db, err := c.GetDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	mongoDB, ok := db.(*mongodb.Database)
+	if !ok {
+		return nil, fmt.Errorf("database is not MongoDB implementation")
+	}
+
+	repo := mongodb.NewSilenceRepository(mongoDB)
+	c.Register("silence_repository", repo)
+	return repo, nil
+}
+
+// GetSilenceHandler returns the silence handler
+func (c *Container) GetSilenceHandler() (*handlers.SilenceHandler, error) {
+	if handler, exists := c.Get("silence_handler"); exists {
+		return handler.(*handlers.SilenceHandler), nil
+	}
+
+	repo, err := c.GetSilenceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silence repository: %w", err)
+	}
+
+	handler := handlers.NewSilenceHandler(repo, c.buildInfo)
+	c.Register("silence_handler", handler)
+	return handler, nil
+}
+
+// GetTemplateRepository returns the message template repository for the
+// configured driver
+func (c *Container) GetTemplateRepository() (alert.TemplateRepository, error) {
+	if repo, exists := c.Get("template_repository"); exists {
+		return repo.(alert.TemplateRepository), nil
+	}
+
+	if c.config.Database.Driver == config.DriverPostgres {
+		db, err := c.GetPostgresDatabase()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres database: %w", err)
+		}
+
+		repo := postgres.NewTemplateRepository(db)
+		c.Register("template_repository", repo)
+		return repo, nil
+	}
+
+	db, err := c.GetDatabase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	mongoDB, ok := db.(*mongodb.Database)
+	if !ok {
+		return nil, fmt.Errorf("database is not MongoDB implementation")
+	}
+
+	repo := mongodb.NewTemplateRepository(mongoDB)
+	c.Register("template_repository", repo)
+	return repo, nil
+}
+
+// GetTemplateStore returns the store serving each channel's message
+// template. The template files configured for chat channels are the
+// defaults that stored templates are parsed over.
+func (c *Container) GetTemplateStore() (*notifier.TemplateStore, error) {
+	if store, exists := c.Get("template_store"); exists {
+		return store.(*notifier.TemplateStore), nil
+	}
+
+	options := []notifier.TemplateStoreOption{
+		notifier.WithTemplateIncidentURL(c.config.Notifier.IncidentURL),
+		notifier.WithTemplateLogger(c.logger),
+	}
+	files := map[string]string{
+		"slack":   c.config.Notifier.SlackTemplateFile,
+		"teams":   c.config.Notifier.TeamsTemplateFile,
+		"discord": c.config.Notifier.DiscordTemplateFile,
+	}
+	for channel, path := range files {
+		tmpl, err := loadMessageTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, notifier.WithChannelTemplate(channel, tmpl))
+	}
+
+	repo, err := c.GetTemplateRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template repository: %w", err)
+	}
+
+	store := notifier.NewTemplateStore(repo, options...)
+	c.Register("template_store", store)
+	return store, nil
+}
+
+// GetTemplateHandler returns the message template handler
+func (c *Container) GetTemplateHandler() (*handlers.TemplateHandler, error) {
+	if handler, exists := c.Get("template_handler"); exists {
+		return handler.(*handlers.TemplateHandler), nil
+	}
+
+	repo, err := c.GetTemplateRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template repository: %w", err)
+	}
+
+	store, err := c.GetTemplateStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template store: %w", err)
+	}
+
+	handler := handlers.NewTemplateHandler(repo, store, c.buildInfo)
+	c.Register("template_handler", handler)
+	return handler, nil
+}
+
+// GetStatusHandler returns the status handler
+func (c *Container) GetStatusHandler() (*handlers.StatusHandler, error) {
+	if handler, exists := c.Get("status_handler"); exists {
+		return handler.(*handlers.StatusHandler), nil
+	}
+
+	// Get repository dependency
+	repo, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	handler := handlers.NewStatusHandler(repo, c.buildInfo)
 	c.Register("status_handler", handler)
 	return handler, nil
 }
 
+// GetProbeHandler returns the handler probing services for Prometheus
+func (c *Container) GetProbeHandler() (*handlers.ProbeHandler, error) {
+	if handler, exists := c.Get("probe_handler"); exists {
+		return handler.(*handlers.ProbeHandler), nil
+	}
+
+	repo, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	handler := handlers.NewProbeHandler(repo, checker.NewProber(), c.buildInfo)
+	c.Register("probe_handler", handler)
+	return handler, nil
+}
+
 // GetCheckerService returns the checker service
 func (c *Container) GetCheckerService() (checker.ServiceInterface, error) {
 	if service, exists := c.Get("checker"); exists {
 		return service.(checker.ServiceInterface), nil
 	}
 
-	// Get database dependency
-	db, err := c.GetDatabase()
+	// Get repository dependency
+	repo, err := c.GetServiceRepository()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get database: %w", err)
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	writer, err := c.GetStatusLogWriter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status log writer: %w", err)
 	}
 
 	// Create checker service with functional options
-	checkerService := checker.NewService(db,
+	checkerService := checker.NewService(repo,
 		checker.WithTimeout(c.config.Database.Timeout),
+		checker.WithStatusLogWriter(writer),
+		checker.WithMetrics(c.GetMetrics()),
 	)
 
 	c.Register("checker", checkerService)
 	return checkerService, nil
 }
 
+// GetStatusLogWriter returns the buffered status log writer
+func (c *Container) GetStatusLogWriter() (*checker.BatchWriter, error) {
+	if writer, exists := c.Get("status_log_writer"); exists {
+		return writer.(*checker.BatchWriter), nil
+	}
+
+	repo, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	writer := checker.NewBatchWriter(repo,
+		checker.WithBatchSize(c.config.Checker.BatchSize),
+		checker.WithFlushInterval(c.config.Checker.FlushInterval),
+		checker.WithWriteTimeout(c.config.Database.Timeout),
+		checker.WithWriterMetrics(c.GetMetrics()),
+	)
+
+	c.Register("status_log_writer", writer)
+	return writer, nil
+}
+
+// GetNotifier returns a dispatcher for the configured notification channels.
+// The dispatcher is empty when no channel is configured.
+func (c *Container) GetNotifier() (*notifier.Router, error) {
+	if n, exists := c.Get("notifier"); exists {
+		return n.(*notifier.Router), nil
+	}
+
+	notifiers, err := c.notificationChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// Policies only matter once there is a channel to route to
+	if len(notifiers) == 0 {
+		router := notifier.NewRouter(nil, nil, nil)
+		c.Register("notifier", router)
+		return router, nil
+	}
+
+	policies, err := c.GetPolicyRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy repository: %w", err)
+	}
+
+	services, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	alerts, err := c.GetAlertRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert repository: %w", err)
+	}
+
+	silences, err := c.GetSilenceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silence repository: %w", err)
+	}
+
+	outbox, err := c.GetOutboxDispatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox dispatcher: %w", err)
+	}
+
+	var queue notifier.Queue = outbox
+	if c.config.Notifier.GroupWait > 0 {
+		grouper, err := c.GetNotificationGrouper()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notification grouper: %w", err)
+		}
+		queue = grouper
+	}
+
+	router := notifier.NewRouter(policies, services, notifiers,
+		notifier.WithRouterLogger(c.logger),
+		notifier.WithRouterAlerts(alerts),
+		notifier.WithRouterSilences(silences),
+		notifier.WithRouterReminders(c.config.Notifier.ReminderInterval),
+		notifier.WithRouterQueue(queue),
+	)
+
+	c.Register("notifier", router)
+	return router, nil
+}
+
+// GetNotificationGrouper returns the grouper that batches failures into
+// digests before they are queued in the outbox
+func (c *Container) GetNotificationGrouper() (*notifier.Grouper, error) {
+	if g, exists := c.Get("notification_grouper"); exists {
+		return g.(*notifier.Grouper), nil
+	}
+
+	channels, err := c.notificationChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	outbox, err := c.GetOutboxDispatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox dispatcher: %w", err)
+	}
+
+	g := notifier.NewGrouper(channels,
+		notifier.WithGroupBy(c.config.Notifier.GroupBy...),
+		notifier.WithGroupWait(c.config.Notifier.GroupWait),
+		notifier.WithGroupInterval(c.config.Notifier.GroupInterval),
+		notifier.WithGroupServices(services),
+		notifier.WithGroupQueue(outbox),
+		notifier.WithGroupLogger(c.logger),
+	)
+	c.Register("notification_grouper", g)
+	return g, nil
+}
+
+// GetOutboxDispatcher returns the dispatcher that delivers queued
+// notifications to the configured channels
+func (c *Container) GetOutboxDispatcher() (*notifier.OutboxDispatcher, error) {
+	if d, exists := c.Get("outbox_dispatcher"); exists {
+		return d.(*notifier.OutboxDispatcher), nil
+	}
+
+	channels, err := c.notificationChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.GetOutboxRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox repository: %w", err)
+	}
+
+	d := notifier.NewOutboxDispatcher(repo, channels,
+		notifier.WithOutboxInterval(c.config.Notifier.OutboxInterval),
+		notifier.WithOutboxMaxAttempts(c.config.Notifier.OutboxMaxAttempts),
+		notifier.WithOutboxLogger(c.logger),
+		notifier.WithOutboxMetrics(c.GetMetrics()),
+	)
+	c.Register("outbox_dispatcher", d)
+	return d, nil
+}
+
+// notificationChannels builds the configured notification channels once
+func (c *Container) notificationChannels() ([]notifier.Notifier, error) {
+	if channels, exists := c.Get("notification_channels"); exists {
+		return channels.([]notifier.Notifier), nil
+	}
+
+	cfg := c.config.Notifier
+
+	// Each configured channel is built once the template store is known
+	var builders []func(templates notifier.TemplateSource) (notifier.Notifier, error)
+	add := func(build func(templates notifier.TemplateSource) notifier.Notifier) {
+		builders = append(builders, func(templates notifier.TemplateSource) (notifier.Notifier, error) {
+			return build(templates), nil
+		})
+	}
+
+	if cfg.WebhookURL != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewWebhookNotifier(cfg.WebhookURL,
+				notifier.WithWebhookSecret(cfg.WebhookSecret),
+				notifier.WithWebhookTemplates(templates),
+			)
+		})
+	}
+
+	if cfg.SlackWebhookURL != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewSlackNotifier(cfg.SlackWebhookURL, notifier.WithChatTemplates(templates))
+		})
+	}
+
+	if cfg.TeamsWebhookURL != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewTeamsNotifier(cfg.TeamsWebhookURL, notifier.WithChatTemplates(templates))
+		})
+	}
+
+	if cfg.DiscordWebhookURL != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewDiscordNotifier(cfg.DiscordWebhookURL, notifier.WithChatTemplates(templates))
+		})
+	}
+
+	if cfg.SMTPHost != "" {
+		builders = append(builders, func(templates notifier.TemplateSource) (notifier.Notifier, error) {
+			return c.newEmailNotifier(templates)
+		})
+	}
+
+	if cfg.PagerDutyRoutingKey != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewPagerDutyNotifier(cfg.PagerDutyRoutingKey,
+				notifier.WithPagerDutyURL(cfg.PagerDutyEventsURL),
+				notifier.WithPagerDutyTemplates(templates),
+			)
+		})
+	}
+
+	if cfg.OpsgenieAPIKey != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewOpsgenieNotifier(cfg.OpsgenieAPIKey,
+				notifier.WithOpsgenieAPIURL(cfg.OpsgenieAPIURL),
+				notifier.WithOpsgenieTemplates(templates),
+			)
+		})
+	}
+
+	if cfg.TelegramBotToken != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID,
+				notifier.WithTelegramAPIURL(cfg.TelegramAPIURL),
+				notifier.WithTelegramTemplates(templates),
+			)
+		})
+	}
+
+	if cfg.NtfyTopic != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewNtfyNotifier(cfg.NtfyTopic,
+				notifier.WithNtfyServerURL(cfg.NtfyURL),
+				notifier.WithNtfyToken(cfg.NtfyToken),
+				notifier.WithNtfyTemplates(templates),
+			)
+		})
+	}
+
+	if cfg.PushoverAppToken != "" {
+		add(func(templates notifier.TemplateSource) notifier.Notifier {
+			return notifier.NewPushoverNotifier(cfg.PushoverAppToken, cfg.PushoverUserKey,
+				notifier.WithPushoverURL(cfg.PushoverAPIURL),
+				notifier.WithPushoverTemplates(templates),
+			)
+		})
+	}
+
+	var notifiers []notifier.Notifier
+	if len(builders) > 0 {
+		templates, err := c.GetTemplateStore()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template store: %w", err)
+		}
+
+		for _, build := range builders {
+			n, err := build(templates)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, n)
+		}
+	}
+
+	c.Register("notification_channels", notifiers)
+	return notifiers, nil
+}
+
 // GetHTTPServer returns the HTTP server
 func (c *Container) GetHTTPServer() (server.Interface, error) {
 	if srv, exists := c.Get("http_server"); exists {
 		return srv.(server.Interface), nil
 	}
 
-	// Get status handler
-	statusHandler, err := c.GetStatusHandler()
+	router, err := c.GetRouter()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get status handler: %w", err)
+		return nil, err
 	}
 
-	// Setup routes
-	router := routes.SetupRoutes(statusHandler)
-
-	// Apply middleware
-	corsMiddleware := middleware.NewCORS()
-	handler := corsMiddleware.Handler(router)
+	// Apply the configured middleware
+	pipeline, err := c.NewMiddlewarePipeline(router)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create middleware pipeline: %w", err)
+	}
+	c.logger.Info(context.Background(), "Configured API middleware", logger.Fields{"middleware": pipeline.Names()})
 
 	// Create server using functional options
-	srv := server.New(handler, c.config)
+	srv := server.New(pipeline.Then(router), c.config)
 	c.Register("http_server", srv)
 	return srv, nil
 }
 
+// GetRouter returns a router serving the API routes
+func (c *Container) GetRouter() (*http.ServeMux, error) {
+	statusHandler, err := c.GetStatusHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status handler: %w", err)
+	}
+
+	policyHandler, err := c.GetPolicyHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy handler: %w", err)
+	}
+
+	alertHandler, err := c.GetAlertHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert handler: %w", err)
+	}
+
+	outboxHandler, err := c.GetOutboxHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox handler: %w", err)
+	}
+
+	silenceHandler, err := c.GetSilenceHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silence handler: %w", err)
+	}
+
+	templateHandler, err := c.GetTemplateHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template handler: %w", err)
+	}
+
+	probeHandler, err := c.GetProbeHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get probe handler: %w", err)
+	}
+
+	return routes.SetupRoutes(routes.Handlers{
+		Status:   statusHandler,
+		Policy:   policyHandler,
+		Alert:    alertHandler,
+		Outbox:   outboxHandler,
+		Silence:  silenceHandler,
+		Template: templateHandler,
+		Probe:    probeHandler,
+		Metrics:  c.GetMetrics().Handler(),
+	}), nil
+}
+
+// NewMiddlewarePipeline returns the configured API middleware for a server
+// whose routes are matched by routes. Its IP lists are updated by
+// ReloadMiddleware.
+func (c *Container) NewMiddlewarePipeline(routes middleware.RouteMatcher) (*middleware.Pipeline, error) {
+	pipeline, err := middleware.NewPipeline(c.config.Middleware, routes,
+		middleware.WithPipelineMetrics(c.GetMetrics()),
+		middleware.WithPipelineTracerProvider(otel.GetTracerProvider()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.pipelines = append(c.pipelines, pipeline)
+	c.mu.Unlock()
+
+	return pipeline, nil
+}
+
+// ReloadMiddleware re-reads the configuration and applies its IP allow and
+// deny lists to the middleware pipelines of the container. The current
+// lists are kept when the configuration cannot be loaded or is invalid.
+func (c *Container) ReloadMiddleware(ctx context.Context) error {
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+
+	c.mu.RLock()
+	pipelines := append([]*middleware.Pipeline(nil), c.pipelines...)
+	c.mu.RUnlock()
+
+	for _, pipeline := range pipelines {
+		if err := pipeline.Reload(cfg.Middleware); err != nil {
+			return fmt.Errorf("failed to reload IP lists: %w", err)
+		}
+	}
+
+	c.logger.Info(ctx, "Reloaded IP lists", logger.Fields{
+		"allow": cfg.Middleware.IPAllowlist,
+		"deny":  cfg.Middleware.IPDenylist,
+		"paths": cfg.Middleware.IPFilterPaths,
+	})
+	return nil
+}
+
+// WatchReloads calls ReloadMiddleware on every SIGHUP until ctx is done
+func (c *Container) WatchReloads(ctx context.Context) {
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+
+	for {
+		select {
+		case <-hupChan:
+			if err := c.ReloadMiddleware(ctx); err != nil {
+				c.logger.Error(ctx, "Failed to reload middleware, keeping the current IP lists", err, nil)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetMetrics returns the Prometheus collectors shared by the services of the container
+func (c *Container) GetMetrics() *metrics.Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m, exists := c.services["metrics"]; exists {
+		return m.(*metrics.Metrics)
+	}
+
+	m := metrics.New()
+	c.services["metrics"] = m
+	return m
+}
+
+// GetMetricsServer returns a server exposing /metrics on the checker metrics
+// address, or nil when the address is not configured
+func (c *Container) GetMetricsServer() *http.Server {
+	if c.config.Checker.MetricsAddr == "" {
+		return nil
+	}
+
+	if srv, exists := c.Get("metrics_server"); exists {
+		return srv.(*http.Server)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", c.GetMetrics().Handler())
+
+	srv := &http.Server{
+		Addr:              c.config.Checker.MetricsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	c.Register("metrics_server", srv)
+	return srv
+}
+
 // GetConfig returns the configuration
 func (c *Container) GetConfig() *config.Config {
 	return c.config
@@ -232,6 +1053,40 @@ func (c *Container) Shutdown(ctx context.Context) error {
 	defer c.mu.Unlock()
 
 	var lastErr error
+
+	// Flush buffered status logs before the database connections are closed
+	if service, exists := c.services["status_log_writer"]; exists {
+		if writer, ok := service.(*checker.BatchWriter); ok {
+			if err := writer.Close(ctx); err != nil {
+				lastErr = err
+				c.logger.Error(ctx, "Failed to flush status log writer", err, nil)
+			}
+		}
+		delete(c.services, "status_log_writer")
+	}
+
+	// Send pending notification groups while the outbox still accepts them
+	if service, exists := c.services["notification_grouper"]; exists {
+		if grouper, ok := service.(*notifier.Grouper); ok {
+			if err := grouper.Close(ctx); err != nil {
+				lastErr = err
+				c.logger.Error(ctx, "Failed to flush notification groups", err, nil)
+			}
+		}
+		delete(c.services, "notification_grouper")
+	}
+
+	// Stop delivering notifications; pending deliveries stay in the outbox
+	if service, exists := c.services["outbox_dispatcher"]; exists {
+		if dispatcher, ok := service.(*notifier.OutboxDispatcher); ok {
+			if err := dispatcher.Close(ctx); err != nil {
+				lastErr = err
+				c.logger.Error(ctx, "Failed to stop outbox dispatcher", err, nil)
+			}
+		}
+		delete(c.services, "outbox_dispatcher")
+	}
+
 	for name, service := range c.services {
 		if closer, ok := service.(interface{ Close() error }); ok {
 			if err := closer.Close(); err != nil {
@@ -289,3 +1144,50 @@ func (c *Container) MustGetHTTPServer() server.Interface {
 	}
 	return srv
 }
+
+// newEmailNotifier builds the SMTP notifier, including recent status history in emails
+func (c *Container) newEmailNotifier(messages notifier.TemplateSource) (*notifier.EmailNotifier, error) {
+	cfg := c.config.Notifier
+
+	templates := notifier.DefaultEmailTemplates()
+	if cfg.SMTPTemplateDir != "" {
+		var err error
+		if templates, err = notifier.LoadEmailTemplates(cfg.SMTPTemplateDir); err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := c.GetServiceRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service repository: %w", err)
+	}
+
+	return notifier.NewEmailNotifier(cfg.SMTPHost, cfg.SMTPFrom, cfg.SMTPTo,
+		notifier.WithEmailPort(cfg.SMTPPort),
+		notifier.WithEmailAuth(cfg.SMTPUsername, cfg.SMTPPassword),
+		notifier.WithEmailTLS(notifier.TLSMode(cfg.SMTPTLS)),
+		notifier.WithEmailTemplates(templates),
+		notifier.WithEmailMessageTemplates(messages),
+		notifier.WithEmailHistory(repo, 5),
+	), nil
+}
+
+// loadMessageTemplate parses a chat message template file, returning nil
+// (the built-in template) when no file is configured
+func loadMessageTemplate(path string) (*notifier.MessageTemplate, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message template %s: %w", path, err)
+	}
+
+	tmpl, err := notifier.ParseMessageTemplate(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template %s: %w", path, err)
+	}
+
+	return tmpl, nil
+}
@@ -2,10 +2,22 @@ package container
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/postgres"
+	"github.com/sukhera/uptime-monitor/internal/notifier"
 	"github.com/sukhera/uptime-monitor/internal/shared/config"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -14,14 +26,19 @@ import (
 // MockDatabase is a simple mock for testing
 type MockDatabase struct{}
 
-func (m *MockDatabase) Close() error                              { return nil }
-func (m *MockDatabase) Ping(ctx context.Context) error            { return nil }
-func (m *MockDatabase) HealthCheck(ctx context.Context) error     { return nil }
-func (m *MockDatabase) EnsureIndexes(ctx context.Context) error   { return nil }
-func (m *MockDatabase) ServicesCollection() *mongo.Collection     { return nil }
-func (m *MockDatabase) StatusLogsCollection() *mongo.Collection   { return nil }
-func (m *MockDatabase) IncidentsCollection() *mongo.Collection    { return nil }
-func (m *MockDatabase) MaintenancesCollection() *mongo.Collection { return nil }
+func (m *MockDatabase) Close() error                               { return nil }
+func (m *MockDatabase) Ping(ctx context.Context) error             { return nil }
+func (m *MockDatabase) HealthCheck(ctx context.Context) error      { return nil }
+func (m *MockDatabase) EnsureIndexes(ctx context.Context) error    { return nil }
+func (m *MockDatabase) ServicesCollection() *mongo.Collection      { return nil }
+func (m *MockDatabase) StatusLogsCollection() *mongo.Collection    { return nil }
+func (m *MockDatabase) IncidentsCollection() *mongo.Collection     { return nil }
+func (m *MockDatabase) MaintenancesCollection() *mongo.Collection  { return nil }
+func (m *MockDatabase) AlertPoliciesCollection() *mongo.Collection { return nil }
+func (m *MockDatabase) AlertsCollection() *mongo.Collection        { return nil }
+func (m *MockDatabase) OutboxCollection() *mongo.Collection        { return nil }
+func (m *MockDatabase) SilencesCollection() *mongo.Collection      { return nil }
+func (m *MockDatabase) TemplatesCollection() *mongo.Collection     { return nil }
 func (m *MockDatabase) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
 	return nil, nil
 }
@@ -41,12 +58,31 @@ func (m *MockDatabase) CountDocuments(ctx context.Context, filter interface{}, o
 	return 0, nil
 }
 
+// MockPostgresDatabase is a simple mock for testing
+type MockPostgresDatabase struct{}
+
+func (m *MockPostgresDatabase) Close() error                      { return nil }
+func (m *MockPostgresDatabase) Ping(ctx context.Context) error    { return nil }
+func (m *MockPostgresDatabase) Migrate(ctx context.Context) error { return nil }
+func (m *MockPostgresDatabase) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+func (m *MockPostgresDatabase) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (m *MockPostgresDatabase) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
 // MockServiceInterface is a simple mock for testing
 type MockServiceInterface struct{}
 
 func (m *MockServiceInterface) RunHealthChecks(ctx context.Context) error           { return nil }
 func (m *MockServiceInterface) AddObserver(observer checker.HealthCheckObserver)    {}
 func (m *MockServiceInterface) RemoveObserver(observer checker.HealthCheckObserver) {}
+func (m *MockServiceInterface) RunHealthChecksWithObservers(ctx context.Context, subject *checker.HealthCheckSubject) error {
+	return nil
+}
 
 func TestContainer_New(t *testing.T) {
 	cfg := config.New()
@@ -83,6 +119,336 @@ func TestContainer_WithCheckerService(t *testing.T) {
 	assert.Equal(t, mockService, service)
 }
 
+func TestContainer_PostgresServiceRepository(t *testing.T) {
+	cfg := config.New(config.WithDatabaseDriver(config.DriverPostgres))
+	container, err := New(cfg, WithPostgresDatabase(&MockPostgresDatabase{}))
+	assert.NoError(t, err)
+
+	repo, err := container.GetServiceRepository()
+	assert.NoError(t, err)
+	assert.IsType(t, &postgres.ServiceRepository{}, repo)
+}
+
+func TestContainer_ShutdownFlushesStatusLogWriter(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewServiceRepository()
+	container, err := New(config.New(config.WithCheckerBatching(100, time.Hour)), WithServiceRepository(repo))
+	require.NoError(t, err)
+
+	writer, err := container.GetStatusLogWriter()
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(ctx, &service.StatusLog{ServiceName: "api", Status: "operational", Timestamp: time.Now()}))
+
+	require.NoError(t, container.Shutdown(ctx))
+
+	history, err := repo.GetStatusHistory(ctx, "api", 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestContainer_GetNotifier(t *testing.T) {
+	container, err := New(config.New())
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	assert.Zero(t, router.Len())
+
+	container, err = New(config.New(config.WithWebhook("https://hooks.example.com/uptime", "secret")), withMemoryRepositories())
+	require.NoError(t, err)
+
+	router, err = container.GetNotifier()
+	require.NoError(t, err)
+	assert.Equal(t, 1, router.Len())
+}
+
+func TestContainer_GetNotifier_ChatChannels(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "slack.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{{define "title"}}{{.ServiceName}}{{end}}`), 0o600))
+
+	cfg := config.New(config.WithChatWebhooks("https://hooks.slack.com/services/x", "https://teams.example.com/x", "https://discord.com/api/webhooks/x"))
+	cfg.Notifier.SlackTemplateFile = templateFile
+	container, err := New(cfg, withMemoryRepositories())
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	assert.Equal(t, 3, router.Len())
+
+	// A broken template file fails fast instead of silently using defaults
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{{define "title"}}{{.Status}`), 0o600))
+	container, err = New(cfg)
+	require.NoError(t, err)
+
+	_, err = container.GetNotifier()
+	assert.Error(t, err)
+}
+
+func TestContainer_GetNotifier_Email(t *testing.T) {
+	cfg := config.New(config.WithSMTP("smtp.example.com", 587, "alerts@example.com", []string{"ops@example.com"}))
+	container, err := New(cfg, withMemoryRepositories())
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	assert.Equal(t, 1, router.Len())
+}
+
+func TestContainer_GetNotifier_Paging(t *testing.T) {
+	cfg := config.New(config.WithPaging("routing-key", "genie-key"))
+	container, err := New(cfg, withMemoryRepositories())
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	assert.Equal(t, 2, router.Len())
+}
+
+func TestContainer_GetPolicyRepository(t *testing.T) {
+	repo := memory.NewPolicyRepository()
+	container, err := New(config.New(), WithPolicyRepository(repo))
+	require.NoError(t, err)
+
+	got, err := container.GetPolicyRepository()
+	require.NoError(t, err)
+	assert.Same(t, repo, got)
+
+	// The postgres driver stores policies in PostgreSQL
+	container, err = New(config.New(config.WithDatabaseDriver(config.DriverPostgres)), WithPostgresDatabase(&MockPostgresDatabase{}))
+	require.NoError(t, err)
+
+	got, err = container.GetPolicyRepository()
+	require.NoError(t, err)
+	assert.IsType(t, &postgres.PolicyRepository{}, got)
+
+	handler, err := container.GetPolicyHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, handler)
+}
+
+func TestContainer_GetAlertRepository(t *testing.T) {
+	repo := memory.NewAlertRepository()
+	container, err := New(config.New(), WithAlertRepository(repo))
+	require.NoError(t, err)
+
+	got, err := container.GetAlertRepository()
+	require.NoError(t, err)
+	assert.Same(t, repo, got)
+
+	// The postgres driver stores alerts in PostgreSQL
+	container, err = New(config.New(config.WithDatabaseDriver(config.DriverPostgres)), WithPostgresDatabase(&MockPostgresDatabase{}))
+	require.NoError(t, err)
+
+	got, err = container.GetAlertRepository()
+	require.NoError(t, err)
+	assert.IsType(t, &postgres.AlertRepository{}, got)
+
+	handler, err := container.GetAlertHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, handler)
+}
+
+func TestContainer_GetOutboxDispatcher(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewOutboxRepository()
+	container, err := New(config.New(config.WithWebhook("https://hooks.example.com/uptime", ""), config.WithOutbox(time.Hour, 3)),
+		withMemoryRepositories(), WithOutboxRepository(repo))
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	assert.Equal(t, 1, router.Len())
+
+	dispatcher, err := container.GetOutboxDispatcher()
+	require.NoError(t, err)
+	again, err := container.GetOutboxDispatcher()
+	require.NoError(t, err)
+	assert.Same(t, dispatcher, again)
+
+	handler, err := container.GetOutboxHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, handler)
+
+	// Shutdown stops the dispatcher so nothing more is queued
+	require.NoError(t, container.Shutdown(ctx))
+	assert.ErrorIs(t, dispatcher.Enqueue(ctx, notifier.Event{}, []string{"webhook"}), notifier.ErrOutboxClosed)
+
+	// The postgres driver stores the outbox in PostgreSQL
+	container, err = New(config.New(config.WithDatabaseDriver(config.DriverPostgres)), WithPostgresDatabase(&MockPostgresDatabase{}))
+	require.NoError(t, err)
+
+	got, err := container.GetOutboxRepository()
+	require.NoError(t, err)
+	assert.IsType(t, &postgres.OutboxRepository{}, got)
+}
+
+func TestContainer_GetNotificationGrouper(t *testing.T) {
+	ctx := context.Background()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	repo := memory.NewOutboxRepository()
+	cfg := config.New(
+		config.WithWebhook(receiver.URL, ""),
+		config.WithOutbox(time.Hour, 3),
+		config.WithGrouping([]string{"group"}, time.Hour, 0),
+	)
+	container, err := New(cfg, withMemoryRepositories(), WithOutboxRepository(repo))
+	require.NoError(t, err)
+
+	router, err := container.GetNotifier()
+	require.NoError(t, err)
+	grouper, err := container.GetNotificationGrouper()
+	require.NoError(t, err)
+
+	for _, slug := range []string{"api", "web"} {
+		event, _ := notifier.NewEvent(slug, slug, service.StatusOperational, service.StatusDown)
+		require.NoError(t, router.Notify(ctx, event))
+	}
+	assert.Equal(t, 1, grouper.Len())
+
+	// Shutdown sends pending groups to the outbox before it stops
+	require.NoError(t, container.Shutdown(ctx))
+	deliveries, err := repo.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Len(t, deliveries[0].Notification.Grouped, 2)
+}
+
+func TestContainer_GetSilenceRepository(t *testing.T) {
+	repo := memory.NewSilenceRepository()
+	container, err := New(config.New(), WithSilenceRepository(repo))
+	require.NoError(t, err)
+
+	got, err := container.GetSilenceRepository()
+	require.NoError(t, err)
+	assert.Same(t, repo, got)
+
+	// The postgres driver stores silences in PostgreSQL
+	container, err = New(config.New(config.WithDatabaseDriver(config.DriverPostgres)), WithPostgresDatabase(&MockPostgresDatabase{}))
+	require.NoError(t, err)
+
+	got, err = container.GetSilenceRepository()
+	require.NoError(t, err)
+	assert.IsType(t, &postgres.SilenceRepository{}, got)
+
+	handler, err := container.GetSilenceHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, handler)
+}
+
+func TestContainer_GetTemplateStore(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewTemplateRepository()
+	require.NoError(t, repo.Save(ctx, &alert.Template{Channel: "slack", Source: `{{define "title"}}stored{{end}}`}))
+
+	container, err := New(config.New(config.WithIncidentURL("https://status.example.com/{service}")), WithTemplateRepository(repo))
+	require.NoError(t, err)
+
+	store, err := container.GetTemplateStore()
+	require.NoError(t, err)
+
+	event := notifier.Event{Type: notifier.EventDown, ServiceName: "API", ServiceSlug: "api", Status: "down", PreviousStatus: "operational"}
+	msg, err := store.Template(ctx, "slack").Render(event)
+	require.NoError(t, err)
+	assert.Equal(t, "stored", msg.Title)
+	assert.Equal(t, "API changed from operational to down.\nhttps://status.example.com/api", msg.Text)
+
+	// The postgres driver stores templates in PostgreSQL
+	container, err = New(config.New(config.WithDatabaseDriver(config.DriverPostgres)), WithPostgresDatabase(&MockPostgresDatabase{}))
+	require.NoError(t, err)
+
+	got, err := container.GetTemplateRepository()
+	require.NoError(t, err)
+	assert.IsType(t, &postgres.TemplateRepository{}, got)
+
+	handler, err := container.GetTemplateHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, handler)
+}
+
+func TestContainer_Metrics(t *testing.T) {
+	container, err := New(config.New(), withMemoryRepositories())
+	require.NoError(t, err)
+	assert.Same(t, container.GetMetrics(), container.GetMetrics())
+
+	srv, err := container.GetHTTPServer()
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.GetHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("X-Request-ID"))
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"), "the configured middleware is applied")
+
+	rec = httptest.NewRecorder()
+	srv.GetHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `uptime_http_request_duration_seconds_count{code="200",method="GET",route="/api/v1/health"} 1`)
+
+	// The checker serves the same registry on its own address
+	metricsServer := container.GetMetricsServer()
+	require.NotNil(t, metricsServer)
+	assert.Equal(t, ":9091", metricsServer.Addr)
+
+	rec = httptest.NewRecorder()
+	metricsServer.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `route="/api/v1/health"`)
+
+	container, err = New(config.New(config.WithCheckerMetricsAddr("")))
+	require.NoError(t, err)
+	assert.Nil(t, container.GetMetricsServer())
+}
+
+func TestContainer_ReloadMiddleware(t *testing.T) {
+	reloaded := config.New()
+	container, err := New(config.New(), withMemoryRepositories(), WithConfigLoader(func() (*config.Config, error) {
+		return reloaded, reloaded.Validate()
+	}))
+	require.NoError(t, err)
+
+	srv, err := container.GetHTTPServer()
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv.GetHandler())
+	defer ts.Close()
+
+	get := func(path string) int {
+		resp, err := http.Get(ts.URL + path)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		return resp.StatusCode
+	}
+	require.Equal(t, http.StatusOK, get("/api/v1/health"))
+
+	// The served handler picks up the reloaded lists
+	reloaded.Middleware.IPDenylist = []string{"127.0.0.1", "::1"}
+	reloaded.Middleware.IPFilterPaths = []string{"/metrics"}
+	require.NoError(t, container.ReloadMiddleware(context.Background()))
+	assert.Equal(t, http.StatusForbidden, get("/metrics"))
+	assert.Equal(t, http.StatusOK, get("/api/v1/health"))
+
+	// Invalid lists keep the current ones
+	reloaded.Middleware.IPDenylist = []string{"nope"}
+	assert.Error(t, container.ReloadMiddleware(context.Background()))
+	assert.Equal(t, http.StatusForbidden, get("/metrics"))
+}
+
+// withMemoryRepositories registers in-memory service, policy, alert, outbox,
+// silence and template repositories
+func withMemoryRepositories() ContainerOption {
+	return func(c *Container) error {
+		c.Register("service_repository", memory.NewServiceRepository())
+		c.Register("policy_repository", memory.NewPolicyRepository())
+		c.Register("alert_repository", memory.NewAlertRepository())
+		c.Register("outbox_repository", memory.NewOutboxRepository())
+		c.Register("silence_repository", memory.NewSilenceRepository())
+		c.Register("template_repository", memory.NewTemplateRepository())
+		return nil
+	}
+}
+
 func TestContainer_Shutdown(t *testing.T) {
 	cfg := config.New()
 	mockDB := &MockDatabase{}
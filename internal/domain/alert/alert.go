@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"time"
+)
+
+// Alert states
+const (
+	StateTriggered    = "triggered"
+	StateAcknowledged = "acknowledged"
+	StateResolved     = "resolved"
+)
+
+// Alert is raised when a service starts failing and stays open until the
+// service recovers. Acknowledging an open alert stops its escalation.
+type Alert struct {
+	ID             string         `bson:"_id,omitempty" json:"id,omitempty"`
+	ServiceName    string         `bson:"service_name" json:"service_name"`
+	ServiceSlug    string         `bson:"service_slug" json:"service_slug"`
+	Severity       string         `bson:"severity" json:"severity"`
+	State          string         `bson:"state" json:"state"`
+	Error          string         `bson:"error,omitempty" json:"error,omitempty"`
+	Escalations    map[string]int `bson:"escalations,omitempty" json:"escalations,omitempty"`
+	Announced      []string       `bson:"announced,omitempty" json:"announced,omitempty"`
+	TriggeredAt    time.Time      `bson:"triggered_at" json:"triggered_at"`
+	AcknowledgedAt *time.Time     `bson:"acknowledged_at,omitempty" json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string         `bson:"acknowledged_by,omitempty" json:"acknowledged_by,omitempty"`
+	ResolvedAt     *time.Time     `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+}
+
+// NewAlert returns a triggered alert for a failing service
+func NewAlert(serviceName, serviceSlug, severity, message string, at time.Time) *Alert {
+	return &Alert{
+		ServiceName: serviceName,
+		ServiceSlug: serviceSlug,
+		Severity:    severity,
+		State:       StateTriggered,
+		Error:       message,
+		TriggeredAt: at,
+	}
+}
+
+// IsOpen reports whether the alert has not been resolved
+func (a *Alert) IsOpen() bool {
+	return a.State != StateResolved
+}
+
+// IsAcknowledged reports whether someone has taken the alert
+func (a *Alert) IsAcknowledged() bool {
+	return a.State == StateAcknowledged
+}
+
+// Escalated returns how many escalation steps of the policy have been sent
+func (a *Alert) Escalated(policyID string) int {
+	return a.Escalations[policyID]
+}
+
+// WasAnnounced reports whether the failure was sent on the policy's channels
+func (a *Alert) WasAnnounced(policyID string) bool {
+	return contains(a.Announced, policyID)
+}
+
+// Acknowledge marks an open alert as taken by the given person. It is a
+// no-op on an alert that is already acknowledged.
+func (a *Alert) Acknowledge(by string, at time.Time) error {
+	switch a.State {
+	case StateResolved:
+		return ErrAlertResolved
+	case StateAcknowledged:
+		return nil
+	}
+
+	a.State = StateAcknowledged
+	a.AcknowledgedAt = &at
+	a.AcknowledgedBy = by
+	return nil
+}
+
+// Resolve closes the alert
+func (a *Alert) Resolve(at time.Time) {
+	a.State = StateResolved
+	a.ResolvedAt = &at
+}
@@ -0,0 +1,34 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlert_Lifecycle(t *testing.T) {
+	triggered := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	a := NewAlert("API", "api", SeverityCritical, "connection refused", triggered)
+
+	assert.Equal(t, StateTriggered, a.State)
+	assert.True(t, a.IsOpen())
+	assert.False(t, a.IsAcknowledged())
+	assert.Equal(t, 0, a.Escalated("1"))
+
+	acked := triggered.Add(5 * time.Minute)
+	require.NoError(t, a.Acknowledge("alice", acked))
+	assert.True(t, a.IsAcknowledged())
+	assert.Equal(t, "alice", a.AcknowledgedBy)
+	assert.Equal(t, acked, *a.AcknowledgedAt)
+
+	// Acknowledging again keeps the first acknowledgement
+	require.NoError(t, a.Acknowledge("bob", acked.Add(time.Minute)))
+	assert.Equal(t, "alice", a.AcknowledgedBy)
+
+	a.Resolve(acked.Add(time.Hour))
+	assert.False(t, a.IsOpen())
+	assert.Equal(t, StateResolved, a.State)
+	assert.Equal(t, ErrAlertResolved, a.Acknowledge("carol", acked.Add(2*time.Hour)))
+}
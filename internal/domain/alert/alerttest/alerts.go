@@ -0,0 +1,222 @@
+package alerttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// AlertFactory returns an empty alert repository for a single subtest
+type AlertFactory func(t *testing.T) alert.AlertRepository
+
+// RunAlertRepositoryContract runs the shared alert repository contract
+// against the repositories produced by newRepo
+func RunAlertRepositoryContract(t *testing.T, newRepo AlertFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo alert.AlertRepository)
+	}{
+		{name: "create assigns id", run: testCreateAlert},
+		{name: "get open alert of a service", run: testGetOpenAlert},
+		{name: "list newest first by state", run: testListAlerts},
+		{name: "acknowledge", run: testAcknowledgeAlert},
+		{name: "resolve", run: testResolveAlert},
+		{name: "record escalation", run: testSetEscalated},
+		{name: "record announcement", run: testSetAnnounced},
+		{name: "missing alert returns not found", run: testMissingAlert},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewAlert returns a triggered critical alert for the service with the given slug
+func NewAlert(slug string, at time.Time) *alert.Alert {
+	return alert.NewAlert("Service "+slug, slug, alert.SeverityCritical, "connection refused", at)
+}
+
+// unknownAlertID is a well-formed ID, for every backend, that is never issued
+// for an alert, a delivery or a silence
+const unknownAlertID = "000000000000000000000000"
+
+func baseTime() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func testCreateAlert(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	a := NewAlert("api", baseTime())
+
+	require.NoError(t, repo.Create(ctx, a))
+	assert.NotEmpty(t, a.ID)
+
+	got, err := repo.GetByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "api", got.ServiceSlug)
+	assert.Equal(t, "Service api", got.ServiceName)
+	assert.Equal(t, alert.SeverityCritical, got.Severity)
+	assert.Equal(t, alert.StateTriggered, got.State)
+	assert.Equal(t, "connection refused", got.Error)
+	assert.WithinDuration(t, a.TriggeredAt, got.TriggeredAt, time.Millisecond)
+	assert.Nil(t, got.AcknowledgedAt)
+	assert.Nil(t, got.ResolvedAt)
+}
+
+func testGetOpenAlert(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	now := baseTime()
+
+	old := NewAlert("api", now.Add(-time.Hour))
+	require.NoError(t, repo.Create(ctx, old))
+	require.NoError(t, repo.Resolve(ctx, old.ID, now.Add(-30*time.Minute)))
+
+	_, err := repo.GetOpen(ctx, "api")
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+
+	open := NewAlert("api", now)
+	require.NoError(t, repo.Create(ctx, open))
+	require.NoError(t, repo.Create(ctx, NewAlert("web", now)))
+
+	got, err := repo.GetOpen(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, open.ID, got.ID)
+
+	// Acknowledged alerts are still open
+	_, err = repo.Acknowledge(ctx, open.ID, "alice", now)
+	require.NoError(t, err)
+	got, err = repo.GetOpen(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, open.ID, got.ID)
+}
+
+func testListAlerts(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	now := baseTime()
+
+	first := NewAlert("api", now.Add(-2*time.Hour))
+	second := NewAlert("web", now.Add(-time.Hour))
+	third := NewAlert("db", now)
+	for _, a := range []*alert.Alert{first, second, third} {
+		require.NoError(t, repo.Create(ctx, a))
+	}
+	require.NoError(t, repo.Resolve(ctx, second.ID, now))
+
+	all, err := repo.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, third.ID, all[0].ID)
+	assert.Equal(t, first.ID, all[2].ID)
+
+	limited, err := repo.List(ctx, "", 2)
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	triggered, err := repo.List(ctx, alert.StateTriggered, 10)
+	require.NoError(t, err)
+	require.Len(t, triggered, 2)
+	assert.Equal(t, third.ID, triggered[0].ID)
+
+	resolved, err := repo.List(ctx, alert.StateResolved, 10)
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, second.ID, resolved[0].ID)
+}
+
+func testAcknowledgeAlert(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	a := NewAlert("api", now)
+	require.NoError(t, repo.Create(ctx, a))
+
+	acked, err := repo.Acknowledge(ctx, a.ID, "alice", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, alert.StateAcknowledged, acked.State)
+	assert.Equal(t, "alice", acked.AcknowledgedBy)
+	require.NotNil(t, acked.AcknowledgedAt)
+	assert.WithinDuration(t, now.Add(time.Minute), *acked.AcknowledgedAt, time.Millisecond)
+
+	// A second acknowledgement keeps the first
+	again, err := repo.Acknowledge(ctx, a.ID, "bob", now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", again.AcknowledgedBy)
+
+	require.NoError(t, repo.Resolve(ctx, a.ID, now.Add(time.Hour)))
+	_, err = repo.Acknowledge(ctx, a.ID, "carol", now.Add(2*time.Hour))
+	assert.Equal(t, alert.ErrAlertResolved, err)
+}
+
+func testResolveAlert(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	a := NewAlert("api", now)
+	require.NoError(t, repo.Create(ctx, a))
+
+	require.NoError(t, repo.Resolve(ctx, a.ID, now.Add(time.Hour)))
+
+	got, err := repo.GetByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alert.StateResolved, got.State)
+	require.NotNil(t, got.ResolvedAt)
+	assert.WithinDuration(t, now.Add(time.Hour), *got.ResolvedAt, time.Millisecond)
+}
+
+func testSetEscalated(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	a := NewAlert("api", now)
+	require.NoError(t, repo.Create(ctx, a))
+
+	require.NoError(t, repo.SetEscalated(ctx, a.ID, "p1", 1))
+	require.NoError(t, repo.SetEscalated(ctx, a.ID, "p2", 2))
+	_, err := repo.Acknowledge(ctx, a.ID, "alice", now)
+	require.NoError(t, err)
+
+	// Recording an escalation never overwrites the acknowledgement
+	require.NoError(t, repo.SetEscalated(ctx, a.ID, "p1", 2))
+
+	got, err := repo.GetByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Escalated("p1"))
+	assert.Equal(t, 2, got.Escalated("p2"))
+	assert.Equal(t, alert.StateAcknowledged, got.State)
+}
+
+func testSetAnnounced(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	a := NewAlert("api", baseTime())
+	require.NoError(t, repo.Create(ctx, a))
+
+	require.NoError(t, repo.SetAnnounced(ctx, a.ID, "p1"))
+	require.NoError(t, repo.SetAnnounced(ctx, a.ID, "p1"))
+	require.NoError(t, repo.SetAnnounced(ctx, a.ID, "p2"))
+
+	got, err := repo.GetByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, got.Announced)
+	assert.True(t, got.WasAnnounced("p1"))
+	assert.False(t, got.WasAnnounced("p3"))
+}
+
+func testMissingAlert(t *testing.T, repo alert.AlertRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, NewAlert("api", baseTime())))
+
+	_, err := repo.GetByID(ctx, unknownAlertID)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+	_, err = repo.Acknowledge(ctx, unknownAlertID, "alice", baseTime())
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+	assert.True(t, errors.IsNotFound(repo.Resolve(ctx, unknownAlertID, baseTime())))
+	assert.True(t, errors.IsNotFound(repo.SetEscalated(ctx, unknownAlertID, "p1", 1)))
+	assert.True(t, errors.IsNotFound(repo.SetAnnounced(ctx, unknownAlertID, "p1")))
+}
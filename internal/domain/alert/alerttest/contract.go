@@ -0,0 +1,158 @@
+// Package alerttest provides behavioural test suites that every
+// alert.PolicyRepository, alert.AlertRepository, alert.OutboxRepository,
+// alert.SilenceRepository and alert.TemplateRepository implementation must
+// pass.
+package alerttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// PolicyFactory returns an empty policy repository for a single subtest
+type PolicyFactory func(t *testing.T) alert.PolicyRepository
+
+// RunPolicyRepositoryContract runs the shared policy repository contract
+// against the repositories produced by newRepo
+func RunPolicyRepositoryContract(t *testing.T, newRepo PolicyFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo alert.PolicyRepository)
+	}{
+		{name: "create assigns id and timestamps", run: testCreatePolicy},
+		{name: "create rejects invalid policy", run: testCreateRejectsInvalidPolicy},
+		{name: "get all in creation order", run: testGetAllPolicies},
+		{name: "update replaces the policy", run: testUpdatePolicy},
+		{name: "missing policy returns not found", run: testMissingPolicy},
+		{name: "delete", run: testDeletePolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewPolicy returns a valid, enabled policy routing to slack
+func NewPolicy(name string) *alert.Policy {
+	return &alert.Policy{
+		Name:           name,
+		Enabled:        true,
+		Match:          alert.Matcher{Groups: []string{"platform"}, Tags: []string{"core"}},
+		Severities:     []string{alert.SeverityCritical},
+		Channels:       []string{"slack"},
+		RepeatInterval: alert.Duration(30 * time.Minute),
+		QuietHours:     &alert.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Berlin"},
+		Escalation: []alert.Step{
+			{After: alert.Duration(15 * time.Minute), Channels: []string{"pagerduty"}},
+		},
+	}
+}
+
+func testCreatePolicy(t *testing.T, repo alert.PolicyRepository) {
+	ctx := context.Background()
+	policy := NewPolicy("platform")
+
+	require.NoError(t, repo.Create(ctx, policy))
+	assert.NotEmpty(t, policy.ID)
+	assert.False(t, policy.CreatedAt.IsZero())
+	assert.False(t, policy.UpdatedAt.IsZero())
+
+	got, err := repo.GetByID(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, policy.Name, got.Name)
+	assert.Equal(t, policy.Match, got.Match)
+	assert.Equal(t, policy.Severities, got.Severities)
+	assert.Equal(t, policy.Channels, got.Channels)
+	assert.Equal(t, policy.RepeatInterval, got.RepeatInterval)
+	assert.Equal(t, policy.QuietHours, got.QuietHours)
+	assert.Equal(t, policy.Escalation, got.Escalation)
+	assert.True(t, got.Enabled)
+}
+
+func testCreateRejectsInvalidPolicy(t *testing.T, repo alert.PolicyRepository) {
+	policy := NewPolicy("platform")
+	policy.Channels = nil
+
+	err := repo.Create(context.Background(), policy)
+	assert.True(t, errors.IsValidation(err), "expected validation error, got %v", err)
+}
+
+func testGetAllPolicies(t *testing.T, repo alert.PolicyRepository) {
+	ctx := context.Background()
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.Create(ctx, NewPolicy(name)))
+	}
+
+	policies, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 3)
+	assert.Equal(t, "first", policies[0].Name)
+	assert.Equal(t, "third", policies[2].Name)
+
+	// Returned policies are copies
+	policies[0].Channels[0] = "mutated"
+	again, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slack"}, again[0].Channels)
+}
+
+func testUpdatePolicy(t *testing.T, repo alert.PolicyRepository) {
+	ctx := context.Background()
+	policy := NewPolicy("platform")
+	require.NoError(t, repo.Create(ctx, policy))
+
+	policy.Channels = []string{"pagerduty", "email"}
+	policy.QuietHours = nil
+	policy.Escalation = nil
+	policy.Enabled = false
+	require.NoError(t, repo.Update(ctx, policy))
+
+	got, err := repo.GetByID(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pagerduty", "email"}, got.Channels)
+	assert.Nil(t, got.QuietHours)
+	assert.Empty(t, got.Escalation)
+	assert.False(t, got.Enabled)
+	assert.WithinDuration(t, policy.CreatedAt, got.CreatedAt, time.Millisecond)
+
+	policy.Name = ""
+	assert.True(t, errors.IsValidation(repo.Update(ctx, policy)))
+}
+
+func testMissingPolicy(t *testing.T, repo alert.PolicyRepository) {
+	ctx := context.Background()
+	policy := NewPolicy("gone")
+	require.NoError(t, repo.Create(ctx, policy))
+	require.NoError(t, repo.Delete(ctx, policy.ID))
+
+	_, err := repo.GetByID(ctx, policy.ID)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+	assert.True(t, errors.IsNotFound(repo.Update(ctx, policy)))
+	assert.True(t, errors.IsNotFound(repo.Delete(ctx, policy.ID)))
+}
+
+func testDeletePolicy(t *testing.T, repo alert.PolicyRepository) {
+	ctx := context.Background()
+	keep := NewPolicy("keep")
+	drop := NewPolicy("drop")
+	require.NoError(t, repo.Create(ctx, keep))
+	require.NoError(t, repo.Create(ctx, drop))
+
+	require.NoError(t, repo.Delete(ctx, drop.ID))
+
+	policies, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, keep.ID, policies[0].ID)
+}
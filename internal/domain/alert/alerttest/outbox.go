@@ -0,0 +1,276 @@
+package alerttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// OutboxFactory returns an empty outbox repository for a single subtest
+type OutboxFactory func(t *testing.T) alert.OutboxRepository
+
+// RunOutboxRepositoryContract runs the shared outbox repository contract
+// against the repositories produced by newRepo
+func RunOutboxRepositoryContract(t *testing.T, newRepo OutboxFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo alert.OutboxRepository)
+	}{
+		{name: "enqueue and claim", run: testEnqueueAndClaim},
+		{name: "claim respects the limit", run: testClaimLimit},
+		{name: "expired lease is claimed again", run: testClaimLease},
+		{name: "complete", run: testCompleteDelivery},
+		{name: "fail schedules a retry", run: testFailDelivery},
+		{name: "bury and retry", run: testBuryAndRetry},
+		{name: "list and count", run: testListAndCountDeliveries},
+		{name: "missing delivery returns not found", run: testMissingDelivery},
+		{name: "purge delivered", run: testPurgeDelivered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewDelivery returns a pending delivery of a down notification to channel
+func NewDelivery(channel string, at time.Time) *alert.Delivery {
+	return alert.NewDelivery(channel, alert.Notification{
+		EventID:        "evt-" + channel,
+		Type:           "service.down",
+		ServiceName:    "API",
+		ServiceSlug:    "api",
+		Status:         "down",
+		PreviousStatus: "operational",
+		Latency:        1200,
+		StatusCode:     503,
+		Error:          "service unavailable",
+		Timestamp:      at,
+		AlertID:        "alert-1",
+	}, at)
+}
+
+const testLease = time.Minute
+
+func enqueue(t *testing.T, repo alert.OutboxRepository, deliveries ...*alert.Delivery) {
+	t.Helper()
+	require.NoError(t, repo.Enqueue(context.Background(), deliveries))
+	for _, d := range deliveries {
+		require.NotEmpty(t, d.ID)
+	}
+}
+
+func testEnqueueAndClaim(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+
+	first := NewDelivery("slack", now.Add(-2*time.Second))
+	second := NewDelivery("pagerduty", now.Add(-time.Second))
+	later := NewDelivery("email", now.Add(time.Hour))
+	enqueue(t, repo, first, second, later)
+
+	claimed, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, first.ID, claimed[0].ID)
+	assert.Equal(t, second.ID, claimed[1].ID)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.Equal(t, alert.DeliveryPending, claimed[0].State)
+	assert.Equal(t, "slack", claimed[0].Channel)
+
+	n := claimed[0].Notification
+	assert.Equal(t, "evt-slack", n.EventID)
+	assert.Equal(t, "service.down", n.Type)
+	assert.Equal(t, "api", n.ServiceSlug)
+	assert.Equal(t, int64(1200), n.Latency)
+	assert.Equal(t, 503, n.StatusCode)
+	assert.Equal(t, "alert-1", n.AlertID)
+	assert.WithinDuration(t, first.Notification.Timestamp, n.Timestamp, time.Millisecond)
+
+	// Leased deliveries are not handed out twice
+	again, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+}
+
+func testClaimLimit(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	enqueue(t, repo, NewDelivery("a", now), NewDelivery("b", now), NewDelivery("c", now))
+
+	claimed, err := repo.Claim(ctx, now, testLease, 2)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 2)
+
+	claimed, err = repo.Claim(ctx, now, testLease, 2)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 1)
+}
+
+func testClaimLease(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	d := NewDelivery("slack", now)
+	enqueue(t, repo, d)
+
+	_, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+
+	claimed, err := repo.Claim(ctx, now.Add(testLease), testLease, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, d.ID, claimed[0].ID)
+	assert.Equal(t, 2, claimed[0].Attempts)
+}
+
+func testCompleteDelivery(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	d := NewDelivery("slack", now)
+	enqueue(t, repo, d)
+
+	_, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(ctx, d.ID, now.Add(time.Second)))
+
+	claimed, err := repo.Claim(ctx, now.Add(time.Hour), testLease, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	delivered, err := repo.List(ctx, alert.DeliveryDelivered, 10)
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	require.NotNil(t, delivered[0].DeliveredAt)
+	assert.WithinDuration(t, now.Add(time.Second), *delivered[0].DeliveredAt, time.Millisecond)
+}
+
+func testPurgeDelivered(t *testing.T, repo alert.OutboxRepository) {
+	purger, ok := repo.(alert.OutboxPurger)
+	if !ok {
+		t.Skip("repository expires delivered deliveries by itself")
+	}
+
+	ctx := context.Background()
+	now := baseTime()
+	old := NewDelivery("slack", now)
+	recent := NewDelivery("pagerduty", now)
+	pending := NewDelivery("email", now)
+	enqueue(t, repo, old, recent, pending)
+
+	_, err := repo.Claim(ctx, now, testLease, 2)
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(ctx, old.ID, now))
+	require.NoError(t, repo.Complete(ctx, recent.ID, now.Add(time.Hour)))
+
+	purged, err := purger.PurgeDelivered(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	delivered, err := repo.List(ctx, alert.DeliveryDelivered, 10)
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, recent.ID, delivered[0].ID)
+
+	counts, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts[alert.DeliveryPending])
+}
+
+func testFailDelivery(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	d := NewDelivery("slack", now)
+	enqueue(t, repo, d)
+
+	_, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+	require.NoError(t, repo.Fail(ctx, d.ID, "HTTP 502", now.Add(10*time.Second)))
+
+	claimed, err := repo.Claim(ctx, now.Add(5*time.Second), testLease, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	claimed, err = repo.Claim(ctx, now.Add(10*time.Second), testLease, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "HTTP 502", claimed[0].LastError)
+	assert.Equal(t, 2, claimed[0].Attempts)
+}
+
+func testBuryAndRetry(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	d := NewDelivery("slack", now)
+	enqueue(t, repo, d)
+
+	_, err := repo.Claim(ctx, now, testLease, 10)
+	require.NoError(t, err)
+	require.NoError(t, repo.Bury(ctx, d.ID, "HTTP 410"))
+
+	claimed, err := repo.Claim(ctx, now.Add(time.Hour), testLease, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	dead, err := repo.List(ctx, alert.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "HTTP 410", dead[0].LastError)
+
+	retried, err := repo.Retry(ctx, d.ID, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, alert.DeliveryPending, retried.State)
+	assert.Equal(t, 0, retried.Attempts)
+
+	_, err = repo.Retry(ctx, d.ID, now.Add(time.Minute))
+	assert.Equal(t, alert.ErrDeliveryNotDead, err)
+
+	claimed, err = repo.Claim(ctx, now.Add(time.Minute), testLease, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, 1, claimed[0].Attempts)
+}
+
+func testListAndCountDeliveries(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	first := NewDelivery("slack", now.Add(-time.Minute))
+	second := NewDelivery("email", now)
+	enqueue(t, repo, first, second)
+	require.NoError(t, repo.Bury(ctx, first.ID, "gone"))
+
+	all, err := repo.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, second.ID, all[0].ID)
+
+	limited, err := repo.List(ctx, "", 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	counts, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts[alert.DeliveryPending])
+	assert.Equal(t, int64(1), counts[alert.DeliveryDead])
+	assert.Zero(t, counts[alert.DeliveryDelivered])
+}
+
+func testMissingDelivery(t *testing.T, repo alert.OutboxRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	enqueue(t, repo, NewDelivery("slack", now))
+
+	assert.True(t, errors.IsNotFound(repo.Complete(ctx, unknownAlertID, now)))
+	assert.True(t, errors.IsNotFound(repo.Fail(ctx, unknownAlertID, "x", now)))
+	assert.True(t, errors.IsNotFound(repo.Bury(ctx, unknownAlertID, "x")))
+	_, err := repo.Retry(ctx, unknownAlertID, now)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+}
@@ -0,0 +1,158 @@
+package alerttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// SilenceFactory returns an empty silence repository for a single subtest
+type SilenceFactory func(t *testing.T) alert.SilenceRepository
+
+// RunSilenceRepositoryContract runs the shared silence repository contract
+// against the repositories produced by newRepo
+func RunSilenceRepositoryContract(t *testing.T, newRepo SilenceFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo alert.SilenceRepository)
+	}{
+		{name: "create assigns id and timestamp", run: testCreateSilence},
+		{name: "create rejects invalid silence", run: testCreateRejectsInvalidSilence},
+		{name: "get all in creation order", run: testGetAllSilences},
+		{name: "get active at a time", run: testGetActiveSilences},
+		{name: "expire", run: testExpireSilence},
+		{name: "missing silence returns not found", run: testMissingSilence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewSilence returns a valid silence of the service with the given slug
+// that starts at start and lasts an hour
+func NewSilence(slug string, start time.Time) *alert.Silence {
+	return &alert.Silence{
+		Match:     alert.Matcher{Services: []string{slug}},
+		Channels:  []string{"pagerduty"},
+		StartsAt:  start,
+		EndsAt:    start.Add(time.Hour),
+		CreatedBy: "alice",
+		Comment:   "planned migration",
+	}
+}
+
+func testCreateSilence(t *testing.T, repo alert.SilenceRepository) {
+	ctx := context.Background()
+	s := NewSilence("api", baseTime())
+
+	require.NoError(t, repo.Create(ctx, s))
+	assert.NotEmpty(t, s.ID)
+	assert.False(t, s.CreatedAt.IsZero())
+
+	got, err := repo.GetByID(ctx, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, s.Match, got.Match)
+	assert.Equal(t, s.Channels, got.Channels)
+	assert.Equal(t, "alice", got.CreatedBy)
+	assert.Equal(t, "planned migration", got.Comment)
+	assert.WithinDuration(t, s.StartsAt, got.StartsAt, time.Millisecond)
+	assert.WithinDuration(t, s.EndsAt, got.EndsAt, time.Millisecond)
+}
+
+func testCreateRejectsInvalidSilence(t *testing.T, repo alert.SilenceRepository) {
+	s := NewSilence("api", baseTime())
+	s.EndsAt = s.StartsAt.Add(-time.Minute)
+
+	err := repo.Create(context.Background(), s)
+	assert.True(t, errors.IsValidation(err), "expected validation error, got %v", err)
+}
+
+func testGetAllSilences(t *testing.T, repo alert.SilenceRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	for _, slug := range []string{"first", "second", "third"} {
+		require.NoError(t, repo.Create(ctx, NewSilence(slug, now)))
+	}
+
+	silences, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, silences, 3)
+	assert.Equal(t, []string{"first"}, silences[0].Match.Services)
+	assert.Equal(t, []string{"third"}, silences[2].Match.Services)
+
+	// Returned silences are copies
+	silences[0].Channels[0] = "mutated"
+	again, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pagerduty"}, again[0].Channels)
+}
+
+func testGetActiveSilences(t *testing.T, repo alert.SilenceRepository) {
+	ctx := context.Background()
+	now := baseTime()
+
+	active := NewSilence("api", now.Add(-30*time.Minute))
+	pending := NewSilence("web", now.Add(time.Hour))
+	expired := NewSilence("db", now.Add(-2*time.Hour))
+	for _, s := range []*alert.Silence{active, pending, expired} {
+		require.NoError(t, repo.Create(ctx, s))
+	}
+
+	got, err := repo.GetActive(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, active.ID, got[0].ID)
+
+	// The end of the window is exclusive
+	got, err = repo.GetActive(ctx, active.EndsAt)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = repo.GetActive(ctx, pending.StartsAt)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, pending.ID, got[0].ID)
+}
+
+func testExpireSilence(t *testing.T, repo alert.SilenceRepository) {
+	ctx := context.Background()
+	now := baseTime()
+	s := NewSilence("api", now.Add(-time.Minute))
+	require.NoError(t, repo.Create(ctx, s))
+
+	expired, err := repo.Expire(ctx, s.ID, now)
+	require.NoError(t, err)
+	assert.WithinDuration(t, now, expired.EndsAt, time.Millisecond)
+
+	active, err := repo.GetActive(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	got, err := repo.GetByID(ctx, s.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alert.SilenceExpired, got.State(now))
+
+	_, err = repo.Expire(ctx, s.ID, now.Add(time.Minute))
+	assert.Equal(t, alert.ErrSilenceExpired, err)
+}
+
+func testMissingSilence(t *testing.T, repo alert.SilenceRepository) {
+	ctx := context.Background()
+
+	_, err := repo.GetByID(ctx, unknownAlertID)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+
+	_, err = repo.Expire(ctx, unknownAlertID, baseTime())
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+}
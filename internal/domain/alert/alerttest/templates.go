@@ -0,0 +1,110 @@
+package alerttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// TemplateFactory returns an empty template repository for a single subtest
+type TemplateFactory func(t *testing.T) alert.TemplateRepository
+
+// RunTemplateRepositoryContract runs the shared template repository
+// contract against the repositories produced by newRepo
+func RunTemplateRepositoryContract(t *testing.T, newRepo TemplateFactory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo alert.TemplateRepository)
+	}{
+		{name: "save stamps and stores", run: testSaveTemplate},
+		{name: "save rejects invalid template", run: testSaveRejectsInvalidTemplate},
+		{name: "save replaces the channel template", run: testReplaceTemplate},
+		{name: "get all ordered by channel", run: testGetAllTemplates},
+		{name: "missing template returns not found", run: testMissingTemplate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewTemplate returns a valid template for channel that redefines the title
+func NewTemplate(channel string) *alert.Template {
+	return &alert.Template{
+		Channel:   channel,
+		Source:    `{{define "title"}}[{{upper .Status}}] {{.ServiceName}}{{end}}`,
+		UpdatedBy: "alice",
+	}
+}
+
+func testSaveTemplate(t *testing.T, repo alert.TemplateRepository) {
+	ctx := context.Background()
+	tmpl := NewTemplate("slack")
+
+	require.NoError(t, repo.Save(ctx, tmpl))
+	assert.False(t, tmpl.UpdatedAt.IsZero())
+
+	got, err := repo.Get(ctx, "slack")
+	require.NoError(t, err)
+	assert.Equal(t, "slack", got.Channel)
+	assert.Equal(t, tmpl.Source, got.Source)
+	assert.Equal(t, "alice", got.UpdatedBy)
+}
+
+func testSaveRejectsInvalidTemplate(t *testing.T, repo alert.TemplateRepository) {
+	tmpl := NewTemplate("slack")
+	tmpl.Source = "  "
+
+	err := repo.Save(context.Background(), tmpl)
+	assert.True(t, errors.IsValidation(err), "expected validation error, got %v", err)
+}
+
+func testReplaceTemplate(t *testing.T, repo alert.TemplateRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, NewTemplate("slack")))
+
+	replacement := &alert.Template{Channel: "slack", Source: `{{define "text"}}{{.Error}}{{end}}`}
+	require.NoError(t, repo.Save(ctx, replacement))
+
+	got, err := repo.Get(ctx, "slack")
+	require.NoError(t, err)
+	assert.Equal(t, replacement.Source, got.Source)
+	assert.Empty(t, got.UpdatedBy)
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func testGetAllTemplates(t *testing.T, repo alert.TemplateRepository) {
+	ctx := context.Background()
+	for _, channel := range []string{"telegram", "discord", "slack"} {
+		require.NoError(t, repo.Save(ctx, NewTemplate(channel)))
+	}
+
+	templates, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+	assert.Equal(t, "discord", templates[0].Channel)
+	assert.Equal(t, "slack", templates[1].Channel)
+	assert.Equal(t, "telegram", templates[2].Channel)
+}
+
+func testMissingTemplate(t *testing.T, repo alert.TemplateRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, NewTemplate("slack")))
+	require.NoError(t, repo.Delete(ctx, "slack"))
+
+	_, err := repo.Get(ctx, "slack")
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+	assert.True(t, errors.IsNotFound(repo.Delete(ctx, "slack")))
+}
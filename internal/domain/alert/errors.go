@@ -0,0 +1,29 @@
+package alert
+
+import (
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// Alerting-specific errors
+var (
+	ErrPolicyNameRequired      = errors.NewValidationError("policy name is required")
+	ErrPolicyChannelsRequired  = errors.NewValidationError("policy must route to at least one channel")
+	ErrInvalidSeverity         = errors.NewValidationError("severity must be one of: critical, warning")
+	ErrInvalidRepeatInterval   = errors.NewValidationError("repeat interval cannot be negative")
+	ErrInvalidQuietHours       = errors.NewValidationError("quiet hours need distinct HH:MM start and end times and a valid timezone")
+	ErrInvalidEscalation       = errors.NewValidationError("escalation steps need channels and strictly increasing positive delays")
+	ErrPolicyNotFound          = errors.NewNotFoundError("policy not found")
+	ErrAlertNotFound           = errors.NewNotFoundError("alert not found")
+	ErrAlertResolved           = errors.NewConflictError("alert is already resolved")
+	ErrDeliveryNotFound        = errors.NewNotFoundError("delivery not found")
+	ErrDeliveryNotDead         = errors.NewConflictError("only dead-lettered deliveries can be retried")
+	ErrSilenceCreatorRequired  = errors.NewValidationError("silence creator is required")
+	ErrSilenceCommentRequired  = errors.NewValidationError("silence comment is required")
+	ErrSilenceMatcherRequired  = errors.NewValidationError("silence must match at least one service, group, tag or channel")
+	ErrInvalidSilenceWindow    = errors.NewValidationError("silence needs a start time and an end time after it")
+	ErrSilenceNotFound         = errors.NewNotFoundError("silence not found")
+	ErrSilenceExpired          = errors.NewConflictError("silence has already expired")
+	ErrTemplateChannelRequired = errors.NewValidationError("template channel is required and cannot contain slashes or spaces")
+	ErrTemplateSourceRequired  = errors.NewValidationError("template source is required")
+	ErrTemplateNotFound        = errors.NewNotFoundError("template not found")
+)
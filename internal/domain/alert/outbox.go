@@ -0,0 +1,61 @@
+package alert
+
+import (
+	"time"
+)
+
+// Delivery states
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Notification is the channel-independent content of a queued notification
+type Notification struct {
+	EventID         string    `bson:"event_id" json:"event_id"`
+	Type            string    `bson:"type" json:"type"`
+	ServiceName     string    `bson:"service_name" json:"service_name"`
+	ServiceSlug     string    `bson:"service_slug,omitempty" json:"service_slug,omitempty"`
+	Status          string    `bson:"status" json:"status"`
+	PreviousStatus  string    `bson:"previous_status" json:"previous_status"`
+	Latency         int64     `bson:"latency_ms" json:"latency_ms"`
+	StatusCode      int       `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	Error           string    `bson:"error,omitempty" json:"error,omitempty"`
+	Timestamp       time.Time `bson:"timestamp" json:"timestamp"`
+	Repeat          bool      `bson:"repeat,omitempty" json:"repeat,omitempty"`
+	FailingSince    time.Time `bson:"failing_since,omitempty" json:"failing_since,omitempty"`
+	AlertID         string    `bson:"alert_id,omitempty" json:"alert_id,omitempty"`
+	EscalationLevel int       `bson:"escalation_level,omitempty" json:"escalation_level,omitempty"`
+
+	// Digests carry the notifications of the services they group
+	GroupKey    string         `bson:"group_key,omitempty" json:"group_key,omitempty"`
+	Grouped     []Notification `bson:"grouped,omitempty" json:"grouped,omitempty"`
+	GroupUpdate bool           `bson:"group_update,omitempty" json:"group_update,omitempty"`
+}
+
+// Delivery is a notification queued in the outbox for one channel. It stays
+// pending until the channel accepts it, and is dead-lettered once it runs
+// out of attempts.
+type Delivery struct {
+	ID            string       `bson:"_id,omitempty" json:"id,omitempty"`
+	Channel       string       `bson:"channel" json:"channel"`
+	Notification  Notification `bson:"notification" json:"notification"`
+	State         string       `bson:"state" json:"state"`
+	Attempts      int          `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time    `bson:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string       `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt     time.Time    `bson:"created_at" json:"created_at"`
+	DeliveredAt   *time.Time   `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
+
+// NewDelivery returns a pending delivery of n to channel, due immediately
+func NewDelivery(channel string, n Notification, at time.Time) *Delivery {
+	return &Delivery{
+		Channel:       channel,
+		Notification:  n,
+		State:         DeliveryPending,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+	}
+}
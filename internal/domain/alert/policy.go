@@ -0,0 +1,233 @@
+// Package alert holds the alerting domain: notification policies that
+// decide which channels hear about which services, and the alerts raised
+// while a service is failing.
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+// Severity levels used to route notifications
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// SeverityForStatus returns the severity of a failing service status, or an
+// empty string for statuses that do not alert
+func SeverityForStatus(status string) string {
+	switch status {
+	case service.StatusDown:
+		return SeverityCritical
+	case service.StatusDegraded:
+		return SeverityWarning
+	default:
+		return ""
+	}
+}
+
+// StatusForSeverity returns the failing service status of a severity, or an
+// empty string for unknown severities
+func StatusForSeverity(severity string) string {
+	switch severity {
+	case SeverityCritical:
+		return service.StatusDown
+	case SeverityWarning:
+		return service.StatusDegraded
+	default:
+		return ""
+	}
+}
+
+// Policy routes notifications for matching services and severities to a set
+// of notification channels
+type Policy struct {
+	ID             string      `bson:"_id,omitempty" json:"id,omitempty"`
+	Name           string      `bson:"name" json:"name"`
+	Enabled        bool        `bson:"enabled" json:"enabled"`
+	Match          Matcher     `bson:"match" json:"match"`
+	Severities     []string    `bson:"severities,omitempty" json:"severities,omitempty"`
+	Channels       []string    `bson:"channels" json:"channels"`
+	RepeatInterval Duration    `bson:"repeat_interval" json:"repeat_interval,omitempty"`
+	QuietHours     *QuietHours `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+	Escalation     []Step      `bson:"escalation,omitempty" json:"escalation,omitempty"`
+	CreatedAt      time.Time   `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// Step is an escalation level: when an alert is still unacknowledged After
+// it was triggered, its channels are notified as well. The policy's own
+// channels are the first level.
+type Step struct {
+	After    Duration `bson:"after" json:"after"`
+	Channels []string `bson:"channels" json:"channels"`
+}
+
+// Matcher selects services by slug, group or tag. Every non-empty list must
+// contain a value of the service; an empty matcher selects every service.
+type Matcher struct {
+	Services []string `bson:"services,omitempty" json:"services,omitempty"`
+	Groups   []string `bson:"groups,omitempty" json:"groups,omitempty"`
+	Tags     []string `bson:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// QuietHours is a daily window, in the given timezone, during which a policy
+// sends nothing. A window whose end is before its start spans midnight.
+type QuietHours struct {
+	Start    string `bson:"start" json:"start"`
+	End      string `bson:"end" json:"end"`
+	Timezone string `bson:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// Duration is a time.Duration that reads and writes JSON as a string such as "30m"
+type Duration time.Duration
+
+// Validate validates the policy
+func (p *Policy) Validate() error {
+	if p.Name == "" {
+		return ErrPolicyNameRequired
+	}
+	if len(p.Channels) == 0 {
+		return ErrPolicyChannelsRequired
+	}
+	for _, severity := range p.Severities {
+		if severity != SeverityCritical && severity != SeverityWarning {
+			return ErrInvalidSeverity
+		}
+	}
+	if p.RepeatInterval < 0 {
+		return ErrInvalidRepeatInterval
+	}
+	if p.QuietHours != nil {
+		if _, _, _, err := p.QuietHours.parse(); err != nil {
+			return ErrInvalidQuietHours
+		}
+	}
+	var previous Duration
+	for _, step := range p.Escalation {
+		if step.After <= previous || len(step.Channels) == 0 {
+			return ErrInvalidEscalation
+		}
+		previous = step.After
+	}
+	return nil
+}
+
+// DueSteps returns how many escalation steps are due for an alert that has
+// been unacknowledged for elapsed
+func (p *Policy) DueSteps(elapsed time.Duration) int {
+	due := 0
+	for _, step := range p.Escalation {
+		if elapsed < time.Duration(step.After) {
+			break
+		}
+		due++
+	}
+	return due
+}
+
+// Matches reports whether the policy applies to svc at the given severity
+func (p *Policy) Matches(svc *service.Service, severity string) bool {
+	if !p.Enabled {
+		return false
+	}
+	if len(p.Severities) > 0 && !contains(p.Severities, severity) {
+		return false
+	}
+	return p.Match.Matches(svc)
+}
+
+// Matches reports whether svc is selected by the matcher
+func (m Matcher) Matches(svc *service.Service) bool {
+	if len(m.Services) > 0 && !contains(m.Services, svc.Slug) {
+		return false
+	}
+	if len(m.Groups) > 0 && !contains(m.Groups, svc.Group) {
+		return false
+	}
+	if len(m.Tags) > 0 {
+		for _, tag := range svc.Tags {
+			if contains(m.Tags, tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Active reports whether t falls within the quiet hours
+func (q *QuietHours) Active(t time.Time) bool {
+	start, end, loc, err := q.parse()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// parse returns the window bounds as minutes after midnight and its location
+func (q *QuietHours) parse() (int, int, *time.Location, error) {
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if start.Equal(end) {
+		return 0, 0, nil, fmt.Errorf("quiet hours start and end are equal")
+	}
+
+	loc := time.UTC
+	if q.Timezone != "" {
+		if loc, err = time.LoadLocation(q.Timezone); err != nil {
+			return 0, 0, nil, err
+		}
+	}
+
+	return start.Hour()*60 + start.Minute(), end.Hour()*60 + end.Minute(), loc, nil
+}
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string such as "1h30m"
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30m\": %w", err)
+	}
+	if raw == "" {
+		*d = 0
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
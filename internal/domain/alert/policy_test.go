@@ -0,0 +1,133 @@
+package alert
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	valid := func() *Policy {
+		return &Policy{Name: "platform", Channels: []string{"slack"}}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *Policy)
+		wantErr error
+	}{
+		{name: "valid", mutate: func(p *Policy) {}},
+		{name: "missing name", mutate: func(p *Policy) { p.Name = "" }, wantErr: ErrPolicyNameRequired},
+		{name: "missing channels", mutate: func(p *Policy) { p.Channels = nil }, wantErr: ErrPolicyChannelsRequired},
+		{name: "unknown severity", mutate: func(p *Policy) { p.Severities = []string{"info"} }, wantErr: ErrInvalidSeverity},
+		{name: "negative repeat", mutate: func(p *Policy) { p.RepeatInterval = -1 }, wantErr: ErrInvalidRepeatInterval},
+		{name: "bad quiet hours", mutate: func(p *Policy) { p.QuietHours = &QuietHours{Start: "25:00", End: "07:00"} }, wantErr: ErrInvalidQuietHours},
+		{name: "empty quiet window", mutate: func(p *Policy) { p.QuietHours = &QuietHours{Start: "07:00", End: "07:00"} }, wantErr: ErrInvalidQuietHours},
+		{name: "bad timezone", mutate: func(p *Policy) {
+			p.QuietHours = &QuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}
+		}, wantErr: ErrInvalidQuietHours},
+		{name: "escalation", mutate: func(p *Policy) {
+			p.Escalation = []Step{{After: Duration(15 * time.Minute), Channels: []string{"pagerduty"}}}
+		}},
+		{name: "escalation without channels", mutate: func(p *Policy) {
+			p.Escalation = []Step{{After: Duration(15 * time.Minute)}}
+		}, wantErr: ErrInvalidEscalation},
+		{name: "escalation without delay", mutate: func(p *Policy) {
+			p.Escalation = []Step{{Channels: []string{"pagerduty"}}}
+		}, wantErr: ErrInvalidEscalation},
+		{name: "escalation out of order", mutate: func(p *Policy) {
+			p.Escalation = []Step{
+				{After: Duration(30 * time.Minute), Channels: []string{"pagerduty"}},
+				{After: Duration(15 * time.Minute), Channels: []string{"opsgenie"}},
+			}
+		}, wantErr: ErrInvalidEscalation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.mutate(p)
+			assert.Equal(t, tt.wantErr, p.Validate())
+		})
+	}
+}
+
+func TestPolicy_Matches(t *testing.T) {
+	svc := &service.Service{Slug: "api", Group: "platform", Tags: []string{"core", "public"}}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		severity string
+		want     bool
+	}{
+		{name: "empty matcher", policy: Policy{Enabled: true}, severity: SeverityCritical, want: true},
+		{name: "disabled", policy: Policy{}, severity: SeverityCritical, want: false},
+		{name: "slug", policy: Policy{Enabled: true, Match: Matcher{Services: []string{"web", "api"}}}, severity: SeverityWarning, want: true},
+		{name: "other slug", policy: Policy{Enabled: true, Match: Matcher{Services: []string{"web"}}}, severity: SeverityWarning, want: false},
+		{name: "group and tag", policy: Policy{Enabled: true, Match: Matcher{Groups: []string{"platform"}, Tags: []string{"public"}}}, severity: SeverityCritical, want: true},
+		{name: "group but no tag", policy: Policy{Enabled: true, Match: Matcher{Groups: []string{"platform"}, Tags: []string{"internal"}}}, severity: SeverityCritical, want: false},
+		{name: "severity filtered", policy: Policy{Enabled: true, Severities: []string{SeverityCritical}}, severity: SeverityWarning, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Matches(svc, tt.severity))
+		})
+	}
+}
+
+func TestQuietHours_Active(t *testing.T) {
+	overnight := &QuietHours{Start: "22:00", End: "07:00", Timezone: "America/New_York"}
+
+	// 03:30 UTC is 22:30 in New York during winter
+	assert.True(t, overnight.Active(time.Date(2024, 1, 10, 3, 30, 0, 0, time.UTC)))
+	assert.True(t, overnight.Active(time.Date(2024, 1, 10, 11, 59, 0, 0, time.UTC)))
+	assert.False(t, overnight.Active(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)))
+
+	lunch := &QuietHours{Start: "12:00", End: "13:00"}
+	assert.True(t, lunch.Active(time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)))
+	assert.False(t, lunch.Active(time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC)))
+}
+
+func TestDuration_JSON(t *testing.T) {
+	var p Policy
+	require.NoError(t, json.Unmarshal([]byte(`{"repeat_interval":"1h30m"}`), &p))
+	assert.Equal(t, Duration(90*time.Minute), p.RepeatInterval)
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"repeat_interval":"1h30m0s"`)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"repeat_interval":"soon"}`), &p))
+	assert.Error(t, json.Unmarshal([]byte(`{"repeat_interval":60}`), &p))
+}
+
+func TestSeverityForStatus(t *testing.T) {
+	assert.Equal(t, SeverityCritical, SeverityForStatus(service.StatusDown))
+	assert.Equal(t, SeverityWarning, SeverityForStatus(service.StatusDegraded))
+	assert.Empty(t, SeverityForStatus(service.StatusOperational))
+}
+
+func TestStatusForSeverity(t *testing.T) {
+	assert.Equal(t, service.StatusDown, StatusForSeverity(SeverityCritical))
+	assert.Equal(t, service.StatusDegraded, StatusForSeverity(SeverityWarning))
+	assert.Empty(t, StatusForSeverity("info"))
+}
+
+func TestPolicy_DueSteps(t *testing.T) {
+	policy := Policy{Escalation: []Step{
+		{After: Duration(15 * time.Minute), Channels: []string{"pagerduty"}},
+		{After: Duration(time.Hour), Channels: []string{"opsgenie"}},
+	}}
+
+	assert.Equal(t, 0, policy.DueSteps(10*time.Minute))
+	assert.Equal(t, 1, policy.DueSteps(15*time.Minute))
+	assert.Equal(t, 2, policy.DueSteps(2*time.Hour))
+	assert.Equal(t, 0, (&Policy{}).DueSteps(time.Hour))
+}
@@ -0,0 +1,127 @@
+package alert
+
+import (
+	"context"
+	"time"
+)
+
+// PolicyRepository defines the interface for notification policy data access
+type PolicyRepository interface {
+	// Create creates a new policy
+	Create(ctx context.Context, policy *Policy) error
+
+	// GetByID retrieves a policy by ID
+	GetByID(ctx context.Context, id string) (*Policy, error)
+
+	// GetAll retrieves all policies in creation order
+	GetAll(ctx context.Context) ([]*Policy, error)
+
+	// Update replaces an existing policy, matched by ID
+	Update(ctx context.Context, policy *Policy) error
+
+	// Delete deletes a policy
+	Delete(ctx context.Context, id string) error
+}
+
+// AlertRepository defines the interface for alert data access. State changes
+// are separate operations so the checker and the API can update the same
+// alert without overwriting each other.
+type AlertRepository interface {
+	// Create stores a new alert
+	Create(ctx context.Context, alert *Alert) error
+
+	// GetByID retrieves an alert by ID
+	GetByID(ctx context.Context, id string) (*Alert, error)
+
+	// GetOpen retrieves the unresolved alert of a service
+	GetOpen(ctx context.Context, serviceSlug string) (*Alert, error)
+
+	// List retrieves up to limit alerts, newest first, optionally filtered by state
+	List(ctx context.Context, state string, limit int) ([]*Alert, error)
+
+	// Acknowledge marks an open alert as acknowledged and returns it
+	Acknowledge(ctx context.Context, id, by string, at time.Time) (*Alert, error)
+
+	// Resolve closes an alert
+	Resolve(ctx context.Context, id string, at time.Time) error
+
+	// SetEscalated records how many escalation steps of a policy were sent
+	SetEscalated(ctx context.Context, id, policyID string, steps int) error
+
+	// SetAnnounced records that the failure was sent on a policy's channels
+	SetAnnounced(ctx context.Context, id, policyID string) error
+}
+
+// OutboxRepository defines the interface for the notification outbox.
+// Deliveries are claimed with a lease: a claimed delivery that is neither
+// completed nor failed before the lease expires becomes due again, so a
+// dispatcher that crashes mid-delivery never loses it.
+type OutboxRepository interface {
+	// Enqueue stores new pending deliveries
+	Enqueue(ctx context.Context, deliveries []*Delivery) error
+
+	// Claim leases up to limit pending deliveries that are due at now,
+	// counting an attempt on each, oldest due first
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, error)
+
+	// Complete marks a delivery as delivered
+	Complete(ctx context.Context, id string, at time.Time) error
+
+	// Fail records a failed attempt and schedules the next one
+	Fail(ctx context.Context, id, lastError string, retryAt time.Time) error
+
+	// Bury moves a delivery to the dead-letter state
+	Bury(ctx context.Context, id, lastError string) error
+
+	// Retry returns a dead-lettered delivery to the queue with fresh attempts
+	Retry(ctx context.Context, id string, at time.Time) (*Delivery, error)
+
+	// List retrieves up to limit deliveries, newest first, optionally filtered by state
+	List(ctx context.Context, state string, limit int) ([]*Delivery, error)
+
+	// Count returns the number of deliveries in each state
+	Count(ctx context.Context) (map[string]int64, error)
+}
+
+// OutboxPurger is implemented by outbox repositories whose store does not
+// expire delivered deliveries by itself
+type OutboxPurger interface {
+	// PurgeDelivered deletes deliveries delivered before the given time and
+	// returns how many were deleted
+	PurgeDelivered(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SilenceRepository defines the interface for silence data access
+type SilenceRepository interface {
+	// Create validates and stores a new silence
+	Create(ctx context.Context, silence *Silence) error
+
+	// GetByID retrieves a silence by ID
+	GetByID(ctx context.Context, id string) (*Silence, error)
+
+	// GetAll retrieves all silences in creation order
+	GetAll(ctx context.Context) ([]*Silence, error)
+
+	// GetActive retrieves the silences whose window contains at
+	GetActive(ctx context.Context, at time.Time) ([]*Silence, error)
+
+	// Expire ends a silence at the given time and returns it
+	Expire(ctx context.Context, id string, at time.Time) (*Silence, error)
+}
+
+// TemplateRepository defines the interface for channel message template
+// data access. Templates are keyed by channel name.
+type TemplateRepository interface {
+	// Save validates and stores the template of a channel, replacing any
+	// existing one
+	Save(ctx context.Context, template *Template) error
+
+	// Get retrieves the template of a channel
+	Get(ctx context.Context, channel string) (*Template, error)
+
+	// GetAll retrieves all templates ordered by channel
+	GetAll(ctx context.Context) ([]*Template, error)
+
+	// Delete deletes the template of a channel
+	Delete(ctx context.Context, channel string) error
+}
@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+// Silence states, derived from the silence window
+const (
+	SilencePending = "pending"
+	SilenceActive  = "active"
+	SilenceExpired = "expired"
+)
+
+// Silence mutes notifications for matching services between StartsAt and
+// EndsAt, for example during a planned migration. The services stay
+// monitored and visible on the status page. Channels limits the silence to
+// those channels; without it every channel is muted.
+type Silence struct {
+	ID        string    `bson:"_id,omitempty" json:"id,omitempty"`
+	Match     Matcher   `bson:"match" json:"match"`
+	Channels  []string  `bson:"channels,omitempty" json:"channels,omitempty"`
+	StartsAt  time.Time `bson:"starts_at" json:"starts_at"`
+	EndsAt    time.Time `bson:"ends_at" json:"ends_at"`
+	CreatedBy string    `bson:"created_by" json:"created_by"`
+	Comment   string    `bson:"comment" json:"comment"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Validate validates the silence
+func (s *Silence) Validate() error {
+	if s.CreatedBy == "" {
+		return ErrSilenceCreatorRequired
+	}
+	if s.Comment == "" {
+		return ErrSilenceCommentRequired
+	}
+	if len(s.Match.Services) == 0 && len(s.Match.Groups) == 0 && len(s.Match.Tags) == 0 && len(s.Channels) == 0 {
+		return ErrSilenceMatcherRequired
+	}
+	if s.StartsAt.IsZero() || !s.EndsAt.After(s.StartsAt) {
+		return ErrInvalidSilenceWindow
+	}
+	return nil
+}
+
+// State returns whether the silence is pending, active or expired at t
+func (s *Silence) State(t time.Time) string {
+	switch {
+	case t.Before(s.StartsAt):
+		return SilencePending
+	case t.Before(s.EndsAt):
+		return SilenceActive
+	default:
+		return SilenceExpired
+	}
+}
+
+// Mutes reports whether the silence mutes notifications about svc on the
+// named channel at t
+func (s *Silence) Mutes(svc *service.Service, channel string, t time.Time) bool {
+	if s.State(t) != SilenceActive {
+		return false
+	}
+	if len(s.Channels) > 0 && !contains(s.Channels, channel) {
+		return false
+	}
+	return s.Match.Matches(svc)
+}
+
+// Expire ends the silence at t. A silence that has not started yet is
+// ended before it starts; one that has already expired is left unchanged.
+func (s *Silence) Expire(t time.Time) error {
+	if s.State(t) == SilenceExpired {
+		return ErrSilenceExpired
+	}
+	if t.Before(s.StartsAt) {
+		s.StartsAt = t
+	}
+	s.EndsAt = t
+	return nil
+}
@@ -0,0 +1,87 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+func validSilence() Silence {
+	start := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	return Silence{
+		Match:     Matcher{Services: []string{"api"}},
+		StartsAt:  start,
+		EndsAt:    start.Add(2 * time.Hour),
+		CreatedBy: "alice",
+		Comment:   "database migration",
+	}
+}
+
+func TestSilence_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Silence)
+		wantErr error
+	}{
+		{name: "valid", modify: func(s *Silence) {}},
+		{name: "channel only", modify: func(s *Silence) { s.Match = Matcher{}; s.Channels = []string{"slack"} }},
+		{name: "missing creator", modify: func(s *Silence) { s.CreatedBy = "" }, wantErr: ErrSilenceCreatorRequired},
+		{name: "missing comment", modify: func(s *Silence) { s.Comment = "" }, wantErr: ErrSilenceCommentRequired},
+		{name: "matches everything", modify: func(s *Silence) { s.Match = Matcher{} }, wantErr: ErrSilenceMatcherRequired},
+		{name: "missing start", modify: func(s *Silence) { s.StartsAt = time.Time{} }, wantErr: ErrInvalidSilenceWindow},
+		{name: "ends before start", modify: func(s *Silence) { s.EndsAt = s.StartsAt }, wantErr: ErrInvalidSilenceWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := validSilence()
+			tt.modify(&s)
+			assert.Equal(t, tt.wantErr, s.Validate())
+		})
+	}
+}
+
+func TestSilence_Mutes(t *testing.T) {
+	api := &service.Service{Slug: "api", Group: "platform", Tags: []string{"core"}}
+	web := &service.Service{Slug: "web", Group: "frontend"}
+
+	s := validSilence()
+	during := s.StartsAt.Add(time.Hour)
+
+	assert.Equal(t, SilencePending, s.State(s.StartsAt.Add(-time.Second)))
+	assert.Equal(t, SilenceActive, s.State(s.StartsAt))
+	assert.Equal(t, SilenceExpired, s.State(s.EndsAt))
+
+	assert.True(t, s.Mutes(api, "slack", during))
+	assert.False(t, s.Mutes(web, "slack", during))
+	assert.False(t, s.Mutes(api, "slack", s.EndsAt))
+
+	s.Channels = []string{"pagerduty"}
+	assert.True(t, s.Mutes(api, "pagerduty", during))
+	assert.False(t, s.Mutes(api, "slack", during))
+
+	// A channel-only silence mutes that channel for every service
+	s.Match = Matcher{}
+	assert.True(t, s.Mutes(web, "pagerduty", during))
+}
+
+func TestSilence_Expire(t *testing.T) {
+	s := validSilence()
+	at := s.StartsAt.Add(30 * time.Minute)
+
+	require.NoError(t, s.Expire(at))
+	assert.Equal(t, at, s.EndsAt)
+	assert.Equal(t, SilenceExpired, s.State(at))
+	assert.Equal(t, ErrSilenceExpired, s.Expire(at.Add(time.Minute)))
+
+	// Expiring a pending silence ends it before it starts
+	pending := validSilence()
+	early := pending.StartsAt.Add(-time.Hour)
+	require.NoError(t, pending.Expire(early))
+	assert.Equal(t, early, pending.StartsAt)
+	assert.Equal(t, early, pending.EndsAt)
+}
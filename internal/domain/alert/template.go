@@ -0,0 +1,29 @@
+package alert
+
+import (
+	"strings"
+	"time"
+)
+
+// Template is the message template of a notification channel. Source is Go
+// template text that may define "title" and "text"; anything it leaves out
+// keeps the channel's built-in template. There is at most one template per
+// channel, identified by the channel name.
+type Template struct {
+	Channel   string    `bson:"_id" json:"channel"`
+	Source    string    `bson:"source" json:"source"`
+	UpdatedBy string    `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate validates the template. Template syntax is checked by the
+// notifier that renders it.
+func (t *Template) Validate() error {
+	if t.Channel == "" || strings.ContainsAny(t.Channel, "/ ") {
+		return ErrTemplateChannelRequired
+	}
+	if strings.TrimSpace(t.Source) == "" {
+		return ErrTemplateSourceRequired
+	}
+	return nil
+}
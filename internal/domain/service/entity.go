@@ -4,14 +4,27 @@ import (
 	"time"
 )
 
+// Service status values reported by health checks
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusDown        = "down"
+
+	// StatusUnknown is reported for enabled services that have not been checked yet
+	StatusUnknown = "unknown"
+)
+
 // Service represents a monitored service
 type Service struct {
+	ID             string            `bson:"_id,omitempty" json:"id,omitempty"`
 	Name           string            `bson:"name" json:"name"`
 	Slug           string            `bson:"slug" json:"slug"`
 	URL            string            `bson:"url" json:"url"`
 	Headers        map[string]string `bson:"headers" json:"headers"`
 	ExpectedStatus int               `bson:"expected_status" json:"expected_status"`
 	Enabled        bool              `bson:"enabled" json:"enabled"`
+	Group          string            `bson:"group,omitempty" json:"group,omitempty"`
+	Tags           []string          `bson:"tags,omitempty" json:"tags,omitempty"`
 	CreatedAt      time.Time         `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time         `bson:"updated_at" json:"updated_at"`
 }
@@ -51,15 +64,20 @@ func (s *Service) Validate() error {
 
 // IsOperational returns true if the service is operational
 func (ss *ServiceStatus) IsOperational() bool {
-	return ss.Status == "operational"
+	return ss.Status == StatusOperational
 }
 
 // IsDegraded returns true if the service is degraded
 func (ss *ServiceStatus) IsDegraded() bool {
-	return ss.Status == "degraded"
+	return ss.Status == StatusDegraded
 }
 
 // IsDown returns true if the service is down
 func (ss *ServiceStatus) IsDown() bool {
-	return ss.Status == "down"
+	return ss.Status == StatusDown
+}
+
+// IsUnknown returns true if the service has not been checked yet
+func (ss *ServiceStatus) IsUnknown() bool {
+	return ss.Status == StatusUnknown
 }
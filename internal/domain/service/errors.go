@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+
 	"github.com/sukhera/uptime-monitor/internal/shared/errors"
 )
 
@@ -14,3 +16,58 @@ var (
 	ErrServiceDisabled       = errors.NewValidationError("service is disabled")
 	ErrInvalidServiceStatus  = errors.NewValidationError("invalid service status")
 )
+
+// RejectedLogsError reports status logs the database rejected from a batch
+// whose other logs were stored. Rejected logs will not succeed on retry.
+type RejectedLogsError struct {
+	Rejected int
+	Err      error
+}
+
+// Kind classifies the rejection as a validation error
+func (e *RejectedLogsError) Kind() errors.ErrorKind {
+	return errors.ErrorKindValidation
+}
+
+// Error returns the error message
+func (e *RejectedLogsError) Error() string {
+	return fmt.Sprintf("%d status logs rejected by database: %v", e.Rejected, e.Err)
+}
+
+// Cause returns the database error
+func (e *RejectedLogsError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the database error
+func (e *RejectedLogsError) Unwrap() error {
+	return e.Err
+}
+
+// UnsentLogsError reports a batch write that failed before the logs after
+// the first Stored ones reached the database. Those logs can be written
+// again without storing duplicates.
+type UnsentLogsError struct {
+	Stored int
+	Err    error
+}
+
+// Kind classifies the failure as an internal error
+func (e *UnsentLogsError) Kind() errors.ErrorKind {
+	return errors.ErrorKindInternal
+}
+
+// Error returns the error message
+func (e *UnsentLogsError) Error() string {
+	return fmt.Sprintf("status logs not sent to database after %d stored: %v", e.Stored, e.Err)
+}
+
+// Cause returns the database error
+func (e *UnsentLogsError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the database error
+func (e *UnsentLogsError) Unwrap() error {
+	return e.Err
+}
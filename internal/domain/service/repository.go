@@ -30,9 +30,18 @@ type Repository interface {
 	// SaveStatusLog saves a status log entry
 	SaveStatusLog(ctx context.Context, log *StatusLog) error
 
-	// GetLatestStatus retrieves the latest status for all services
+	// SaveStatusLogs saves a batch of status log entries in as few round
+	// trips as the storage allows
+	SaveStatusLogs(ctx context.Context, logs []*StatusLog) error
+
+	// GetLatestStatus retrieves exactly one current status per enabled service,
+	// in the same order as GetEnabled. Services without status logs are
+	// reported as StatusUnknown.
 	GetLatestStatus(ctx context.Context) ([]*ServiceStatus, error)
 
 	// GetStatusHistory retrieves status history for a service
 	GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*StatusLog, error)
+
+	// Ping verifies the underlying storage is reachable
+	Ping(ctx context.Context) error
 }
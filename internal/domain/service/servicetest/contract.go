@@ -0,0 +1,410 @@
+// Package servicetest provides a behavioural test suite that every
+// service.Repository implementation must pass.
+package servicetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// Factory returns an empty repository for a single subtest
+type Factory func(t *testing.T) service.Repository
+
+// RunRepositoryContract runs the shared repository contract against the
+// repositories produced by newRepo. Each subtest receives a fresh repository.
+func RunRepositoryContract(t *testing.T, newRepo Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo service.Repository)
+	}{
+		{name: "create assigns id and timestamps", run: testCreateAssignsIDAndTimestamps},
+		{name: "create rejects invalid service", run: testCreateRejectsInvalid},
+		{name: "create rejects duplicate slug", run: testCreateRejectsDuplicateSlug},
+		{name: "get by id and slug", run: testGetByIDAndSlug},
+		{name: "get missing service returns not found", run: testGetMissing},
+		{name: "returned services are copies", run: testReturnedServicesAreCopies},
+		{name: "get all and enabled", run: testGetAllAndEnabled},
+		{name: "update by slug", run: testUpdate},
+		{name: "update keeps created_at and stamps updated_at", run: testUpdateTimestamps},
+		{name: "update missing service returns not found", run: testUpdateMissing},
+		{name: "delete", run: testDelete},
+		{name: "status history is newest first", run: testStatusHistoryOrdering},
+		{name: "status history respects limit", run: testStatusHistoryLimit},
+		{name: "save status logs in batch", run: testSaveStatusLogs},
+		{name: "latest status covers every enabled service", run: testLatestStatus},
+		{name: "latest status is not capped", run: testLatestStatusManyServices},
+		{name: "ping", run: testPing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// NewService returns a valid service with the given slug
+func NewService(slug string) *service.Service {
+	return &service.Service{
+		Name:           "Service " + slug,
+		Slug:           slug,
+		URL:            "https://" + slug + ".example.com/health",
+		Headers:        map[string]string{"User-Agent": "uptime-monitor"},
+		ExpectedStatus: 200,
+		Enabled:        true,
+		Group:          "platform",
+		Tags:           []string{"core"},
+	}
+}
+
+func testCreateAssignsIDAndTimestamps(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	svc := NewService("api")
+
+	require.NoError(t, repo.Create(ctx, svc))
+
+	assert.NotEmpty(t, svc.ID)
+	assert.False(t, svc.CreatedAt.IsZero())
+	assert.False(t, svc.UpdatedAt.IsZero())
+}
+
+func testCreateRejectsInvalid(t *testing.T, repo service.Repository) {
+	svc := NewService("api")
+	svc.URL = ""
+
+	err := repo.Create(context.Background(), svc)
+
+	assert.True(t, errors.IsValidation(err), "expected validation error, got %v", err)
+}
+
+func testCreateRejectsDuplicateSlug(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, NewService("api")))
+
+	duplicate := NewService("api")
+	duplicate.Name = "Another API"
+	err := repo.Create(ctx, duplicate)
+
+	require.Error(t, err)
+	var domainErr errors.Error
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, errors.ErrorKindConflict, domainErr.Kind())
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func testGetByIDAndSlug(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	svc := NewService("api")
+	require.NoError(t, repo.Create(ctx, svc))
+
+	byID, err := repo.GetByID(ctx, svc.ID)
+	require.NoError(t, err)
+	assertSameService(t, svc, byID)
+
+	bySlug, err := repo.GetBySlug(ctx, svc.Slug)
+	require.NoError(t, err)
+	assertSameService(t, svc, bySlug)
+}
+
+func testGetMissing(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+
+	// Create and delete a service to obtain a well-formed but unknown ID
+	svc := NewService("gone")
+	require.NoError(t, repo.Create(ctx, svc))
+	require.NoError(t, repo.Delete(ctx, svc.ID))
+
+	_, err := repo.GetByID(ctx, svc.ID)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+
+	_, err = repo.GetBySlug(ctx, "does-not-exist")
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func testReturnedServicesAreCopies(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, NewService("api")))
+
+	got, err := repo.GetBySlug(ctx, "api")
+	require.NoError(t, err)
+	got.Name = "mutated"
+	got.Headers["User-Agent"] = "mutated"
+
+	again, err := repo.GetBySlug(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, "Service api", again.Name)
+	assert.Equal(t, "uptime-monitor", again.Headers["User-Agent"])
+}
+
+func testGetAllAndEnabled(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	disabled := NewService("disabled")
+	disabled.Enabled = false
+
+	for _, svc := range []*service.Service{NewService("first"), disabled, NewService("third")} {
+		require.NoError(t, repo.Create(ctx, svc))
+	}
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "disabled", "third"}, slugs(all))
+
+	enabled, err := repo.GetEnabled(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "third"}, slugs(enabled))
+}
+
+func testUpdate(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	svc := NewService("api")
+	require.NoError(t, repo.Create(ctx, svc))
+
+	got, err := repo.GetBySlug(ctx, "api")
+	require.NoError(t, err)
+	got.Name = "Renamed API"
+	got.Enabled = false
+	got.ExpectedStatus = 204
+	got.Group = "platform"
+	got.Tags = []string{"core", "public"}
+	require.NoError(t, repo.Update(ctx, got))
+
+	updated, err := repo.GetByID(ctx, svc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, svc.ID, updated.ID)
+	assert.Equal(t, "Renamed API", updated.Name)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, 204, updated.ExpectedStatus)
+	assert.Equal(t, "platform", updated.Group)
+	assert.Equal(t, []string{"core", "public"}, updated.Tags)
+
+	invalid := *updated
+	invalid.ExpectedStatus = 0
+	assert.True(t, errors.IsValidation(repo.Update(ctx, &invalid)))
+}
+
+func testUpdateTimestamps(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	svc := NewService("api")
+	svc.CreatedAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	svc.UpdatedAt = svc.CreatedAt
+	require.NoError(t, repo.Create(ctx, svc))
+
+	// A caller that leaves the timestamps zero neither clears created_at nor
+	// writes a zero updated_at
+	changed := NewService("api")
+	changed.Name = "Renamed API"
+	before := time.Now().UTC()
+	require.NoError(t, repo.Update(ctx, changed))
+
+	assert.WithinDuration(t, svc.CreatedAt, changed.CreatedAt, time.Millisecond)
+	assert.False(t, changed.UpdatedAt.Before(before.Truncate(time.Millisecond)))
+
+	stored, err := repo.GetBySlug(ctx, "api")
+	require.NoError(t, err)
+	assert.WithinDuration(t, svc.CreatedAt, stored.CreatedAt, time.Millisecond)
+	assert.WithinDuration(t, changed.UpdatedAt, stored.UpdatedAt, time.Millisecond)
+	assert.True(t, stored.UpdatedAt.After(svc.UpdatedAt))
+
+	// A caller cannot rewrite created_at either
+	changed.CreatedAt = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Update(ctx, changed))
+	stored, err = repo.GetBySlug(ctx, "api")
+	require.NoError(t, err)
+	assert.WithinDuration(t, svc.CreatedAt, stored.CreatedAt, time.Millisecond)
+}
+
+func testUpdateMissing(t *testing.T, repo service.Repository) {
+	err := repo.Update(context.Background(), NewService("missing"))
+
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+}
+
+func testDelete(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	svc := NewService("api")
+	require.NoError(t, repo.Create(ctx, svc))
+
+	require.NoError(t, repo.Delete(ctx, svc.ID))
+
+	_, err := repo.GetBySlug(ctx, "api")
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+
+	err = repo.Delete(ctx, svc.ID)
+	assert.True(t, errors.IsNotFound(err), "expected not found error, got %v", err)
+
+	// The slug is free again after deletion
+	assert.NoError(t, repo.Create(ctx, NewService("api")))
+}
+
+func testStatusHistoryOrdering(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	// Insert out of order to make sure ordering comes from the timestamp
+	for _, offset := range []int{2, 0, 3, 1} {
+		require.NoError(t, repo.SaveStatusLog(ctx, statusLog("api", base, offset)))
+	}
+	require.NoError(t, repo.SaveStatusLog(ctx, statusLog("other", base, 10)))
+
+	history, err := repo.GetStatusHistory(ctx, "api", 0)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+
+	for i, log := range history {
+		assert.Equal(t, "api", log.ServiceName)
+		assert.Equal(t, int64(3-i), log.Latency)
+		assert.WithinDuration(t, base.Add(time.Duration(3-i)*time.Minute), log.Timestamp, time.Millisecond)
+	}
+
+	empty, err := repo.GetStatusHistory(ctx, "unknown", 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func testStatusHistoryLimit(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	for offset := 0; offset < 5; offset++ {
+		require.NoError(t, repo.SaveStatusLog(ctx, statusLog("api", base, offset)))
+	}
+
+	history, err := repo.GetStatusHistory(ctx, "api", 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, int64(4), history[0].Latency)
+	assert.Equal(t, int64(3), history[1].Latency)
+}
+
+func testSaveStatusLogs(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	require.NoError(t, repo.SaveStatusLogs(ctx, nil))
+
+	logs := make([]*service.StatusLog, 0, 5)
+	for offset := 0; offset < 5; offset++ {
+		logs = append(logs, statusLog("api", base, offset))
+	}
+	logs = append(logs, statusLog("other", base, 10))
+	require.NoError(t, repo.SaveStatusLogs(ctx, logs))
+
+	history, err := repo.GetStatusHistory(ctx, "api", 0)
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+	assert.Equal(t, int64(4), history[0].Latency)
+	assert.Equal(t, int64(0), history[4].Latency)
+
+	other, err := repo.GetStatusHistory(ctx, "other", 0)
+	require.NoError(t, err)
+	assert.Len(t, other, 1)
+}
+
+func testLatestStatus(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	disabled := NewService("disabled")
+	disabled.Enabled = false
+	for _, svc := range []*service.Service{NewService("api"), disabled, NewService("unchecked"), NewService("web")} {
+		require.NoError(t, repo.Create(ctx, svc))
+	}
+
+	for _, offset := range []int{1, 3, 2} {
+		require.NoError(t, repo.SaveStatusLog(ctx, statusLog("Service api", base, offset)))
+	}
+	down := statusLog("Service web", base, 5)
+	down.Status = service.StatusDown
+	down.Error = "connection refused"
+	require.NoError(t, repo.SaveStatusLog(ctx, down))
+	require.NoError(t, repo.SaveStatusLog(ctx, statusLog("Service disabled", base, 9)))
+
+	statuses, err := repo.GetLatestStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	assert.Equal(t, "Service api", statuses[0].Name)
+	assert.Equal(t, service.StatusOperational, statuses[0].Status)
+	assert.Equal(t, int64(3), statuses[0].Latency)
+	assert.WithinDuration(t, base.Add(3*time.Minute), statuses[0].UpdatedAt, time.Millisecond)
+
+	assert.Equal(t, "Service unchecked", statuses[1].Name)
+	assert.Equal(t, service.StatusUnknown, statuses[1].Status)
+	assert.True(t, statuses[1].UpdatedAt.IsZero())
+
+	assert.Equal(t, "Service web", statuses[2].Name)
+	assert.Equal(t, service.StatusDown, statuses[2].Status)
+	assert.Equal(t, "connection refused", statuses[2].Error)
+}
+
+func testLatestStatusManyServices(t *testing.T, repo service.Repository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	// Older implementations only looked at the newest 100 logs
+	const count = 120
+	for i := 0; i < count; i++ {
+		svc := NewService(fmt.Sprintf("svc-%03d", i))
+		require.NoError(t, repo.Create(ctx, svc))
+		require.NoError(t, repo.SaveStatusLog(ctx, statusLog(svc.Name, base, i)))
+	}
+
+	statuses, err := repo.GetLatestStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, count)
+	for i, status := range statuses {
+		assert.Equal(t, fmt.Sprintf("Service svc-%03d", i), status.Name)
+		assert.Equal(t, service.StatusOperational, status.Status)
+	}
+}
+
+func testPing(t *testing.T, repo service.Repository) {
+	assert.NoError(t, repo.Ping(context.Background()))
+}
+
+// statusLog builds an operational log for name, offset minutes after base.
+// The latency doubles as a marker for the offset.
+func statusLog(name string, base time.Time, offset int) *service.StatusLog {
+	return &service.StatusLog{
+		ServiceName: name,
+		Status:      service.StatusOperational,
+		Latency:     int64(offset),
+		StatusCode:  200,
+		Timestamp:   base.Add(time.Duration(offset) * time.Minute),
+	}
+}
+
+func assertSameService(t *testing.T, want, got *service.Service) {
+	t.Helper()
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Slug, got.Slug)
+	assert.Equal(t, want.URL, got.URL)
+	assert.Equal(t, want.Headers, got.Headers)
+	assert.Equal(t, want.ExpectedStatus, got.ExpectedStatus)
+	assert.Equal(t, want.Enabled, got.Enabled)
+	assert.Equal(t, want.Group, got.Group)
+	assert.Equal(t, want.Tags, got.Tags)
+	assert.WithinDuration(t, want.CreatedAt, got.CreatedAt, time.Millisecond)
+}
+
+func slugs(services []*service.Service) []string {
+	result := make([]string, 0, len(services))
+	for _, svc := range services {
+		result = append(result, svc.Slug)
+	}
+	return result
+}
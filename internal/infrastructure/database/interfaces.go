@@ -20,6 +20,11 @@ type Interface interface {
 	StatusLogsCollection() *mongo.Collection
 	IncidentsCollection() *mongo.Collection
 	MaintenancesCollection() *mongo.Collection
+	AlertPoliciesCollection() *mongo.Collection
+	AlertsCollection() *mongo.Collection
+	OutboxCollection() *mongo.Collection
+	SilencesCollection() *mongo.Collection
+	TemplatesCollection() *mongo.Collection
 
 	// Database operations
 	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
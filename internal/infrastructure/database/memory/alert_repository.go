@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+)
+
+// AlertRepository is an in-memory implementation of alert.AlertRepository
+type AlertRepository struct {
+	mu     sync.RWMutex
+	nextID int64
+	alerts map[string]*alert.Alert
+	order  []string
+}
+
+// NewAlertRepository creates a new, empty in-memory alert repository
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{
+		alerts: make(map[string]*alert.Alert),
+	}
+}
+
+// Create stores a new alert
+func (r *AlertRepository) Create(ctx context.Context, a *alert.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	a.ID = strconv.FormatInt(r.nextID, 10)
+
+	r.alerts[a.ID] = copyAlert(a)
+	r.order = append(r.order, a.ID)
+
+	return nil
+}
+
+// GetByID retrieves an alert by its ID
+func (r *AlertRepository) GetByID(ctx context.Context, id string) (*alert.Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.alerts[id]
+	if !ok {
+		return nil, alert.ErrAlertNotFound
+	}
+
+	return copyAlert(a), nil
+}
+
+// GetOpen retrieves the most recent unresolved alert of a service
+func (r *AlertRepository) GetOpen(ctx context.Context, serviceSlug string) (*alert.Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.order) - 1; i >= 0; i-- {
+		a := r.alerts[r.order[i]]
+		if a.ServiceSlug == serviceSlug && a.IsOpen() {
+			return copyAlert(a), nil
+		}
+	}
+
+	return nil, alert.ErrAlertNotFound
+}
+
+// List retrieves up to limit alerts in reverse insertion order, optionally
+// filtered by state
+func (r *AlertRepository) List(ctx context.Context, state string, limit int) ([]*alert.Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alerts := []*alert.Alert{}
+	for i := len(r.order) - 1; i >= 0 && len(alerts) < limit; i-- {
+		a := r.alerts[r.order[i]]
+		if state == "" || a.State == state {
+			alerts = append(alerts, copyAlert(a))
+		}
+	}
+
+	return alerts, nil
+}
+
+// Acknowledge marks an open alert as acknowledged and returns it
+func (r *AlertRepository) Acknowledge(ctx context.Context, id, by string, at time.Time) (*alert.Alert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.alerts[id]
+	if !ok {
+		return nil, alert.ErrAlertNotFound
+	}
+	if err := a.Acknowledge(by, at); err != nil {
+		return nil, err
+	}
+
+	return copyAlert(a), nil
+}
+
+// Resolve closes an alert
+func (r *AlertRepository) Resolve(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.alerts[id]
+	if !ok {
+		return alert.ErrAlertNotFound
+	}
+	a.Resolve(at)
+
+	return nil
+}
+
+// SetEscalated records how many escalation steps of a policy were sent
+func (r *AlertRepository) SetEscalated(ctx context.Context, id, policyID string, steps int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.alerts[id]
+	if !ok {
+		return alert.ErrAlertNotFound
+	}
+	if a.Escalations == nil {
+		a.Escalations = make(map[string]int)
+	}
+	a.Escalations[policyID] = steps
+
+	return nil
+}
+
+// SetAnnounced records that the failure was sent on a policy's channels
+func (r *AlertRepository) SetAnnounced(ctx context.Context, id, policyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.alerts[id]
+	if !ok {
+		return alert.ErrAlertNotFound
+	}
+	if !a.WasAnnounced(policyID) {
+		a.Announced = append(a.Announced, policyID)
+	}
+
+	return nil
+}
+
+// copyAlert returns a deep copy so callers cannot mutate stored state
+func copyAlert(a *alert.Alert) *alert.Alert {
+	clone := *a
+	if a.Escalations != nil {
+		clone.Escalations = make(map[string]int, len(a.Escalations))
+		for policyID, steps := range a.Escalations {
+			clone.Escalations[policyID] = steps
+		}
+	}
+	clone.Announced = append([]string(nil), a.Announced...)
+	if a.AcknowledgedAt != nil {
+		at := *a.AcknowledgedAt
+		clone.AcknowledgedAt = &at
+	}
+	if a.ResolvedAt != nil {
+		at := *a.ResolvedAt
+		clone.ResolvedAt = &at
+	}
+	return &clone
+}
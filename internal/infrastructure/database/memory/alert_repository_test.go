@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+)
+
+func TestAlertRepository_Contract(t *testing.T) {
+	alerttest.RunAlertRepositoryContract(t, func(t *testing.T) alert.AlertRepository {
+		return NewAlertRepository()
+	})
+}
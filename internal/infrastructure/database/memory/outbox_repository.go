@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+)
+
+// OutboxRepository is an in-memory implementation of alert.OutboxRepository.
+// It is not durable: queued deliveries are lost when the process exits.
+type OutboxRepository struct {
+	mu         sync.Mutex
+	nextID     int64
+	deliveries map[string]*alert.Delivery
+	order      []string
+}
+
+// NewOutboxRepository creates a new, empty in-memory outbox
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		deliveries: make(map[string]*alert.Delivery),
+	}
+}
+
+// Enqueue stores new pending deliveries
+func (r *OutboxRepository) Enqueue(ctx context.Context, deliveries []*alert.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range deliveries {
+		r.nextID++
+		d.ID = strconv.FormatInt(r.nextID, 10)
+		d.State = alert.DeliveryPending
+
+		r.deliveries[d.ID] = copyDelivery(d)
+		r.order = append(r.order, d.ID)
+	}
+
+	return nil
+}
+
+// Claim leases up to limit due pending deliveries, oldest due first
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*alert.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*alert.Delivery
+	for _, id := range r.order {
+		d := r.deliveries[id]
+		if d.State == alert.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*alert.Delivery, 0, len(due))
+	for _, d := range due {
+		d.Attempts++
+		d.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, copyDelivery(d))
+	}
+
+	return claimed, nil
+}
+
+// Complete marks a delivery as delivered
+func (r *OutboxRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	return r.update(id, func(d *alert.Delivery) {
+		d.State = alert.DeliveryDelivered
+		d.DeliveredAt = &at
+	})
+}
+
+// Fail records a failed attempt and schedules the next one
+func (r *OutboxRepository) Fail(ctx context.Context, id, lastError string, retryAt time.Time) error {
+	return r.update(id, func(d *alert.Delivery) {
+		d.LastError = lastError
+		d.NextAttemptAt = retryAt
+	})
+}
+
+// Bury moves a delivery to the dead-letter state
+func (r *OutboxRepository) Bury(ctx context.Context, id, lastError string) error {
+	return r.update(id, func(d *alert.Delivery) {
+		d.State = alert.DeliveryDead
+		d.LastError = lastError
+	})
+}
+
+// Retry returns a dead-lettered delivery to the queue with fresh attempts
+func (r *OutboxRepository) Retry(ctx context.Context, id string, at time.Time) (*alert.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, alert.ErrDeliveryNotFound
+	}
+	if d.State != alert.DeliveryDead {
+		return nil, alert.ErrDeliveryNotDead
+	}
+
+	d.State = alert.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+
+	return copyDelivery(d), nil
+}
+
+// List retrieves up to limit deliveries in reverse insertion order,
+// optionally filtered by state
+func (r *OutboxRepository) List(ctx context.Context, state string, limit int) ([]*alert.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := []*alert.Delivery{}
+	for i := len(r.order) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		d := r.deliveries[r.order[i]]
+		if state == "" || d.State == state {
+			deliveries = append(deliveries, copyDelivery(d))
+		}
+	}
+
+	return deliveries, nil
+}
+
+// Count returns the number of deliveries in each state
+func (r *OutboxRepository) Count(ctx context.Context) (map[string]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := make(map[string]int64)
+	for _, d := range r.deliveries {
+		counts[d.State]++
+	}
+
+	return counts, nil
+}
+
+// PurgeDelivered deletes deliveries delivered before the given time and
+// returns how many were deleted
+func (r *OutboxRepository) PurgeDelivered(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	r.order = slices.DeleteFunc(r.order, func(id string) bool {
+		d := r.deliveries[id]
+		if d.State != alert.DeliveryDelivered || d.DeliveredAt == nil || !d.DeliveredAt.Before(before) {
+			return false
+		}
+		delete(r.deliveries, id)
+		purged++
+		return true
+	})
+
+	return purged, nil
+}
+
+func (r *OutboxRepository) update(id string, apply func(d *alert.Delivery)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return alert.ErrDeliveryNotFound
+	}
+	apply(d)
+
+	return nil
+}
+
+// copyDelivery returns a copy so callers cannot mutate stored state
+func copyDelivery(d *alert.Delivery) *alert.Delivery {
+	clone := *d
+	if d.DeliveredAt != nil {
+		at := *d.DeliveredAt
+		clone.DeliveredAt = &at
+	}
+	clone.Notification.Grouped = append([]alert.Notification(nil), d.Notification.Grouped...)
+	return &clone
+}
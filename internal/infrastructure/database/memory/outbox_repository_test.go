@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+)
+
+func TestOutboxRepository_Contract(t *testing.T) {
+	alerttest.RunOutboxRepositoryContract(t, func(t *testing.T) alert.OutboxRepository {
+		return NewOutboxRepository()
+	})
+}
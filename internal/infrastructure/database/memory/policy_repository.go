@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// PolicyRepository is an in-memory implementation of alert.PolicyRepository
+type PolicyRepository struct {
+	mu       sync.RWMutex
+	nextID   int64
+	policies map[string]*alert.Policy
+	order    []string
+}
+
+// NewPolicyRepository creates a new, empty in-memory policy repository
+func NewPolicyRepository() *PolicyRepository {
+	return &PolicyRepository{
+		policies: make(map[string]*alert.Policy),
+	}
+}
+
+// Create creates a new policy
+func (r *PolicyRepository) Create(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	r.nextID++
+	policy.ID = strconv.FormatInt(r.nextID, 10)
+
+	r.policies[policy.ID] = copyPolicy(policy)
+	r.order = append(r.order, policy.ID)
+
+	return nil
+}
+
+// GetByID retrieves a policy by its ID
+func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*alert.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy, ok := r.policies[id]
+	if !ok {
+		return nil, alert.ErrPolicyNotFound
+	}
+
+	return copyPolicy(policy), nil
+}
+
+// GetAll retrieves all policies in insertion order
+func (r *PolicyRepository) GetAll(ctx context.Context) ([]*alert.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := make([]*alert.Policy, 0, len(r.order))
+	for _, id := range r.order {
+		policies = append(policies, copyPolicy(r.policies[id]))
+	}
+	return policies, nil
+}
+
+// Update replaces an existing policy, keeping its creation time
+func (r *PolicyRepository) Update(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.policies[policy.ID]
+	if !ok {
+		return alert.ErrPolicyNotFound
+	}
+
+	policy.CreatedAt = existing.CreatedAt
+	policy.UpdatedAt = time.Now().UTC()
+	r.policies[policy.ID] = copyPolicy(policy)
+
+	return nil
+}
+
+// Delete deletes a policy by its ID
+func (r *PolicyRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.policies[id]; !ok {
+		return alert.ErrPolicyNotFound
+	}
+
+	delete(r.policies, id)
+	for i, existing := range r.order {
+		if existing == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// copyPolicy returns a deep copy so callers cannot mutate stored state
+func copyPolicy(policy *alert.Policy) *alert.Policy {
+	clone := *policy
+	clone.Match.Services = append([]string(nil), policy.Match.Services...)
+	clone.Match.Groups = append([]string(nil), policy.Match.Groups...)
+	clone.Match.Tags = append([]string(nil), policy.Match.Tags...)
+	clone.Severities = append([]string(nil), policy.Severities...)
+	clone.Channels = append([]string(nil), policy.Channels...)
+	if policy.QuietHours != nil {
+		quiet := *policy.QuietHours
+		clone.QuietHours = &quiet
+	}
+	if policy.Escalation != nil {
+		clone.Escalation = make([]alert.Step, len(policy.Escalation))
+		for i, step := range policy.Escalation {
+			clone.Escalation[i] = alert.Step{After: step.After, Channels: append([]string(nil), step.Channels...)}
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+)
+
+func TestPolicyRepository_Contract(t *testing.T) {
+	alerttest.RunPolicyRepositoryContract(t, func(t *testing.T) alert.PolicyRepository {
+		return NewPolicyRepository()
+	})
+}
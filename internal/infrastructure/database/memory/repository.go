@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// ServiceRepository is an in-memory implementation of service.Repository.
+// It mirrors the semantics of the database backends and is intended for
+// tests and local development.
+type ServiceRepository struct {
+	mu         sync.RWMutex
+	nextID     int64
+	services   map[string]*service.Service
+	order      []string
+	statusLogs []service.StatusLog
+}
+
+// NewServiceRepository creates a new, empty in-memory service repository
+func NewServiceRepository() *ServiceRepository {
+	return &ServiceRepository{
+		services: make(map[string]*service.Service),
+	}
+}
+
+// Create creates a new service
+func (r *ServiceRepository) Create(ctx context.Context, svc *service.Service) error {
+	if err := svc.Validate(); err != nil {
+		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findBySlug(svc.Slug) != nil {
+		return service.ErrServiceAlreadyExists
+	}
+
+	now := time.Now().UTC()
+	if svc.CreatedAt.IsZero() {
+		svc.CreatedAt = now
+	}
+	if svc.UpdatedAt.IsZero() {
+		svc.UpdatedAt = now
+	}
+
+	r.nextID++
+	svc.ID = strconv.FormatInt(r.nextID, 10)
+
+	r.services[svc.ID] = copyService(svc)
+	r.order = append(r.order, svc.ID)
+
+	return nil
+}
+
+// GetByID retrieves a service by its ID
+func (r *ServiceRepository) GetByID(ctx context.Context, id string) (*service.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	svc, ok := r.services[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("service not found")
+	}
+
+	return copyService(svc), nil
+}
+
+// GetBySlug retrieves a service by slug
+func (r *ServiceRepository) GetBySlug(ctx context.Context, slug string) (*service.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	svc := r.findBySlug(slug)
+	if svc == nil {
+		return nil, errors.NewNotFoundError("service not found")
+	}
+
+	return copyService(svc), nil
+}
+
+// GetAll retrieves all services in insertion order
+func (r *ServiceRepository) GetAll(ctx context.Context) ([]*service.Service, error) {
+	return r.list(func(*service.Service) bool { return true }), nil
+}
+
+// GetEnabled retrieves all enabled services in insertion order
+func (r *ServiceRepository) GetEnabled(ctx context.Context) ([]*service.Service, error) {
+	return r.list(func(svc *service.Service) bool { return svc.Enabled }), nil
+}
+
+// Update updates an existing service, matched by slug
+func (r *ServiceRepository) Update(ctx context.Context, svc *service.Service) error {
+	if err := svc.Validate(); err != nil {
+		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := r.findBySlug(svc.Slug)
+	if existing == nil {
+		return errors.NewNotFoundError("service not found")
+	}
+
+	// The creation time is kept and the update time is stamped here
+	svc.CreatedAt = existing.CreatedAt
+	svc.UpdatedAt = time.Now().UTC()
+
+	updated := copyService(svc)
+	updated.ID = existing.ID
+	r.services[existing.ID] = updated
+
+	return nil
+}
+
+// Delete deletes a service by its ID
+func (r *ServiceRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.services[id]; !ok {
+		return errors.NewNotFoundError("service not found")
+	}
+
+	delete(r.services, id)
+	for i, existing := range r.order {
+		if existing == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// SaveStatusLog saves a status log entry
+func (r *ServiceRepository) SaveStatusLog(ctx context.Context, log *service.StatusLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statusLogs = append(r.statusLogs, *log)
+
+	return nil
+}
+
+// SaveStatusLogs saves a batch of status log entries
+func (r *ServiceRepository) SaveStatusLogs(ctx context.Context, logs []*service.StatusLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, log := range logs {
+		r.statusLogs = append(r.statusLogs, *log)
+	}
+
+	return nil
+}
+
+// GetLatestStatus retrieves one current status per enabled service, in
+// insertion order. Services without status logs are reported as unknown.
+func (r *ServiceRepository) GetLatestStatus(ctx context.Context) ([]*service.ServiceStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latest := make(map[string]service.StatusLog)
+	for _, log := range r.statusLogs {
+		if current, ok := latest[log.ServiceName]; !ok || log.Timestamp.After(current.Timestamp) {
+			latest[log.ServiceName] = log
+		}
+	}
+
+	statuses := make([]*service.ServiceStatus, 0, len(r.order))
+	for _, id := range r.order {
+		svc := r.services[id]
+		if !svc.Enabled {
+			continue
+		}
+
+		log, ok := latest[svc.Name]
+		if !ok {
+			statuses = append(statuses, &service.ServiceStatus{
+				Name:   svc.Name,
+				Status: service.StatusUnknown,
+			})
+			continue
+		}
+
+		statuses = append(statuses, &service.ServiceStatus{
+			Name:      svc.Name,
+			Status:    log.Status,
+			Latency:   log.Latency,
+			UpdatedAt: log.Timestamp,
+			Error:     log.Error,
+		})
+	}
+
+	return statuses, nil
+}
+
+// GetStatusHistory retrieves status history for a service, newest first.
+// A limit of zero or less returns the full history.
+func (r *ServiceRepository) GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*service.StatusLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var history []*service.StatusLog
+	for i := range r.statusLogs {
+		if r.statusLogs[i].ServiceName == serviceName {
+			log := r.statusLogs[i]
+			history = append(history, &log)
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.After(history[j].Timestamp)
+	})
+
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	return history, nil
+}
+
+// Ping always succeeds for the in-memory repository
+func (r *ServiceRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// findBySlug returns the stored service with the given slug; callers must hold the lock
+func (r *ServiceRepository) findBySlug(slug string) *service.Service {
+	for _, id := range r.order {
+		if svc := r.services[id]; svc.Slug == slug {
+			return svc
+		}
+	}
+	return nil
+}
+
+// list returns copies of the stored services matching keep, in insertion order
+func (r *ServiceRepository) list(keep func(*service.Service) bool) []*service.Service {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var services []*service.Service
+	for _, id := range r.order {
+		if svc := r.services[id]; keep(svc) {
+			services = append(services, copyService(svc))
+		}
+	}
+	return services
+}
+
+// copyService returns a deep copy so callers cannot mutate stored state
+func copyService(svc *service.Service) *service.Service {
+	clone := *svc
+	if svc.Headers != nil {
+		clone.Headers = make(map[string]string, len(svc.Headers))
+		for k, v := range svc.Headers {
+			clone.Headers[k] = v
+		}
+	}
+	clone.Tags = append([]string(nil), svc.Tags...)
+	return &clone
+}
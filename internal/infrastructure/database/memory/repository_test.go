@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/domain/service/servicetest"
+)
+
+func TestServiceRepository_Contract(t *testing.T) {
+	servicetest.RunRepositoryContract(t, func(t *testing.T) service.Repository {
+		return NewServiceRepository()
+	})
+}
+
+func TestServiceRepository_InterfaceCompliance(t *testing.T) {
+	var _ service.Repository = (*ServiceRepository)(nil)
+}
+
+func TestServiceRepository_GetLatestStatus(t *testing.T) {
+	repo := NewServiceRepository()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	disabled := servicetest.NewService("disabled")
+	disabled.Enabled = false
+	for _, svc := range []*service.Service{servicetest.NewService("web"), disabled, servicetest.NewService("api")} {
+		require.NoError(t, repo.Create(ctx, svc))
+	}
+
+	logs := []*service.StatusLog{
+		{ServiceName: "Service api", Status: "down", Timestamp: now.Add(-time.Minute)},
+		{ServiceName: "Service api", Status: "operational", Latency: 42, Timestamp: now},
+		{ServiceName: "Service disabled", Status: "down", Timestamp: now},
+		{ServiceName: "Service removed", Status: "down", Timestamp: now},
+	}
+	for _, log := range logs {
+		require.NoError(t, repo.SaveStatusLog(ctx, log))
+	}
+
+	statuses, err := repo.GetLatestStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "Service web", statuses[0].Name)
+	assert.True(t, statuses[0].IsUnknown())
+	assert.True(t, statuses[0].UpdatedAt.IsZero())
+	assert.Equal(t, "Service api", statuses[1].Name)
+	assert.Equal(t, "operational", statuses[1].Status)
+	assert.Equal(t, int64(42), statuses[1].Latency)
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// SilenceRepository is an in-memory implementation of alert.SilenceRepository
+type SilenceRepository struct {
+	mu       sync.RWMutex
+	nextID   int64
+	silences map[string]*alert.Silence
+	order    []string
+}
+
+// NewSilenceRepository creates a new, empty in-memory silence repository
+func NewSilenceRepository() *SilenceRepository {
+	return &SilenceRepository{
+		silences: make(map[string]*alert.Silence),
+	}
+}
+
+// Create validates and stores a new silence
+func (r *SilenceRepository) Create(ctx context.Context, silence *alert.Silence) error {
+	if err := silence.Validate(); err != nil {
+		return errors.NewWithCause("invalid silence", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	silence.CreatedAt = time.Now().UTC()
+
+	r.nextID++
+	silence.ID = strconv.FormatInt(r.nextID, 10)
+
+	r.silences[silence.ID] = copySilence(silence)
+	r.order = append(r.order, silence.ID)
+
+	return nil
+}
+
+// GetByID retrieves a silence by its ID
+func (r *SilenceRepository) GetByID(ctx context.Context, id string) (*alert.Silence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	silence, ok := r.silences[id]
+	if !ok {
+		return nil, alert.ErrSilenceNotFound
+	}
+
+	return copySilence(silence), nil
+}
+
+// GetAll retrieves all silences in insertion order
+func (r *SilenceRepository) GetAll(ctx context.Context) ([]*alert.Silence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	silences := make([]*alert.Silence, 0, len(r.order))
+	for _, id := range r.order {
+		silences = append(silences, copySilence(r.silences[id]))
+	}
+	return silences, nil
+}
+
+// GetActive retrieves the silences whose window contains at
+func (r *SilenceRepository) GetActive(ctx context.Context, at time.Time) ([]*alert.Silence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var silences []*alert.Silence
+	for _, id := range r.order {
+		if silence := r.silences[id]; silence.State(at) == alert.SilenceActive {
+			silences = append(silences, copySilence(silence))
+		}
+	}
+	return silences, nil
+}
+
+// Expire ends a silence at the given time and returns it
+func (r *SilenceRepository) Expire(ctx context.Context, id string, at time.Time) (*alert.Silence, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	silence, ok := r.silences[id]
+	if !ok {
+		return nil, alert.ErrSilenceNotFound
+	}
+	if err := silence.Expire(at); err != nil {
+		return nil, err
+	}
+
+	return copySilence(silence), nil
+}
+
+// copySilence returns a deep copy so callers cannot mutate stored state
+func copySilence(silence *alert.Silence) *alert.Silence {
+	clone := *silence
+	clone.Match.Services = append([]string(nil), silence.Match.Services...)
+	clone.Match.Groups = append([]string(nil), silence.Match.Groups...)
+	clone.Match.Tags = append([]string(nil), silence.Match.Tags...)
+	clone.Channels = append([]string(nil), silence.Channels...)
+	return &clone
+}
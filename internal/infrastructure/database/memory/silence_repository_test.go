@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+)
+
+func TestSilenceRepository_Contract(t *testing.T) {
+	alerttest.RunSilenceRepositoryContract(t, func(t *testing.T) alert.SilenceRepository {
+		return NewSilenceRepository()
+	})
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// TemplateRepository is an in-memory implementation of alert.TemplateRepository
+type TemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[string]*alert.Template
+}
+
+// NewTemplateRepository creates a new, empty in-memory template repository
+func NewTemplateRepository() *TemplateRepository {
+	return &TemplateRepository{
+		templates: make(map[string]*alert.Template),
+	}
+}
+
+// Save validates and stores the template of a channel, replacing any existing one
+func (r *TemplateRepository) Save(ctx context.Context, template *alert.Template) error {
+	if err := template.Validate(); err != nil {
+		return errors.NewWithCause("invalid template", errors.ErrorKindValidation, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template.UpdatedAt = time.Now().UTC()
+
+	clone := *template
+	r.templates[template.Channel] = &clone
+
+	return nil
+}
+
+// Get retrieves the template of a channel
+func (r *TemplateRepository) Get(ctx context.Context, channel string) (*alert.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.templates[channel]
+	if !ok {
+		return nil, alert.ErrTemplateNotFound
+	}
+
+	clone := *template
+	return &clone, nil
+}
+
+// GetAll retrieves all templates ordered by channel
+func (r *TemplateRepository) GetAll(ctx context.Context) ([]*alert.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]*alert.Template, 0, len(r.templates))
+	for _, template := range r.templates {
+		clone := *template
+		templates = append(templates, &clone)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Channel < templates[j].Channel
+	})
+	return templates, nil
+}
+
+// Delete deletes the template of a channel
+func (r *TemplateRepository) Delete(ctx context.Context, channel string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[channel]; !ok {
+		return alert.ErrTemplateNotFound
+	}
+
+	delete(r.templates, channel)
+	return nil
+}
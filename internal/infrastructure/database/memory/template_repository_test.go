@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+)
+
+func TestTemplateRepository_Contract(t *testing.T) {
+	alerttest.RunTemplateRepositoryContract(t, func(t *testing.T) alert.TemplateRepository {
+		return NewTemplateRepository()
+	})
+}
@@ -0,0 +1,187 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AlertRepository implements alert.AlertRepository for MongoDB
+type AlertRepository struct {
+	db Interface
+}
+
+// NewAlertRepository creates a new alert repository
+func NewAlertRepository(db Interface) *AlertRepository {
+	return &AlertRepository{
+		db: db,
+	}
+}
+
+// Create stores a new alert
+func (r *AlertRepository) Create(ctx context.Context, a *alert.Alert) error {
+	a.ID = ""
+
+	result, err := r.db.AlertsCollection().InsertOne(ctx, a)
+	if err != nil {
+		return errors.NewWithCause("failed to create alert", errors.ErrorKindInternal, err)
+	}
+
+	if objectID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		a.ID = objectID.Hex()
+	}
+
+	return nil
+}
+
+// GetByID retrieves an alert by its ID
+func (r *AlertRepository) GetByID(ctx context.Context, id string) (*alert.Alert, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid alert ID format")
+	}
+
+	return r.findOne(ctx, bson.M{"_id": objectID}, nil)
+}
+
+// GetOpen retrieves the most recent unresolved alert of a service
+func (r *AlertRepository) GetOpen(ctx context.Context, serviceSlug string) (*alert.Alert, error) {
+	filter := bson.M{
+		"service_slug": serviceSlug,
+		"state":        bson.M{"$ne": alert.StateResolved},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "triggered_at", Value: -1}})
+
+	return r.findOne(ctx, filter, opts)
+}
+
+// List retrieves up to limit alerts, newest first, optionally filtered by state
+func (r *AlertRepository) List(ctx context.Context, state string, limit int) ([]*alert.Alert, error) {
+	filter := bson.M{}
+	if state != "" {
+		filter["state"] = state
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "triggered_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.db.AlertsCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find alerts", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	alerts := []*alert.Alert{}
+	if err = cursor.All(ctx, &alerts); err != nil {
+		return nil, errors.NewWithCause("failed to decode alerts", errors.ErrorKindInternal, err)
+	}
+
+	return alerts, nil
+}
+
+// Acknowledge marks a triggered alert as acknowledged and returns it. The
+// state is checked in the update filter so a concurrent resolve always wins.
+func (r *AlertRepository) Acknowledge(ctx context.Context, id, by string, at time.Time) (*alert.Alert, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid alert ID format")
+	}
+
+	filter := bson.M{"_id": objectID, "state": alert.StateTriggered}
+	update := bson.M{"$set": bson.M{
+		"state":           alert.StateAcknowledged,
+		"acknowledged_at": at,
+		"acknowledged_by": by,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var acked alert.Alert
+	err = r.db.AlertsCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&acked)
+	if err == nil {
+		return &acked, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, errors.NewWithCause("failed to acknowledge alert", errors.ErrorKindInternal, err)
+	}
+
+	// Not triggered: the alert is missing, already acknowledged or resolved
+	existing, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == alert.StateResolved {
+		return nil, alert.ErrAlertResolved
+	}
+	return existing, nil
+}
+
+// Resolve closes an alert
+func (r *AlertRepository) Resolve(ctx context.Context, id string, at time.Time) error {
+	return r.updateOne(ctx, id, bson.M{"$set": bson.M{
+		"state":       alert.StateResolved,
+		"resolved_at": at,
+	}})
+}
+
+// SetEscalated records how many escalation steps of a policy were sent
+func (r *AlertRepository) SetEscalated(ctx context.Context, id, policyID string, steps int) error {
+	return r.updateOne(ctx, id, bson.M{"$set": bson.M{
+		"escalations." + policyID: steps,
+	}})
+}
+
+// SetAnnounced records that the failure was sent on a policy's channels
+func (r *AlertRepository) SetAnnounced(ctx context.Context, id, policyID string) error {
+	return r.updateOne(ctx, id, bson.M{"$addToSet": bson.M{
+		"announced": policyID,
+	}})
+}
+
+func (r *AlertRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*alert.Alert, error) {
+	var findOpts []*options.FindOneOptions
+	if opts != nil {
+		findOpts = append(findOpts, opts)
+	}
+
+	var a alert.Alert
+	err := r.db.AlertsCollection().FindOne(ctx, filter, findOpts...).Decode(&a)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, alert.ErrAlertNotFound
+		}
+		return nil, errors.NewWithCause("failed to find alert", errors.ErrorKindInternal, err)
+	}
+
+	return &a, nil
+}
+
+func (r *AlertRepository) updateOne(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.NewValidationError("invalid alert ID format")
+	}
+
+	result, err := r.db.AlertsCollection().UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return errors.NewWithCause("failed to update alert", errors.ErrorKindInternal, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return alert.ErrAlertNotFound
+	}
+
+	return nil
+}
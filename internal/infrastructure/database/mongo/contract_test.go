@@ -0,0 +1,75 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/domain/service/servicetest"
+)
+
+// newTestDatabase connects to the server named by MONGO_TEST_URI and
+// returns a database of its own, dropped when the test ends, so indexes and
+// data never leak between tests. The test is skipped when no server is
+// configured.
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set, skipping MongoDB integration test")
+	}
+
+	dbName := fmt.Sprintf("uptime_contract_%d", time.Now().UnixNano())
+	db, err := NewConnection(uri, dbName)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Database().Drop(context.Background())
+		_ = db.Close()
+	})
+
+	return db
+}
+
+func TestServiceRepository_Contract(t *testing.T) {
+	servicetest.RunRepositoryContract(t, func(t *testing.T) service.Repository {
+		return NewServiceRepository(newTestDatabase(t))
+	})
+}
+
+func TestPolicyRepository_Contract(t *testing.T) {
+	alerttest.RunPolicyRepositoryContract(t, func(t *testing.T) alert.PolicyRepository {
+		return NewPolicyRepository(newTestDatabase(t))
+	})
+}
+
+func TestAlertRepository_Contract(t *testing.T) {
+	alerttest.RunAlertRepositoryContract(t, func(t *testing.T) alert.AlertRepository {
+		return NewAlertRepository(newTestDatabase(t))
+	})
+}
+
+func TestOutboxRepository_Contract(t *testing.T) {
+	alerttest.RunOutboxRepositoryContract(t, func(t *testing.T) alert.OutboxRepository {
+		return NewOutboxRepository(newTestDatabase(t))
+	})
+}
+
+func TestSilenceRepository_Contract(t *testing.T) {
+	alerttest.RunSilenceRepositoryContract(t, func(t *testing.T) alert.SilenceRepository {
+		return NewSilenceRepository(newTestDatabase(t))
+	})
+}
+
+func TestTemplateRepository_Contract(t *testing.T) {
+	alerttest.RunTemplateRepositoryContract(t, func(t *testing.T) alert.TemplateRepository {
+		return NewTemplateRepository(newTestDatabase(t))
+	})
+}
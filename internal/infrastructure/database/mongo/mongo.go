@@ -3,12 +3,18 @@ package mongo
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/tracing"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Interface defines the interface for database operations
@@ -17,6 +23,11 @@ type Interface interface {
 	StatusLogsCollection() *mongo.Collection
 	IncidentsCollection() *mongo.Collection
 	MaintenancesCollection() *mongo.Collection
+	AlertPoliciesCollection() *mongo.Collection
+	AlertsCollection() *mongo.Collection
+	OutboxCollection() *mongo.Collection
+	SilencesCollection() *mongo.Collection
+	TemplatesCollection() *mongo.Collection
 	Close() error
 	Ping(ctx context.Context) error
 	HealthCheck(ctx context.Context) error
@@ -35,15 +46,147 @@ type Database struct {
 	timeout time.Duration
 }
 
-func NewConnection(mongoURI, dbName string) (*Database, error) {
-	return NewConnectionWithTimeout(mongoURI, dbName, 10*time.Second)
+// ConnectionOption configures the MongoDB client
+type ConnectionOption func(*options.ClientOptions)
+
+// writeCommands are the commands that modify documents
+var writeCommands = map[string]bool{
+	"insert":        true,
+	"update":        true,
+	"delete":        true,
+	"findAndModify": true,
+}
+
+// WithWriteErrorHook calls hook with the command name whenever a write
+// command fails or reports write errors for some of its documents
+func WithWriteErrorHook(hook func(command string)) ConnectionOption {
+	return func(opts *options.ClientOptions) {
+		addCommandMonitor(opts, &event.CommandMonitor{
+			Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+				if writeCommands[e.CommandName] && writeErrorOf(e.Reply) != "" {
+					hook(e.CommandName)
+				}
+			},
+			Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+				if writeCommands[e.CommandName] {
+					hook(e.CommandName)
+				}
+			},
+		})
+	}
+}
+
+// WithTracing records each write command as a client span of tp, nested
+// under the span in the context of the operation
+func WithTracing(tp trace.TracerProvider) ConnectionOption {
+	tracer := tracing.Tracer(tp)
+	var spans sync.Map // request ID -> trace.Span
+
+	end := func(requestID int64, status string) {
+		value, ok := spans.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if status != "" {
+			span.SetStatus(codes.Error, status)
+		}
+		span.End()
+	}
+
+	return func(opts *options.ClientOptions) {
+		addCommandMonitor(opts, &event.CommandMonitor{
+			Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+				if !writeCommands[e.CommandName] {
+					return
+				}
+				collection, _ := e.Command.Lookup(e.CommandName).StringValueOK()
+				_, span := tracer.Start(ctx, e.CommandName+" "+collection,
+					trace.WithSpanKind(trace.SpanKindClient),
+					trace.WithAttributes(
+						attribute.String("db.system", "mongodb"),
+						attribute.String("db.namespace", e.DatabaseName),
+						attribute.String("db.collection.name", collection),
+						attribute.String("db.operation.name", e.CommandName),
+					),
+				)
+				spans.Store(e.RequestID, span)
+			},
+			Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+				end(e.RequestID, writeErrorOf(e.Reply))
+			},
+			Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+				failure := e.Failure
+				if failure == "" {
+					failure = "command failed"
+				}
+				end(e.RequestID, failure)
+			},
+		})
+	}
+}
+
+// writeErrorOf names the write error a successful reply carries, if any
+func writeErrorOf(reply bson.Raw) string {
+	for _, key := range []string{"writeErrors", "writeConcernError"} {
+		if _, err := reply.LookupErr(key); err == nil {
+			return key
+		}
+	}
+	return ""
 }
 
-func NewConnectionWithTimeout(mongoURI, dbName string, timeout time.Duration) (*Database, error) {
+// addCommandMonitor installs monitor on opts, keeping any monitor an earlier
+// option installed
+func addCommandMonitor(opts *options.ClientOptions, monitor *event.CommandMonitor) {
+	previous := opts.Monitor
+	if previous == nil {
+		opts.SetMonitor(monitor)
+		return
+	}
+
+	opts.SetMonitor(&event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			if previous.Started != nil {
+				previous.Started(ctx, e)
+			}
+			if monitor.Started != nil {
+				monitor.Started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			if previous.Succeeded != nil {
+				previous.Succeeded(ctx, e)
+			}
+			if monitor.Succeeded != nil {
+				monitor.Succeeded(ctx, e)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			if previous.Failed != nil {
+				previous.Failed(ctx, e)
+			}
+			if monitor.Failed != nil {
+				monitor.Failed(ctx, e)
+			}
+		},
+	})
+}
+
+func NewConnection(mongoURI, dbName string, opts ...ConnectionOption) (*Database, error) {
+	return NewConnectionWithTimeout(mongoURI, dbName, 10*time.Second, opts...)
+}
+
+func NewConnectionWithTimeout(mongoURI, dbName string, timeout time.Duration, opts ...ConnectionOption) (*Database, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	clientOpts := options.Client().ApplyURI(mongoURI)
+	for _, opt := range opts {
+		opt(clientOpts)
+	}
+
+	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
@@ -157,6 +300,10 @@ func (db *Database) EnsureIndexes(ctx context.Context) error {
 			Keys:    bson.D{{Key: "service_name", Value: 1}},
 			Options: options.Index().SetName("status_logs_service_name"),
 		},
+		{
+			Keys:    bson.D{{Key: "service_name", Value: 1}, {Key: "timestamp", Value: -1}},
+			Options: options.Index().SetName("status_logs_service_name_timestamp"),
+		},
 		{
 			Keys:    bson.D{{Key: "created_at", Value: 1}},
 			Options: options.Index().SetExpireAfterSeconds(2592000).SetName("status_logs_ttl"), // 30 days TTL
@@ -211,8 +358,52 @@ func (db *Database) EnsureIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to create maintenances indexes: %w", err)
 	}
 
+	// Alerts collection indexes
+	alertsIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "service_slug", Value: 1}, {Key: "state", Value: 1}},
+			Options: options.Index().SetName("alerts_service_state"),
+		},
+		{
+			Keys:    bson.D{{Key: "state", Value: 1}, {Key: "triggered_at", Value: -1}},
+			Options: options.Index().SetName("alerts_state_triggered"),
+		},
+	}
+
+	if _, err := db.AlertsCollection().Indexes().CreateMany(ctxWithTimeout, alertsIndexes); err != nil {
+		return fmt.Errorf("failed to create alerts indexes: %w", err)
+	}
+
+	// Notification outbox indexes; delivered entries expire after a week
+	outboxIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "state", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+			Options: options.Index().SetName("outbox_state_next_attempt"),
+		},
+		{
+			Keys:    bson.D{{Key: "delivered_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(604800).SetName("outbox_delivered_ttl"), // 7 days TTL
+		},
+	}
+
+	if _, err := db.OutboxCollection().Indexes().CreateMany(ctxWithTimeout, outboxIndexes); err != nil {
+		return fmt.Errorf("failed to create notification_outbox indexes: %w", err)
+	}
+
+	// Silences are looked up by the window containing the current time
+	silencesIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "ends_at", Value: 1}, {Key: "starts_at", Value: 1}},
+			Options: options.Index().SetName("silences_window"),
+		},
+	}
+
+	if _, err := db.SilencesCollection().Indexes().CreateMany(ctxWithTimeout, silencesIndexes); err != nil {
+		return fmt.Errorf("failed to create silences indexes: %w", err)
+	}
+
 	log.Info(ctx, "Database indexes created successfully", logger.Fields{
-		"collections": []string{"services", "status_logs", "incidents", "maintenances"},
+		"collections": []string{"services", "status_logs", "incidents", "maintenances", "alerts", "notification_outbox", "silences", "notification_templates"},
 	})
 
 	return nil
@@ -238,6 +429,26 @@ func (db *Database) MaintenancesCollection() *mongo.Collection {
 	return db.Database().Collection("maintenances")
 }
 
+func (db *Database) AlertPoliciesCollection() *mongo.Collection {
+	return db.Database().Collection("alert_policies")
+}
+
+func (db *Database) AlertsCollection() *mongo.Collection {
+	return db.Database().Collection("alerts")
+}
+
+func (db *Database) OutboxCollection() *mongo.Collection {
+	return db.Database().Collection("notification_outbox")
+}
+
+func (db *Database) SilencesCollection() *mongo.Collection {
+	return db.Database().Collection("silences")
+}
+
+func (db *Database) TemplatesCollection() *mongo.Collection {
+	return db.Database().Collection("notification_templates")
+}
+
 // Implement the database interface methods
 func (db *Database) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
 	return db.ServicesCollection().Find(ctx, filter, opts...)
@@ -1,9 +1,18 @@
 package mongo
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewConnection_InvalidURI(t *testing.T) {
@@ -54,3 +63,79 @@ func TestNewConnection_EmptyDatabase(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, db)
 }
+
+func TestWithWriteErrorHook(t *testing.T) {
+	var failed []string
+	opts := options.Client()
+	WithWriteErrorHook(func(command string) {
+		failed = append(failed, command)
+	})(opts)
+
+	ctx := context.Background()
+	reply := func(doc bson.D) bson.Raw {
+		raw, err := bson.Marshal(doc)
+		assert.NoError(t, err)
+		return raw
+	}
+	succeeded := func(command string, doc bson.D) *event.CommandSucceededEvent {
+		return &event.CommandSucceededEvent{
+			CommandFinishedEvent: event.CommandFinishedEvent{CommandName: command},
+			Reply:                reply(doc),
+		}
+	}
+
+	opts.Monitor.Succeeded(ctx, succeeded("insert", bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}}))
+	opts.Monitor.Succeeded(ctx, succeeded("insert", bson.D{{Key: "ok", Value: 1}, {Key: "writeErrors", Value: bson.A{bson.D{{Key: "code", Value: 11000}}}}}))
+	opts.Monitor.Succeeded(ctx, succeeded("update", bson.D{{Key: "ok", Value: 1}, {Key: "writeConcernError", Value: bson.D{{Key: "code", Value: 64}}}}))
+	opts.Monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "findAndModify"}})
+	opts.Monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find"}})
+
+	assert.Equal(t, []string{"insert", "update", "findAndModify"}, failed)
+}
+
+func TestWithTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var failed []string
+	opts := options.Client()
+	WithWriteErrorHook(func(command string) {
+		failed = append(failed, command)
+	})(opts)
+	WithTracing(tp)(opts)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "flush")
+	started := func(id int64, command string) *event.CommandStartedEvent {
+		raw, err := bson.Marshal(bson.D{{Key: command, Value: "status_logs"}})
+		require.NoError(t, err)
+		return &event.CommandStartedEvent{Command: raw, DatabaseName: "uptime", CommandName: command, RequestID: id}
+	}
+	finished := func(id int64, command string) event.CommandFinishedEvent {
+		return event.CommandFinishedEvent{CommandName: command, RequestID: id}
+	}
+	ok, err := bson.Marshal(bson.D{{Key: "ok", Value: 1}})
+	require.NoError(t, err)
+
+	opts.Monitor.Started(ctx, started(1, "insert"))
+	opts.Monitor.Started(ctx, started(2, "find"))
+	opts.Monitor.Started(ctx, started(3, "update"))
+	opts.Monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(1, "insert"), Reply: ok})
+	opts.Monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished(2, "find"), Reply: ok})
+	opts.Monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished(3, "update"), Failure: "connection reset"})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3, "reads are not traced")
+
+	assert.Equal(t, "insert status_logs", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String("db.collection.name", "status_logs"))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, "update status_logs", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "connection reset", spans[1].Status.Description)
+
+	// Both options observe the same commands
+	assert.Equal(t, []string{"update"}, failed)
+}
@@ -0,0 +1,225 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxRepository implements alert.OutboxRepository for MongoDB
+type OutboxRepository struct {
+	db Interface
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db Interface) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// Enqueue stores new pending deliveries
+func (r *OutboxRepository) Enqueue(ctx context.Context, deliveries []*alert.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(deliveries))
+	for i, d := range deliveries {
+		d.ID = ""
+		d.State = alert.DeliveryPending
+		docs[i] = d
+	}
+
+	result, err := r.db.OutboxCollection().InsertMany(ctx, docs)
+	if err != nil {
+		return errors.NewWithCause("failed to enqueue deliveries", errors.ErrorKindInternal, err)
+	}
+
+	for i, id := range result.InsertedIDs {
+		if objectID, ok := id.(primitive.ObjectID); ok {
+			deliveries[i].ID = objectID.Hex()
+		}
+	}
+
+	return nil
+}
+
+// Claim leases up to limit due pending deliveries, oldest due first. Each
+// delivery is claimed with its own atomic update so concurrent dispatchers
+// never receive the same one.
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*alert.Delivery, error) {
+	filter := bson.M{
+		"state":           alert.DeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{"next_attempt_at": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	claimed := []*alert.Delivery{}
+	for len(claimed) < limit {
+		var d alert.Delivery
+		err := r.db.OutboxCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&d)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return claimed, errors.NewWithCause("failed to claim deliveries", errors.ErrorKindInternal, err)
+		}
+		claimed = append(claimed, &d)
+	}
+
+	return claimed, nil
+}
+
+// Complete marks a delivery as delivered
+func (r *OutboxRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	return r.updateOne(ctx, id, bson.M{"$set": bson.M{
+		"state":        alert.DeliveryDelivered,
+		"delivered_at": at,
+	}})
+}
+
+// Fail records a failed attempt and schedules the next one
+func (r *OutboxRepository) Fail(ctx context.Context, id, lastError string, retryAt time.Time) error {
+	return r.updateOne(ctx, id, bson.M{"$set": bson.M{
+		"last_error":      lastError,
+		"next_attempt_at": retryAt,
+	}})
+}
+
+// Bury moves a delivery to the dead-letter state
+func (r *OutboxRepository) Bury(ctx context.Context, id, lastError string) error {
+	return r.updateOne(ctx, id, bson.M{"$set": bson.M{
+		"state":      alert.DeliveryDead,
+		"last_error": lastError,
+	}})
+}
+
+// Retry returns a dead-lettered delivery to the queue with fresh attempts
+func (r *OutboxRepository) Retry(ctx context.Context, id string, at time.Time) (*alert.Delivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid delivery ID format")
+	}
+
+	filter := bson.M{"_id": objectID, "state": alert.DeliveryDead}
+	update := bson.M{"$set": bson.M{
+		"state":           alert.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": at,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var d alert.Delivery
+	err = r.db.OutboxCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&d)
+	if err == nil {
+		return &d, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, errors.NewWithCause("failed to retry delivery", errors.ErrorKindInternal, err)
+	}
+
+	count, err := r.db.OutboxCollection().CountDocuments(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find delivery", errors.ErrorKindInternal, err)
+	}
+	if count == 0 {
+		return nil, alert.ErrDeliveryNotFound
+	}
+	return nil, alert.ErrDeliveryNotDead
+}
+
+// List retrieves up to limit deliveries, newest first, optionally filtered by state
+func (r *OutboxRepository) List(ctx context.Context, state string, limit int) ([]*alert.Delivery, error) {
+	filter := bson.M{}
+	if state != "" {
+		filter["state"] = state
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.db.OutboxCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find deliveries", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	deliveries := []*alert.Delivery{}
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, errors.NewWithCause("failed to decode deliveries", errors.ErrorKindInternal, err)
+	}
+
+	return deliveries, nil
+}
+
+// Count returns the number of deliveries in each state
+func (r *OutboxRepository) Count(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$state", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.db.OutboxCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to count deliveries", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	var groups []struct {
+		State string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, errors.NewWithCause("failed to decode delivery counts", errors.ErrorKindInternal, err)
+	}
+
+	counts := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		counts[group.State] = group.Count
+	}
+
+	return counts, nil
+}
+
+func (r *OutboxRepository) updateOne(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.NewValidationError("invalid delivery ID format")
+	}
+
+	result, err := r.db.OutboxCollection().UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return errors.NewWithCause("failed to update delivery", errors.ErrorKindInternal, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return alert.ErrDeliveryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PolicyRepository implements alert.PolicyRepository for MongoDB
+type PolicyRepository struct {
+	db Interface
+}
+
+// NewPolicyRepository creates a new policy repository
+func NewPolicyRepository(db Interface) *PolicyRepository {
+	return &PolicyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new policy
+func (r *PolicyRepository) Create(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	now := time.Now().UTC()
+	policy.ID = ""
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	result, err := r.db.AlertPoliciesCollection().InsertOne(ctx, policy)
+	if err != nil {
+		return errors.NewWithCause("failed to create policy", errors.ErrorKindInternal, err)
+	}
+
+	if objectID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		policy.ID = objectID.Hex()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a policy by its ID
+func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*alert.Policy, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid policy ID format")
+	}
+
+	var policy alert.Policy
+	err = r.db.AlertPoliciesCollection().FindOne(ctx, bson.M{"_id": objectID}).Decode(&policy)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, alert.ErrPolicyNotFound
+		}
+		return nil, errors.NewWithCause("failed to find policy", errors.ErrorKindInternal, err)
+	}
+
+	return &policy, nil
+}
+
+// GetAll retrieves all policies in creation order
+func (r *PolicyRepository) GetAll(ctx context.Context) ([]*alert.Policy, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.AlertPoliciesCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find policies", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	policies := []*alert.Policy{}
+	if err = cursor.All(ctx, &policies); err != nil {
+		return nil, errors.NewWithCause("failed to decode policies", errors.ErrorKindInternal, err)
+	}
+
+	return policies, nil
+}
+
+// Update replaces an existing policy, keeping its creation time
+func (r *PolicyRepository) Update(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(policy.ID)
+	if err != nil {
+		return errors.NewValidationError("invalid policy ID format")
+	}
+
+	// Every field is set explicitly so cleared lists and quiet hours are
+	// stored as null rather than skipped by omitempty
+	update := bson.M{"$set": bson.M{
+		"name":            policy.Name,
+		"enabled":         policy.Enabled,
+		"match":           policy.Match,
+		"severities":      policy.Severities,
+		"channels":        policy.Channels,
+		"repeat_interval": policy.RepeatInterval,
+		"quiet_hours":     policy.QuietHours,
+		"escalation":      policy.Escalation,
+		"updated_at":      time.Now().UTC(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated alert.Policy
+	err = r.db.AlertPoliciesCollection().FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return alert.ErrPolicyNotFound
+		}
+		return errors.NewWithCause("failed to update policy", errors.ErrorKindInternal, err)
+	}
+
+	policy.CreatedAt = updated.CreatedAt
+	policy.UpdatedAt = updated.UpdatedAt
+
+	return nil
+}
+
+// Delete deletes a policy by its ID
+func (r *PolicyRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.NewValidationError("invalid policy ID format")
+	}
+
+	result, err := r.db.AlertPoliciesCollection().DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return errors.NewWithCause("failed to delete policy", errors.ErrorKindInternal, err)
+	}
+
+	if result.DeletedCount == 0 {
+		return alert.ErrPolicyNotFound
+	}
+
+	return nil
+}
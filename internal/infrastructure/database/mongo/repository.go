@@ -2,6 +2,8 @@ package mongo
 
 import (
 	"context"
+	stderrors "errors"
+	"time"
 
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
 	"github.com/sukhera/uptime-monitor/internal/shared/errors"
@@ -10,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 // ServiceRepository implements the service repository interface for MongoDB
@@ -30,13 +33,25 @@ func (r *ServiceRepository) Create(ctx context.Context, svc *service.Service) er
 		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
 	}
 
+	now := time.Now().UTC()
+	if svc.CreatedAt.IsZero() {
+		svc.CreatedAt = now
+	}
+	if svc.UpdatedAt.IsZero() {
+		svc.UpdatedAt = now
+	}
+
 	result, err := r.db.ServicesCollection().InsertOne(ctx, svc)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return service.ErrServiceAlreadyExists
+		}
 		return errors.NewWithCause("failed to create service", errors.ErrorKindInternal, err)
 	}
 
-	// Note: Service struct doesn't have an ID field, so we don't set it
-	_ = result
+	if objectID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		svc.ID = objectID.Hex()
+	}
 
 	return nil
 }
@@ -78,7 +93,8 @@ func (r *ServiceRepository) GetBySlug(ctx context.Context, slug string) (*servic
 
 // GetAll retrieves all services
 func (r *ServiceRepository) GetAll(ctx context.Context) ([]*service.Service, error) {
-	cursor, err := r.db.ServicesCollection().Find(ctx, bson.M{})
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.ServicesCollection().Find(ctx, bson.M{}, opts)
 	if err != nil {
 		return nil, errors.NewWithCause("failed to find services", errors.ErrorKindInternal, err)
 	}
@@ -101,7 +117,8 @@ func (r *ServiceRepository) GetAll(ctx context.Context) ([]*service.Service, err
 // GetEnabled retrieves all enabled services
 func (r *ServiceRepository) GetEnabled(ctx context.Context) ([]*service.Service, error) {
 	filter := bson.M{"enabled": true}
-	cursor, err := r.db.ServicesCollection().Find(ctx, filter)
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.ServicesCollection().Find(ctx, filter, opts)
 	if err != nil {
 		return nil, errors.NewWithCause("failed to find enabled services", errors.ErrorKindInternal, err)
 	}
@@ -127,19 +144,33 @@ func (r *ServiceRepository) Update(ctx context.Context, svc *service.Service) er
 		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
 	}
 
-	// For now, we'll update by slug since Service doesn't have an ID field
+	// Update by slug. _id and created_at are never part of the $set, and the
+	// update time is stamped here, not taken from the caller.
+	now := time.Now().UTC()
 	filter := bson.M{"slug": svc.Slug}
-	update := bson.M{"$set": svc}
-
-	result, err := r.db.ServicesCollection().UpdateOne(ctx, filter, update)
+	update := bson.M{"$set": bson.M{
+		"name":            svc.Name,
+		"url":             svc.URL,
+		"headers":         svc.Headers,
+		"expected_status": svc.ExpectedStatus,
+		"enabled":         svc.Enabled,
+		"group":           svc.Group,
+		"tags":            svc.Tags,
+		"updated_at":      now,
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated service.Service
+	err := r.db.ServicesCollection().FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return errors.NewNotFoundError("service not found")
+	}
 	if err != nil {
 		return errors.NewWithCause("failed to update service", errors.ErrorKindInternal, err)
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.NewNotFoundError("service not found")
-	}
-
+	svc.CreatedAt = updated.CreatedAt
+	svc.UpdatedAt = now
 	return nil
 }
 
@@ -176,14 +207,76 @@ func (r *ServiceRepository) SaveStatusLog(ctx context.Context, log *service.Stat
 	return nil
 }
 
-// GetLatestStatus retrieves the latest status for all services
+// SaveStatusLogs saves a batch of status log entries with a single unordered InsertMany
+func (r *ServiceRepository) SaveStatusLogs(ctx context.Context, logs []*service.StatusLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(logs))
+	for _, log := range logs {
+		documents = append(documents, log)
+	}
+
+	_, err := r.db.StatusLogsCollection().InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		// Rejected documents will not succeed on retry, and the rest of the
+		// batch has already been written, so report only them as rejected
+		var bulkErr mongo.BulkWriteException
+		if stderrors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil && len(bulkErr.WriteErrors) > 0 {
+			return &service.RejectedLogsError{Rejected: len(bulkErr.WriteErrors), Err: err}
+		}
+		if notSent(err) {
+			return &service.UnsentLogsError{Err: err}
+		}
+		return errors.NewWithCause("failed to create status logs", errors.ErrorKindInternal, err)
+	}
+
+	return nil
+}
+
+// notSent reports whether a write failed before it reached a server, so
+// retrying it cannot store documents twice. Other failures, such as
+// network timeouts, may happen after the server applied the write.
+func notSent(err error) bool {
+	var selectionErr topology.ServerSelectionError
+	if stderrors.As(err, &selectionErr) || stderrors.Is(err, mongo.ErrClientDisconnected) {
+		return true
+	}
+
+	var labeled mongo.LabeledError
+	return stderrors.As(err, &labeled) && labeled.HasErrorLabel("NoWritesPerformed")
+}
+
+// GetLatestStatus retrieves one current status per enabled service
 func (r *ServiceRepository) GetLatestStatus(ctx context.Context) ([]*service.ServiceStatus, error) {
-	// This is a simplified implementation
-	// In a real application, you might want to aggregate the latest status for each service
-	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetLimit(100)
-	cursor, err := r.db.StatusLogsCollection().Find(ctx, bson.M{}, opts)
+	services, err := r.GetEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+
+	// Pick the newest log per service; the sort matches the
+	// status_logs_service_name_timestamp index so $first is cheap
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"service_name": bson.M{"$in": names}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "service_name", Value: 1}, {Key: "timestamp", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$service_name"},
+			{Key: "status", Value: bson.M{"$first": "$status"}},
+			{Key: "latency_ms", Value: bson.M{"$first": "$latency_ms"}},
+			{Key: "error", Value: bson.M{"$first": "$error"}},
+			{Key: "timestamp", Value: bson.M{"$first": "$timestamp"}},
+		}}},
+	}
+
+	cursor, err := r.db.StatusLogsCollection().Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, errors.NewWithCause("failed to find latest status logs", errors.ErrorKindInternal, err)
+		return nil, errors.NewWithCause("failed to aggregate latest status logs", errors.ErrorKindInternal, err)
 	}
 	defer func() {
 		if err := cursor.Close(ctx); err != nil {
@@ -193,31 +286,61 @@ func (r *ServiceRepository) GetLatestStatus(ctx context.Context) ([]*service.Ser
 		}
 	}()
 
-	var statusLogs []service.StatusLog
-	if err = cursor.All(ctx, &statusLogs); err != nil {
+	var latest []latestStatusLog
+	if err = cursor.All(ctx, &latest); err != nil {
 		return nil, errors.NewWithCause("failed to decode status logs", errors.ErrorKindInternal, err)
 	}
 
-	// Convert to ServiceStatus (simplified)
-	var statuses []*service.ServiceStatus
-	for _, log := range statusLogs {
-		status := &service.ServiceStatus{
-			Name:      log.ServiceName,
+	byName := make(map[string]latestStatusLog, len(latest))
+	for _, log := range latest {
+		byName[log.ServiceName] = log
+	}
+
+	statuses := make([]*service.ServiceStatus, 0, len(services))
+	for _, svc := range services {
+		log, ok := byName[svc.Name]
+		if !ok {
+			statuses = append(statuses, &service.ServiceStatus{
+				Name:   svc.Name,
+				Status: service.StatusUnknown,
+			})
+			continue
+		}
+
+		statuses = append(statuses, &service.ServiceStatus{
+			Name:      svc.Name,
 			Status:    log.Status,
 			Latency:   log.Latency,
 			UpdatedAt: log.Timestamp,
 			Error:     log.Error,
-		}
-		statuses = append(statuses, status)
+		})
 	}
 
 	return statuses, nil
 }
 
-// GetStatusHistory retrieves status history for a service
+// latestStatusLog is the shape of a $group result in GetLatestStatus
+type latestStatusLog struct {
+	ServiceName string    `bson:"_id"`
+	Status      string    `bson:"status"`
+	Latency     int64     `bson:"latency_ms"`
+	Error       string    `bson:"error"`
+	Timestamp   time.Time `bson:"timestamp"`
+}
+
+// Ping verifies the database is reachable
+func (r *ServiceRepository) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// GetStatusHistory retrieves status history for a service, newest first.
+// A limit of zero or less returns the full history.
 func (r *ServiceRepository) GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*service.StatusLog, error) {
 	filter := bson.M{"service_name": serviceName}
-	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetLimit(int64(limit))
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
 
 	cursor, err := r.db.StatusLogsCollection().Find(ctx, filter, opts)
 	if err != nil {
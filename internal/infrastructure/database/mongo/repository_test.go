@@ -1,12 +1,15 @@
 package mongo
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/sukhera/uptime-monitor/internal/domain/service"
 	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 func TestService_Validate(t *testing.T) {
@@ -261,3 +264,23 @@ func TestErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestNotSent(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "no server selected", err: topology.ServerSelectionError{Wrapped: context.DeadlineExceeded}, expected: true},
+		{name: "client disconnected", err: mongo.ErrClientDisconnected, expected: true},
+		{name: "no writes performed", err: mongo.CommandError{Labels: []string{"NoWritesPerformed"}}, expected: true},
+		{name: "timeout", err: context.DeadlineExceeded, expected: false},
+		{name: "write concern", err: mongo.BulkWriteException{WriteConcernError: &mongo.WriteConcernError{Code: 64}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, notSent(tt.err))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SilenceRepository implements alert.SilenceRepository for MongoDB
+type SilenceRepository struct {
+	db Interface
+}
+
+// NewSilenceRepository creates a new silence repository
+func NewSilenceRepository(db Interface) *SilenceRepository {
+	return &SilenceRepository{
+		db: db,
+	}
+}
+
+// Create validates and stores a new silence
+func (r *SilenceRepository) Create(ctx context.Context, silence *alert.Silence) error {
+	if err := silence.Validate(); err != nil {
+		return errors.NewWithCause("invalid silence", errors.ErrorKindValidation, err)
+	}
+
+	silence.ID = ""
+	silence.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.SilencesCollection().InsertOne(ctx, silence)
+	if err != nil {
+		return errors.NewWithCause("failed to create silence", errors.ErrorKindInternal, err)
+	}
+
+	if objectID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		silence.ID = objectID.Hex()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a silence by its ID
+func (r *SilenceRepository) GetByID(ctx context.Context, id string) (*alert.Silence, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid silence ID format")
+	}
+
+	var silence alert.Silence
+	err = r.db.SilencesCollection().FindOne(ctx, bson.M{"_id": objectID}).Decode(&silence)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, alert.ErrSilenceNotFound
+		}
+		return nil, errors.NewWithCause("failed to find silence", errors.ErrorKindInternal, err)
+	}
+
+	return &silence, nil
+}
+
+// GetAll retrieves all silences in creation order
+func (r *SilenceRepository) GetAll(ctx context.Context) ([]*alert.Silence, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// GetActive retrieves the silences whose window contains at
+func (r *SilenceRepository) GetActive(ctx context.Context, at time.Time) ([]*alert.Silence, error) {
+	return r.find(ctx, bson.M{
+		"starts_at": bson.M{"$lte": at},
+		"ends_at":   bson.M{"$gt": at},
+	})
+}
+
+// Expire ends a silence at the given time and returns it
+func (r *SilenceRepository) Expire(ctx context.Context, id string, at time.Time) (*alert.Silence, error) {
+	silence, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := silence.Expire(at); err != nil {
+		return nil, err
+	}
+
+	// Only a silence that is still running is updated, so concurrent
+	// expiries cannot move its end time
+	objectID, _ := primitive.ObjectIDFromHex(id)
+	filter := bson.M{"_id": objectID, "ends_at": bson.M{"$gt": at}}
+	update := bson.M{"$set": bson.M{
+		"starts_at": silence.StartsAt,
+		"ends_at":   silence.EndsAt,
+	}}
+
+	result, err := r.db.SilencesCollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to expire silence", errors.ErrorKindInternal, err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, alert.ErrSilenceExpired
+	}
+
+	return silence, nil
+}
+
+func (r *SilenceRepository) find(ctx context.Context, filter bson.M) ([]*alert.Silence, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.SilencesCollection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find silences", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	silences := []*alert.Silence{}
+	if err = cursor.All(ctx, &silences); err != nil {
+		return nil, errors.NewWithCause("failed to decode silences", errors.ErrorKindInternal, err)
+	}
+
+	return silences, nil
+}
@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TemplateRepository implements alert.TemplateRepository for MongoDB.
+// Templates are keyed by channel name.
+type TemplateRepository struct {
+	db Interface
+}
+
+// NewTemplateRepository creates a new template repository
+func NewTemplateRepository(db Interface) *TemplateRepository {
+	return &TemplateRepository{
+		db: db,
+	}
+}
+
+// Save validates and stores the template of a channel, replacing any existing one
+func (r *TemplateRepository) Save(ctx context.Context, template *alert.Template) error {
+	if err := template.Validate(); err != nil {
+		return errors.NewWithCause("invalid template", errors.ErrorKindValidation, err)
+	}
+
+	template.UpdatedAt = time.Now().UTC()
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.db.TemplatesCollection().ReplaceOne(ctx, bson.M{"_id": template.Channel}, template, opts); err != nil {
+		return errors.NewWithCause("failed to save template", errors.ErrorKindInternal, err)
+	}
+
+	return nil
+}
+
+// Get retrieves the template of a channel
+func (r *TemplateRepository) Get(ctx context.Context, channel string) (*alert.Template, error) {
+	var template alert.Template
+	err := r.db.TemplatesCollection().FindOne(ctx, bson.M{"_id": channel}).Decode(&template)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, alert.ErrTemplateNotFound
+		}
+		return nil, errors.NewWithCause("failed to find template", errors.ErrorKindInternal, err)
+	}
+
+	return &template, nil
+}
+
+// GetAll retrieves all templates ordered by channel
+func (r *TemplateRepository) GetAll(ctx context.Context) ([]*alert.Template, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.db.TemplatesCollection().Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find templates", errors.ErrorKindInternal, err)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			// Log error but don't fail the operation
+			log := logger.Get()
+			log.Error(ctx, "Error closing cursor", err, nil)
+		}
+	}()
+
+	templates := []*alert.Template{}
+	if err = cursor.All(ctx, &templates); err != nil {
+		return nil, errors.NewWithCause("failed to decode templates", errors.ErrorKindInternal, err)
+	}
+
+	return templates, nil
+}
+
+// Delete deletes the template of a channel
+func (r *TemplateRepository) Delete(ctx context.Context, channel string) error {
+	result, err := r.db.TemplatesCollection().DeleteOne(ctx, bson.M{"_id": channel})
+	if err != nil {
+		return errors.NewWithCause("failed to delete template", errors.ErrorKindInternal, err)
+	}
+	if result.DeletedCount == 0 {
+		return alert.ErrTemplateNotFound
+	}
+
+	return nil
+}
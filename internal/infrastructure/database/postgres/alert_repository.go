@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"strconv"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const alertColumns = "id, service_name, service_slug, severity, state, error, escalations, announced, triggered_at, acknowledged_at, acknowledged_by, resolved_at"
+
+// AlertRepository implements alert.AlertRepository for PostgreSQL
+type AlertRepository struct {
+	db Interface
+}
+
+// NewAlertRepository creates a new alert repository
+func NewAlertRepository(db Interface) *AlertRepository {
+	return &AlertRepository{
+		db: db,
+	}
+}
+
+// Create stores a new alert
+func (r *AlertRepository) Create(ctx context.Context, a *alert.Alert) error {
+	escalations := a.Escalations
+	if escalations == nil {
+		escalations = map[string]int{}
+	}
+	encoded, err := json.Marshal(escalations)
+	if err != nil {
+		return errors.NewWithCause("failed to encode alert escalations", errors.ErrorKindInternal, err)
+	}
+	announced, err := json.Marshal(append([]string{}, a.Announced...))
+	if err != nil {
+		return errors.NewWithCause("failed to encode alert announcements", errors.ErrorKindInternal, err)
+	}
+
+	var id int64
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO alerts (service_name, service_slug, severity, state, error, escalations, announced, triggered_at, acknowledged_at, acknowledged_by, resolved_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 RETURNING id`,
+		a.ServiceName, a.ServiceSlug, a.Severity, a.State, a.Error, encoded, announced, a.TriggeredAt,
+		a.AcknowledgedAt, a.AcknowledgedBy, a.ResolvedAt,
+	).Scan(&id)
+	if err != nil {
+		return errors.NewWithCause("failed to create alert", errors.ErrorKindInternal, err)
+	}
+
+	a.ID = strconv.FormatInt(id, 10)
+
+	return nil
+}
+
+// GetByID retrieves an alert by its ID
+func (r *AlertRepository) GetByID(ctx context.Context, id string) (*alert.Alert, error) {
+	alertID, err := parseRowID(id, "alert", alert.ErrAlertNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+alertColumns+" FROM alerts WHERE id = $1", alertID)
+	return scanAlert(row)
+}
+
+// GetOpen retrieves the most recent unresolved alert of a service
+func (r *AlertRepository) GetOpen(ctx context.Context, serviceSlug string) (*alert.Alert, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+alertColumns+` FROM alerts
+		 WHERE service_slug = $1 AND state <> $2
+		 ORDER BY triggered_at DESC, id DESC
+		 LIMIT 1`,
+		serviceSlug, alert.StateResolved,
+	)
+	return scanAlert(row)
+}
+
+// List retrieves up to limit alerts, newest first, optionally filtered by state
+func (r *AlertRepository) List(ctx context.Context, state string, limit int) ([]*alert.Alert, error) {
+	// LIMIT NULL is equivalent to LIMIT ALL
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+alertColumns+` FROM alerts
+		 WHERE $1 = '' OR state = $1
+		 ORDER BY triggered_at DESC, id DESC
+		 LIMIT $2`,
+		state, limitArg,
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find alerts", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	alerts := []*alert.Alert{}
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode alerts", errors.ErrorKindInternal, err)
+	}
+
+	return alerts, nil
+}
+
+// Acknowledge marks a triggered alert as acknowledged and returns it. The
+// state is checked in the update so a concurrent resolve always wins.
+func (r *AlertRepository) Acknowledge(ctx context.Context, id, by string, at time.Time) (*alert.Alert, error) {
+	alertID, err := parseRowID(id, "alert", alert.ErrAlertNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE alerts
+		 SET state = $1, acknowledged_at = $2, acknowledged_by = $3
+		 WHERE id = $4 AND state = $5
+		 RETURNING `+alertColumns,
+		alert.StateAcknowledged, at, by, alertID, alert.StateTriggered,
+	)
+	acked, err := scanAlert(row)
+	if err == nil {
+		return acked, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// Not triggered: the alert is missing, already acknowledged or resolved
+	existing, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == alert.StateResolved {
+		return nil, alert.ErrAlertResolved
+	}
+	return existing, nil
+}
+
+// Resolve closes an alert
+func (r *AlertRepository) Resolve(ctx context.Context, id string, at time.Time) error {
+	return r.updateOne(ctx, id, "UPDATE alerts SET state = $2, resolved_at = $3 WHERE id = $1",
+		alert.StateResolved, at)
+}
+
+// SetEscalated records how many escalation steps of a policy were sent
+func (r *AlertRepository) SetEscalated(ctx context.Context, id, policyID string, steps int) error {
+	return r.updateOne(ctx, id, "UPDATE alerts SET escalations = escalations || jsonb_build_object($2::text, $3::int) WHERE id = $1",
+		policyID, steps)
+}
+
+// SetAnnounced records that the failure was sent on a policy's channels
+func (r *AlertRepository) SetAnnounced(ctx context.Context, id, policyID string) error {
+	return r.updateOne(ctx, id, `UPDATE alerts
+		 SET announced = CASE WHEN announced @> jsonb_build_array($2::text) THEN announced ELSE announced || jsonb_build_array($2::text) END
+		 WHERE id = $1`,
+		policyID)
+}
+
+// updateOne runs an update of the alert with the given ID, passed as $1
+func (r *AlertRepository) updateOne(ctx context.Context, id, query string, args ...interface{}) error {
+	alertID, err := parseRowID(id, "alert", alert.ErrAlertNotFound)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, append([]interface{}{alertID}, args...)...)
+	if err != nil {
+		return errors.NewWithCause("failed to update alert", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCause("failed to update alert", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return alert.ErrAlertNotFound
+	}
+
+	return nil
+}
+
+// scanAlert decodes a single alert row
+func scanAlert(row rowScanner) (*alert.Alert, error) {
+	var (
+		a              alert.Alert
+		id             int64
+		escalations    []byte
+		announced      []byte
+		acknowledgedAt sql.NullTime
+		resolvedAt     sql.NullTime
+	)
+
+	err := row.Scan(&id, &a.ServiceName, &a.ServiceSlug, &a.Severity, &a.State, &a.Error, &escalations, &announced,
+		&a.TriggeredAt, &acknowledgedAt, &a.AcknowledgedBy, &resolvedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, alert.ErrAlertNotFound
+		}
+		return nil, errors.NewWithCause("failed to find alert", errors.ErrorKindInternal, err)
+	}
+
+	if err := decodeJSON(escalations, &a.Escalations, "alert escalations"); err != nil {
+		return nil, err
+	}
+	if len(a.Escalations) == 0 {
+		a.Escalations = nil
+	}
+	if err := decodeJSON(announced, &a.Announced, "alert announcements"); err != nil {
+		return nil, err
+	}
+	if len(a.Announced) == 0 {
+		a.Announced = nil
+	}
+	if acknowledgedAt.Valid {
+		a.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if resolvedAt.Valid {
+		a.ResolvedAt = &resolvedAt.Time
+	}
+	a.ID = strconv.FormatInt(id, 10)
+
+	return &a, nil
+}
@@ -0,0 +1,32 @@
+-- Services being monitored. The slug is the stable public identifier and
+-- must be unique, mirroring the services_slug_unique index in MongoDB.
+CREATE TABLE IF NOT EXISTS services (
+    id              BIGSERIAL PRIMARY KEY,
+    name            TEXT        NOT NULL,
+    slug            TEXT        NOT NULL,
+    url             TEXT        NOT NULL,
+    headers         JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    expected_status INTEGER     NOT NULL,
+    enabled         BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS services_slug_unique ON services (slug);
+CREATE INDEX IF NOT EXISTS services_name ON services (name);
+CREATE INDEX IF NOT EXISTS services_enabled ON services (enabled);
+
+-- Health check results, one row per probe.
+CREATE TABLE IF NOT EXISTS status_logs (
+    id           BIGSERIAL PRIMARY KEY,
+    service_name TEXT        NOT NULL,
+    status       TEXT        NOT NULL,
+    latency_ms   BIGINT      NOT NULL DEFAULT 0,
+    status_code  INTEGER     NOT NULL DEFAULT 0,
+    error        TEXT        NOT NULL DEFAULT '',
+    timestamp    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS status_logs_service_name_timestamp ON status_logs (service_name, timestamp DESC);
+CREATE INDEX IF NOT EXISTS status_logs_timestamp ON status_logs (timestamp);
+CREATE INDEX IF NOT EXISTS status_logs_status ON status_logs (status);
@@ -0,0 +1,6 @@
+-- Ownership metadata used by alert routing policies. "group" is a reserved
+-- word, so the column is named service_group.
+ALTER TABLE services ADD COLUMN IF NOT EXISTS service_group TEXT  NOT NULL DEFAULT '';
+ALTER TABLE services ADD COLUMN IF NOT EXISTS tags          JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+CREATE INDEX IF NOT EXISTS services_service_group ON services (service_group);
@@ -0,0 +1,75 @@
+-- Notification routing policies. Matchers, channel lists, quiet hours and
+-- escalation steps are stored as JSONB in their API encoding; the repeat
+-- interval is in nanoseconds.
+CREATE TABLE IF NOT EXISTS alert_policies (
+    id              BIGSERIAL PRIMARY KEY,
+    name            TEXT        NOT NULL,
+    enabled         BOOLEAN     NOT NULL DEFAULT TRUE,
+    match           JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    severities      JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    channels        JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    repeat_interval BIGINT      NOT NULL DEFAULT 0,
+    quiet_hours     JSONB       NOT NULL DEFAULT 'null'::jsonb,
+    escalation      JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Alerts raised for failing services, with the escalation steps sent per
+-- policy and the policies the failure was announced on.
+CREATE TABLE IF NOT EXISTS alerts (
+    id              BIGSERIAL PRIMARY KEY,
+    service_name    TEXT        NOT NULL,
+    service_slug    TEXT        NOT NULL,
+    severity        TEXT        NOT NULL,
+    state           TEXT        NOT NULL,
+    error           TEXT        NOT NULL DEFAULT '',
+    escalations     JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    announced       JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    triggered_at    TIMESTAMPTZ NOT NULL,
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by TEXT        NOT NULL DEFAULT '',
+    resolved_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS alerts_service_slug_state ON alerts (service_slug, state, triggered_at DESC);
+CREATE INDEX IF NOT EXISTS alerts_state_triggered_at ON alerts (state, triggered_at DESC);
+
+-- Notification outbox: one row per channel delivery, claimed with a lease
+-- by moving next_attempt_at forward.
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    channel         TEXT        NOT NULL,
+    notification    JSONB       NOT NULL,
+    state           TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS notification_outbox_due ON notification_outbox (state, next_attempt_at, id);
+CREATE INDEX IF NOT EXISTS notification_outbox_created_at ON notification_outbox (created_at DESC);
+
+-- Silences muting notifications of matching services or channels.
+CREATE TABLE IF NOT EXISTS silences (
+    id         BIGSERIAL PRIMARY KEY,
+    match      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    channels   JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    created_by TEXT        NOT NULL,
+    comment    TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS silences_window ON silences (starts_at, ends_at);
+
+-- Channel message templates, keyed by channel name.
+CREATE TABLE IF NOT EXISTS message_templates (
+    channel    TEXT PRIMARY KEY,
+    source     TEXT        NOT NULL,
+    updated_by TEXT        NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
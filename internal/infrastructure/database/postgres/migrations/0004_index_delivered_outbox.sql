@@ -0,0 +1,3 @@
+-- Delivered outbox rows are purged once they are older than the retention
+-- period, matching the TTL index of the MongoDB outbox.
+CREATE INDEX IF NOT EXISTS notification_outbox_delivered_at ON notification_outbox (state, delivered_at);
@@ -0,0 +1,307 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const deliveryColumns = "id, channel, notification, state, attempts, next_attempt_at, last_error, created_at, delivered_at"
+
+// OutboxRepository implements alert.OutboxRepository for PostgreSQL
+type OutboxRepository struct {
+	db Interface
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db Interface) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// Enqueue stores new pending deliveries with a single multi-row insert, so
+// either every channel of an event is queued or none is
+func (r *OutboxRepository) Enqueue(ctx context.Context, deliveries []*alert.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	query.WriteString(`INSERT INTO notification_outbox (channel, notification, state, attempts, next_attempt_at, last_error, created_at, delivered_at) VALUES `)
+
+	args := make([]interface{}, 0, len(deliveries)*8)
+	for i, d := range deliveries {
+		notification, err := json.Marshal(d.Notification)
+		if err != nil {
+			return errors.NewWithCause("failed to encode delivery notification", errors.ErrorKindInternal, err)
+		}
+
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8)
+		args = append(args, d.Channel, notification, alert.DeliveryPending, d.Attempts, d.NextAttemptAt, d.LastError,
+			d.CreatedAt, d.DeliveredAt)
+	}
+	query.WriteString(" RETURNING id")
+
+	rows, err := r.db.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return errors.NewWithCause("failed to enqueue deliveries", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	ids := make([]int64, 0, len(deliveries))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return errors.NewWithCause("failed to enqueue deliveries", errors.ErrorKindInternal, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewWithCause("failed to enqueue deliveries", errors.ErrorKindInternal, err)
+	}
+	if len(ids) != len(deliveries) {
+		return errors.NewInternalError("failed to enqueue deliveries: unexpected number of IDs returned")
+	}
+
+	// IDs are drawn from the sequence in VALUES order, while RETURNING does
+	// not promise any order
+	slices.Sort(ids)
+	for i, d := range deliveries {
+		d.ID = strconv.FormatInt(ids[i], 10)
+		d.State = alert.DeliveryPending
+	}
+
+	return nil
+}
+
+// Claim leases up to limit due pending deliveries, oldest due first. Rows
+// are locked with SKIP LOCKED so concurrent dispatchers never receive the
+// same delivery.
+func (r *OutboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*alert.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`WITH due AS (
+			SELECT id, next_attempt_at
+			FROM notification_outbox
+			WHERE state = $1 AND next_attempt_at <= $2
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		 ), claimed AS (
+			UPDATE notification_outbox o
+			SET next_attempt_at = $4, attempts = o.attempts + 1
+			FROM due
+			WHERE o.id = due.id
+			RETURNING o.*, due.next_attempt_at AS due_at
+		 )
+		 SELECT `+deliveryColumns+` FROM claimed ORDER BY due_at, id`,
+		alert.DeliveryPending, now, limit, now.Add(lease),
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to claim deliveries", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	return scanDeliveries(rows)
+}
+
+// Complete marks a delivery as delivered
+func (r *OutboxRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	return r.updateOne(ctx, id, "UPDATE notification_outbox SET state = $2, delivered_at = $3 WHERE id = $1",
+		alert.DeliveryDelivered, at)
+}
+
+// Fail records a failed attempt and schedules the next one
+func (r *OutboxRepository) Fail(ctx context.Context, id, lastError string, retryAt time.Time) error {
+	return r.updateOne(ctx, id, "UPDATE notification_outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1",
+		lastError, retryAt)
+}
+
+// Bury moves a delivery to the dead-letter state
+func (r *OutboxRepository) Bury(ctx context.Context, id, lastError string) error {
+	return r.updateOne(ctx, id, "UPDATE notification_outbox SET state = $2, last_error = $3 WHERE id = $1",
+		alert.DeliveryDead, lastError)
+}
+
+// Retry returns a dead-lettered delivery to the queue with fresh attempts
+func (r *OutboxRepository) Retry(ctx context.Context, id string, at time.Time) (*alert.Delivery, error) {
+	deliveryID, err := parseRowID(id, "delivery", alert.ErrDeliveryNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE notification_outbox
+		 SET state = $1, attempts = 0, next_attempt_at = $2
+		 WHERE id = $3 AND state = $4
+		 RETURNING `+deliveryColumns,
+		alert.DeliveryPending, at, deliveryID, alert.DeliveryDead,
+	)
+	d, err := scanDelivery(row)
+	if err == nil {
+		return d, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM notification_outbox WHERE id = $1)", deliveryID).Scan(&exists)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find delivery", errors.ErrorKindInternal, err)
+	}
+	if !exists {
+		return nil, alert.ErrDeliveryNotFound
+	}
+	return nil, alert.ErrDeliveryNotDead
+}
+
+// List retrieves up to limit deliveries, newest first, optionally filtered by state
+func (r *OutboxRepository) List(ctx context.Context, state string, limit int) ([]*alert.Delivery, error) {
+	// LIMIT NULL is equivalent to LIMIT ALL
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+deliveryColumns+` FROM notification_outbox
+		 WHERE $1 = '' OR state = $1
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT $2`,
+		state, limitArg,
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find deliveries", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	return scanDeliveries(rows)
+}
+
+// Count returns the number of deliveries in each state
+func (r *OutboxRepository) Count(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT state, COUNT(*) FROM notification_outbox GROUP BY state")
+	if err != nil {
+		return nil, errors.NewWithCause("failed to count deliveries", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var (
+			state string
+			count int64
+		)
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, errors.NewWithCause("failed to decode delivery counts", errors.ErrorKindInternal, err)
+		}
+		counts[state] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode delivery counts", errors.ErrorKindInternal, err)
+	}
+
+	return counts, nil
+}
+
+// PurgeDelivered deletes deliveries delivered before the given time and
+// returns how many were deleted
+func (r *OutboxRepository) PurgeDelivered(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM notification_outbox WHERE state = $1 AND delivered_at < $2",
+		alert.DeliveryDelivered, before,
+	)
+	if err != nil {
+		return 0, errors.NewWithCause("failed to purge deliveries", errors.ErrorKindInternal, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.NewWithCause("failed to purge deliveries", errors.ErrorKindInternal, err)
+	}
+
+	return purged, nil
+}
+
+// updateOne runs an update of the delivery with the given ID, passed as $1
+func (r *OutboxRepository) updateOne(ctx context.Context, id, query string, args ...interface{}) error {
+	deliveryID, err := parseRowID(id, "delivery", alert.ErrDeliveryNotFound)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, append([]interface{}{deliveryID}, args...)...)
+	if err != nil {
+		return errors.NewWithCause("failed to update delivery", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCause("failed to update delivery", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return alert.ErrDeliveryNotFound
+	}
+
+	return nil
+}
+
+// scanDeliveries decodes delivery rows
+func scanDeliveries(rows *sql.Rows) ([]*alert.Delivery, error) {
+	deliveries := []*alert.Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode deliveries", errors.ErrorKindInternal, err)
+	}
+
+	return deliveries, nil
+}
+
+// scanDelivery decodes a single delivery row
+func scanDelivery(row rowScanner) (*alert.Delivery, error) {
+	var (
+		d            alert.Delivery
+		id           int64
+		notification []byte
+		deliveredAt  sql.NullTime
+	)
+
+	err := row.Scan(&id, &d.Channel, &notification, &d.State, &d.Attempts, &d.NextAttemptAt, &d.LastError,
+		&d.CreatedAt, &deliveredAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, alert.ErrDeliveryNotFound
+		}
+		return nil, errors.NewWithCause("failed to find delivery", errors.ErrorKindInternal, err)
+	}
+
+	if err := decodeJSON(notification, &d.Notification, "delivery notification"); err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	d.ID = strconv.FormatInt(id, 10)
+
+	return &d, nil
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"strconv"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const policyColumns = "id, name, enabled, match, severities, channels, repeat_interval, quiet_hours, escalation, created_at, updated_at"
+
+// PolicyRepository implements alert.PolicyRepository for PostgreSQL
+type PolicyRepository struct {
+	db Interface
+}
+
+// NewPolicyRepository creates a new policy repository
+func NewPolicyRepository(db Interface) *PolicyRepository {
+	return &PolicyRepository{
+		db: db,
+	}
+}
+
+// policyDocuments holds the JSONB encodings of a policy
+type policyDocuments struct {
+	match, severities, channels, quietHours, escalation []byte
+}
+
+// Create creates a new policy
+func (r *PolicyRepository) Create(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	docs, err := encodePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var id int64
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO alert_policies (name, enabled, match, severities, channels, repeat_interval, quiet_hours, escalation, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id`,
+		policy.Name, policy.Enabled, docs.match, docs.severities, docs.channels, int64(policy.RepeatInterval),
+		docs.quietHours, docs.escalation, now, now,
+	).Scan(&id)
+	if err != nil {
+		return errors.NewWithCause("failed to create policy", errors.ErrorKindInternal, err)
+	}
+
+	policy.ID = strconv.FormatInt(id, 10)
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	return nil
+}
+
+// GetByID retrieves a policy by its ID
+func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*alert.Policy, error) {
+	policyID, err := parseRowID(id, "policy", alert.ErrPolicyNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+policyColumns+" FROM alert_policies WHERE id = $1", policyID)
+	return scanPolicy(row)
+}
+
+// GetAll retrieves all policies in creation order
+func (r *PolicyRepository) GetAll(ctx context.Context) ([]*alert.Policy, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+policyColumns+" FROM alert_policies ORDER BY id")
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find policies", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	policies := []*alert.Policy{}
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode policies", errors.ErrorKindInternal, err)
+	}
+
+	return policies, nil
+}
+
+// Update replaces an existing policy, keeping its creation time
+func (r *PolicyRepository) Update(ctx context.Context, policy *alert.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.NewWithCause("invalid policy", errors.ErrorKindValidation, err)
+	}
+
+	policyID, err := parseRowID(policy.ID, "policy", alert.ErrPolicyNotFound)
+	if err != nil {
+		return err
+	}
+
+	docs, err := encodePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var createdAt time.Time
+	err = r.db.QueryRowContext(ctx,
+		`UPDATE alert_policies
+		 SET name = $1, enabled = $2, match = $3, severities = $4, channels = $5, repeat_interval = $6,
+		     quiet_hours = $7, escalation = $8, updated_at = $9
+		 WHERE id = $10
+		 RETURNING created_at`,
+		policy.Name, policy.Enabled, docs.match, docs.severities, docs.channels, int64(policy.RepeatInterval),
+		docs.quietHours, docs.escalation, now, policyID,
+	).Scan(&createdAt)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return alert.ErrPolicyNotFound
+	}
+	if err != nil {
+		return errors.NewWithCause("failed to update policy", errors.ErrorKindInternal, err)
+	}
+
+	policy.CreatedAt = createdAt.UTC()
+	policy.UpdatedAt = now
+
+	return nil
+}
+
+// Delete deletes a policy by its ID
+func (r *PolicyRepository) Delete(ctx context.Context, id string) error {
+	policyID, err := parseRowID(id, "policy", alert.ErrPolicyNotFound)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM alert_policies WHERE id = $1", policyID)
+	if err != nil {
+		return errors.NewWithCause("failed to delete policy", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCause("failed to delete policy", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return alert.ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// encodePolicy serialises the JSONB columns of a policy
+func encodePolicy(policy *alert.Policy) (policyDocuments, error) {
+	var (
+		docs policyDocuments
+		err  error
+	)
+	for _, field := range []struct {
+		dst *[]byte
+		v   interface{}
+	}{
+		{&docs.match, policy.Match},
+		{&docs.severities, policy.Severities},
+		{&docs.channels, policy.Channels},
+		{&docs.quietHours, policy.QuietHours},
+		{&docs.escalation, policy.Escalation},
+	} {
+		if *field.dst, err = json.Marshal(field.v); err != nil {
+			return docs, errors.NewWithCause("failed to encode policy", errors.ErrorKindInternal, err)
+		}
+	}
+	return docs, nil
+}
+
+// scanPolicy decodes a single policy row
+func scanPolicy(row rowScanner) (*alert.Policy, error) {
+	var (
+		policy         alert.Policy
+		id             int64
+		repeatInterval int64
+		docs           policyDocuments
+	)
+
+	err := row.Scan(&id, &policy.Name, &policy.Enabled, &docs.match, &docs.severities, &docs.channels,
+		&repeatInterval, &docs.quietHours, &docs.escalation, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, alert.ErrPolicyNotFound
+		}
+		return nil, errors.NewWithCause("failed to find policy", errors.ErrorKindInternal, err)
+	}
+
+	if err := decodeJSON(docs.match, &policy.Match, "policy match"); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(docs.severities, &policy.Severities, "policy severities"); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(docs.channels, &policy.Channels, "policy channels"); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(docs.quietHours, &policy.QuietHours, "policy quiet hours"); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(docs.escalation, &policy.Escalation, "policy escalation"); err != nil {
+		return nil, err
+	}
+	policy.ID = strconv.FormatInt(id, 10)
+	policy.RepeatInterval = alert.Duration(repeatInterval)
+
+	return &policy, nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	// Register the pgx driver with database/sql
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockID is the advisory lock key used to serialise concurrent migrators
+const migrationLockID = 7302158491
+
+// Interface defines the interface for PostgreSQL database operations
+type Interface interface {
+	Close() error
+	Ping(ctx context.Context) error
+	Migrate(ctx context.Context) error
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Database wraps a PostgreSQL connection pool
+type Database struct {
+	DB      *sql.DB
+	timeout time.Duration
+}
+
+// NewConnection opens a PostgreSQL connection and applies pending migrations
+func NewConnection(dsn string) (*Database, error) {
+	return NewConnectionWithTimeout(dsn, 10*time.Second)
+}
+
+// NewConnectionWithTimeout opens a PostgreSQL connection with the given timeout
+func NewConnectionWithTimeout(dsn string, timeout time.Duration) (*Database, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	sqlDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	db := &Database{
+		DB:      sqlDB,
+		timeout: timeout,
+	}
+
+	// Ensure the schema is up to date
+	if err := db.Migrate(ctx); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to migrate PostgreSQL schema: %w", err)
+	}
+
+	log := logger.Get()
+	log.Info(ctx, "Connected to PostgreSQL successfully", logger.Fields{
+		"timeout": timeout.String(),
+	})
+
+	return db, nil
+}
+
+// Close closes the connection pool
+func (db *Database) Close() error {
+	return db.DB.Close()
+}
+
+// Ping verifies the connection is alive
+func (db *Database) Ping(ctx context.Context) error {
+	return db.DB.PingContext(ctx)
+}
+
+// ExecContext executes a statement that returns no rows
+func (db *Database) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns rows
+func (db *Database) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes a query that returns at most one row
+func (db *Database) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
+// Migrate applies all embedded migrations that have not been applied yet
+func (db *Database) Migrate(ctx context.Context) error {
+	names, err := migrationNames()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	applied := make([]string, 0, len(names))
+	for _, name := range names {
+		version := strings.TrimSuffix(name, ".sql")
+
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", version, err)
+		}
+		if exists {
+			continue
+		}
+
+		script, err := migrationsFS.ReadFile("migrations/" + name)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", version, err)
+		}
+		applied = append(applied, version)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migrations: %w", err)
+	}
+
+	if len(applied) > 0 {
+		log := logger.Get()
+		log.Info(ctx, "PostgreSQL migrations applied", logger.Fields{
+			"migrations": applied,
+		})
+	}
+
+	return nil
+}
+
+// migrationNames returns the embedded migration file names in apply order
+func migrationNames() ([]string, error) {
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -0,0 +1,450 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// statusLogInsertChunk bounds rows per INSERT to stay well under the
+// 65535 bind parameter limit
+const statusLogInsertChunk = 1000
+
+// uniqueViolation is the PostgreSQL SQLSTATE for unique constraint violations
+const uniqueViolation = "23505"
+
+const serviceColumns = "id, name, slug, url, headers, expected_status, enabled, service_group, tags, created_at, updated_at"
+
+// ServiceRepository implements the service repository interface for PostgreSQL
+type ServiceRepository struct {
+	db Interface
+}
+
+// NewServiceRepository creates a new service repository
+func NewServiceRepository(db Interface) *ServiceRepository {
+	return &ServiceRepository{
+		db: db,
+	}
+}
+
+// Create creates a new service
+func (r *ServiceRepository) Create(ctx context.Context, svc *service.Service) error {
+	if err := svc.Validate(); err != nil {
+		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
+	}
+
+	headers, err := encodeHeaders(svc.Headers)
+	if err != nil {
+		return errors.NewWithCause("failed to encode service headers", errors.ErrorKindInternal, err)
+	}
+
+	tags, err := encodeTags(svc.Tags)
+	if err != nil {
+		return errors.NewWithCause("failed to encode service tags", errors.ErrorKindInternal, err)
+	}
+
+	now := time.Now().UTC()
+	if svc.CreatedAt.IsZero() {
+		svc.CreatedAt = now
+	}
+	if svc.UpdatedAt.IsZero() {
+		svc.UpdatedAt = now
+	}
+
+	var id int64
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO services (name, slug, url, headers, expected_status, enabled, service_group, tags, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id`,
+		svc.Name, svc.Slug, svc.URL, headers, svc.ExpectedStatus, svc.Enabled, svc.Group, tags, svc.CreatedAt, svc.UpdatedAt,
+	).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return service.ErrServiceAlreadyExists
+		}
+		return errors.NewWithCause("failed to create service", errors.ErrorKindInternal, err)
+	}
+
+	svc.ID = strconv.FormatInt(id, 10)
+
+	return nil
+}
+
+// GetByID retrieves a service by its ID
+func (r *ServiceRepository) GetByID(ctx context.Context, id string) (*service.Service, error) {
+	serviceID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+serviceColumns+" FROM services WHERE id = $1", serviceID)
+	return scanService(row)
+}
+
+// GetBySlug retrieves a service by slug
+func (r *ServiceRepository) GetBySlug(ctx context.Context, slug string) (*service.Service, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+serviceColumns+" FROM services WHERE slug = $1", slug)
+	return scanService(row)
+}
+
+// GetAll retrieves all services
+func (r *ServiceRepository) GetAll(ctx context.Context) ([]*service.Service, error) {
+	return r.queryServices(ctx, "SELECT "+serviceColumns+" FROM services ORDER BY id")
+}
+
+// GetEnabled retrieves all enabled services
+func (r *ServiceRepository) GetEnabled(ctx context.Context) ([]*service.Service, error) {
+	return r.queryServices(ctx, "SELECT "+serviceColumns+" FROM services WHERE enabled ORDER BY id")
+}
+
+// Update updates an existing service
+func (r *ServiceRepository) Update(ctx context.Context, svc *service.Service) error {
+	if err := svc.Validate(); err != nil {
+		return errors.NewWithCause("invalid service", errors.ErrorKindValidation, err)
+	}
+
+	headers, err := encodeHeaders(svc.Headers)
+	if err != nil {
+		return errors.NewWithCause("failed to encode service headers", errors.ErrorKindInternal, err)
+	}
+
+	tags, err := encodeTags(svc.Tags)
+	if err != nil {
+		return errors.NewWithCause("failed to encode service tags", errors.ErrorKindInternal, err)
+	}
+
+	// Update by slug to match the MongoDB implementation. The creation time
+	// is kept and the update time is stamped here, not taken from the caller.
+	now := time.Now().UTC()
+	var createdAt time.Time
+	err = r.db.QueryRowContext(ctx,
+		`UPDATE services
+		 SET name = $1, url = $2, headers = $3, expected_status = $4, enabled = $5, service_group = $6, tags = $7, updated_at = $8
+		 WHERE slug = $9
+		 RETURNING created_at`,
+		svc.Name, svc.URL, headers, svc.ExpectedStatus, svc.Enabled, svc.Group, tags, now, svc.Slug,
+	).Scan(&createdAt)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return errors.NewNotFoundError("service not found")
+	}
+	if err != nil {
+		return errors.NewWithCause("failed to update service", errors.ErrorKindInternal, err)
+	}
+
+	svc.CreatedAt = createdAt.UTC()
+	svc.UpdatedAt = now
+	return nil
+}
+
+// Delete deletes a service by its ID
+func (r *ServiceRepository) Delete(ctx context.Context, id string) error {
+	serviceID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM services WHERE id = $1", serviceID)
+	if err != nil {
+		return errors.NewWithCause("failed to delete service", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCause("failed to delete service", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return errors.NewNotFoundError("service not found")
+	}
+
+	return nil
+}
+
+// SaveStatusLog saves a status log entry
+func (r *ServiceRepository) SaveStatusLog(ctx context.Context, log *service.StatusLog) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO status_logs (service_name, status, latency_ms, status_code, error, timestamp)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		log.ServiceName, log.Status, log.Latency, log.StatusCode, log.Error, log.Timestamp,
+	)
+	if err != nil {
+		return errors.NewWithCause("failed to create status log", errors.ErrorKindInternal, err)
+	}
+
+	return nil
+}
+
+// SaveStatusLogs saves a batch of status log entries with multi-row inserts.
+// A chunk that failed before it was sent is reported as service.UnsentLogsError
+// along with the logs already stored.
+func (r *ServiceRepository) SaveStatusLogs(ctx context.Context, logs []*service.StatusLog) error {
+	for start := 0; start < len(logs); start += statusLogInsertChunk {
+		end := start + statusLogInsertChunk
+		if end > len(logs) {
+			end = len(logs)
+		}
+
+		query, args := statusLogInsert(logs[start:end])
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			if pgconn.SafeToRetry(err) {
+				return &service.UnsentLogsError{Stored: start, Err: err}
+			}
+			return errors.NewWithCause("failed to create status logs", errors.ErrorKindInternal, err)
+		}
+	}
+
+	return nil
+}
+
+// GetLatestStatus retrieves one current status per enabled service
+func (r *ServiceRepository) GetLatestStatus(ctx context.Context) ([]*service.ServiceStatus, error) {
+	// The lateral join uses the (service_name, timestamp DESC) index to pick
+	// the newest log per service; services without logs get a row of NULLs
+	// and are reported as unknown
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT s.name, l.status, l.latency_ms, l.error, l.timestamp
+		 FROM services s
+		 LEFT JOIN LATERAL (
+			SELECT status, latency_ms, error, timestamp
+			FROM status_logs
+			WHERE service_name = s.name
+			ORDER BY timestamp DESC
+			LIMIT 1
+		 ) l ON true
+		 WHERE s.enabled
+		 ORDER BY s.id`,
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find latest status logs", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	var statuses []*service.ServiceStatus
+	for rows.Next() {
+		var (
+			name      string
+			status    sql.NullString
+			latency   sql.NullInt64
+			errMsg    sql.NullString
+			timestamp sql.NullTime
+		)
+		if err := rows.Scan(&name, &status, &latency, &errMsg, &timestamp); err != nil {
+			return nil, errors.NewWithCause("failed to decode status logs", errors.ErrorKindInternal, err)
+		}
+
+		if !status.Valid {
+			statuses = append(statuses, &service.ServiceStatus{
+				Name:   name,
+				Status: service.StatusUnknown,
+			})
+			continue
+		}
+
+		statuses = append(statuses, &service.ServiceStatus{
+			Name:      name,
+			Status:    status.String,
+			Latency:   latency.Int64,
+			UpdatedAt: timestamp.Time,
+			Error:     errMsg.String,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode status logs", errors.ErrorKindInternal, err)
+	}
+
+	return statuses, nil
+}
+
+// Ping verifies the database is reachable
+func (r *ServiceRepository) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// GetStatusHistory retrieves status history for a service, newest first.
+// A limit of zero or less returns the full history.
+func (r *ServiceRepository) GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*service.StatusLog, error) {
+	// LIMIT NULL is equivalent to LIMIT ALL
+	var limitArg interface{}
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT service_name, status, latency_ms, status_code, error, timestamp
+		 FROM status_logs
+		 WHERE service_name = $1
+		 ORDER BY timestamp DESC
+		 LIMIT $2`,
+		serviceName, limitArg,
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find status logs for service", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	var statusLogs []*service.StatusLog
+	for rows.Next() {
+		log := &service.StatusLog{}
+		if err := rows.Scan(&log.ServiceName, &log.Status, &log.Latency, &log.StatusCode, &log.Error, &log.Timestamp); err != nil {
+			return nil, errors.NewWithCause("failed to decode status logs", errors.ErrorKindInternal, err)
+		}
+		statusLogs = append(statusLogs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode status logs", errors.ErrorKindInternal, err)
+	}
+
+	return statusLogs, nil
+}
+
+// queryServices runs a query returning service rows
+func (r *ServiceRepository) queryServices(ctx context.Context, query string, args ...interface{}) ([]*service.Service, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find services", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	var services []*service.Service
+	for rows.Next() {
+		svc, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, svc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode services", errors.ErrorKindInternal, err)
+	}
+
+	return services, nil
+}
+
+// statusLogInsert builds a single multi-row INSERT for logs
+func statusLogInsert(logs []*service.StatusLog) (string, []interface{}) {
+	var query strings.Builder
+	query.WriteString("INSERT INTO status_logs (service_name, status, latency_ms, status_code, error, timestamp) VALUES ")
+
+	args := make([]interface{}, 0, len(logs)*6)
+	for i, log := range logs {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, log.ServiceName, log.Status, log.Latency, log.StatusCode, log.Error, log.Timestamp)
+	}
+
+	return query.String(), args
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanService decodes a single service row
+func scanService(row rowScanner) (*service.Service, error) {
+	var (
+		svc     service.Service
+		id      int64
+		headers []byte
+		tags    []byte
+	)
+
+	err := row.Scan(&id, &svc.Name, &svc.Slug, &svc.URL, &headers, &svc.ExpectedStatus, &svc.Enabled, &svc.Group, &tags, &svc.CreatedAt, &svc.UpdatedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("service not found")
+		}
+		return nil, errors.NewWithCause("failed to find service", errors.ErrorKindInternal, err)
+	}
+
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &svc.Headers); err != nil {
+			return nil, errors.NewWithCause("failed to decode service headers", errors.ErrorKindInternal, err)
+		}
+	}
+	if len(tags) > 0 {
+		if err := json.Unmarshal(tags, &svc.Tags); err != nil {
+			return nil, errors.NewWithCause("failed to decode service tags", errors.ErrorKindInternal, err)
+		}
+	}
+	svc.ID = strconv.FormatInt(id, 10)
+
+	return &svc, nil
+}
+
+// parseID converts a service ID string into the numeric primary key
+func parseID(id string) (int64, error) {
+	serviceID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || serviceID <= 0 {
+		return 0, errors.NewValidationError("invalid service ID format")
+	}
+	return serviceID, nil
+}
+
+// parseRowID converts the ID of an alerting record into its numeric primary
+// key. Well-formed IDs that no row can have, such as 0, are not found.
+func parseRowID(id, kind string, notFound error) (int64, error) {
+	rowID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, errors.NewValidationError("invalid " + kind + " ID format")
+	}
+	if rowID <= 0 {
+		return 0, notFound
+	}
+	return rowID, nil
+}
+
+// encodeHeaders serialises service headers into JSONB
+func encodeHeaders(headers map[string]string) ([]byte, error) {
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	return json.Marshal(headers)
+}
+
+// encodeTags serialises service tags into JSONB
+func encodeTags(tags []string) ([]byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	return json.Marshal(tags)
+}
+
+// decodeJSON decodes a JSONB column into v unless it is empty
+func decodeJSON(data []byte, v interface{}, column string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.NewWithCause("failed to decode "+column, errors.ErrorKindInternal, err)
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return stderrors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// closeRows closes a result set, logging any error
+func closeRows(ctx context.Context, rows *sql.Rows) {
+	if err := rows.Close(); err != nil {
+		// Log error but don't fail the operation
+		log := logger.Get()
+		log.Error(ctx, "Error closing rows", err, nil)
+	}
+}
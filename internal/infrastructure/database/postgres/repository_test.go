@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert/alerttest"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/domain/service/servicetest"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+// newTestDatabase connects to the database named by POSTGRES_TEST_DSN and
+// truncates the tables, skipping the test when no database is configured
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set, skipping PostgreSQL integration test")
+	}
+
+	db, err := NewConnection(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	_, err = db.ExecContext(context.Background(),
+		"TRUNCATE services, status_logs, alert_policies, alerts, notification_outbox, silences, message_templates RESTART IDENTITY")
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestMigrationNames(t *testing.T) {
+	names, err := migrationNames()
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	assert.Equal(t, "0001_create_services_and_status_logs.sql", names[0])
+	assert.IsIncreasing(t, names)
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    int64
+		wantErr bool
+	}{
+		{name: "valid id", id: "42", want: 42},
+		{name: "non numeric id", id: "507f1f77bcf86cd799439011", wantErr: true},
+		{name: "empty id", id: "", wantErr: true},
+		{name: "zero id", id: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseID(tt.id)
+			if tt.wantErr {
+				assert.True(t, errors.IsValidation(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRowID(t *testing.T) {
+	id, err := parseRowID("42", "alert", alert.ErrAlertNotFound)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+
+	_, err = parseRowID("507f1f77bcf86cd799439011", "alert", alert.ErrAlertNotFound)
+	assert.True(t, errors.IsValidation(err))
+
+	// Well-formed IDs no row can have are not found rather than invalid
+	_, err = parseRowID("000000000000000000000000", "alert", alert.ErrAlertNotFound)
+	assert.Equal(t, alert.ErrAlertNotFound, err)
+}
+
+func TestEncodeHeaders(t *testing.T) {
+	encoded, err := encodeHeaders(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(encoded))
+
+	encoded, err = encodeHeaders(map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Authorization":"Bearer token"}`, string(encoded))
+}
+
+func TestStatusLogInsert(t *testing.T) {
+	now := time.Now().UTC()
+	logs := []*service.StatusLog{
+		{ServiceName: "api", Status: "operational", Latency: 12, StatusCode: 200, Timestamp: now},
+		{ServiceName: "web", Status: "down", StatusCode: 503, Error: "unavailable", Timestamp: now},
+	}
+
+	query, args := statusLogInsert(logs)
+
+	assert.Equal(t,
+		"INSERT INTO status_logs (service_name, status, latency_ms, status_code, error, timestamp) VALUES "+
+			"($1, $2, $3, $4, $5, $6), ($7, $8, $9, $10, $11, $12)",
+		query)
+	assert.Equal(t, []interface{}{
+		"api", "operational", int64(12), 200, "", now,
+		"web", "down", int64(0), 503, "unavailable", now,
+	}, args)
+}
+
+func TestServiceRepository_InvalidIDDoesNotQuery(t *testing.T) {
+	repo := NewServiceRepository(nil)
+
+	_, err := repo.GetByID(context.Background(), "not-a-number")
+	assert.True(t, errors.IsValidation(err))
+
+	err = repo.Delete(context.Background(), "not-a-number")
+	assert.True(t, errors.IsValidation(err))
+}
+
+func TestServiceRepository_Contract(t *testing.T) {
+	servicetest.RunRepositoryContract(t, func(t *testing.T) service.Repository {
+		return NewServiceRepository(newTestDatabase(t))
+	})
+}
+
+func TestPolicyRepository_Contract(t *testing.T) {
+	alerttest.RunPolicyRepositoryContract(t, func(t *testing.T) alert.PolicyRepository {
+		return NewPolicyRepository(newTestDatabase(t))
+	})
+}
+
+func TestAlertRepository_Contract(t *testing.T) {
+	alerttest.RunAlertRepositoryContract(t, func(t *testing.T) alert.AlertRepository {
+		return NewAlertRepository(newTestDatabase(t))
+	})
+}
+
+func TestOutboxRepository_Contract(t *testing.T) {
+	alerttest.RunOutboxRepositoryContract(t, func(t *testing.T) alert.OutboxRepository {
+		return NewOutboxRepository(newTestDatabase(t))
+	})
+}
+
+func TestSilenceRepository_Contract(t *testing.T) {
+	alerttest.RunSilenceRepositoryContract(t, func(t *testing.T) alert.SilenceRepository {
+		return NewSilenceRepository(newTestDatabase(t))
+	})
+}
+
+func TestTemplateRepository_Contract(t *testing.T) {
+	alerttest.RunTemplateRepositoryContract(t, func(t *testing.T) alert.TemplateRepository {
+		return NewTemplateRepository(newTestDatabase(t))
+	})
+}
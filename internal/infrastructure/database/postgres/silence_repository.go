@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"strconv"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const silenceColumns = "id, match, channels, starts_at, ends_at, created_by, comment, created_at"
+
+// SilenceRepository implements alert.SilenceRepository for PostgreSQL
+type SilenceRepository struct {
+	db Interface
+}
+
+// NewSilenceRepository creates a new silence repository
+func NewSilenceRepository(db Interface) *SilenceRepository {
+	return &SilenceRepository{
+		db: db,
+	}
+}
+
+// Create validates and stores a new silence
+func (r *SilenceRepository) Create(ctx context.Context, silence *alert.Silence) error {
+	if err := silence.Validate(); err != nil {
+		return errors.NewWithCause("invalid silence", errors.ErrorKindValidation, err)
+	}
+
+	match, err := json.Marshal(silence.Match)
+	if err != nil {
+		return errors.NewWithCause("failed to encode silence match", errors.ErrorKindInternal, err)
+	}
+	channels, err := json.Marshal(silence.Channels)
+	if err != nil {
+		return errors.NewWithCause("failed to encode silence channels", errors.ErrorKindInternal, err)
+	}
+
+	createdAt := time.Now().UTC()
+	var id int64
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO silences (match, channels, starts_at, ends_at, created_by, comment, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING id`,
+		match, channels, silence.StartsAt, silence.EndsAt, silence.CreatedBy, silence.Comment, createdAt,
+	).Scan(&id)
+	if err != nil {
+		return errors.NewWithCause("failed to create silence", errors.ErrorKindInternal, err)
+	}
+
+	silence.ID = strconv.FormatInt(id, 10)
+	silence.CreatedAt = createdAt
+
+	return nil
+}
+
+// GetByID retrieves a silence by its ID
+func (r *SilenceRepository) GetByID(ctx context.Context, id string) (*alert.Silence, error) {
+	silenceID, err := parseRowID(id, "silence", alert.ErrSilenceNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+silenceColumns+" FROM silences WHERE id = $1", silenceID)
+	return scanSilence(row)
+}
+
+// GetAll retrieves all silences in creation order
+func (r *SilenceRepository) GetAll(ctx context.Context) ([]*alert.Silence, error) {
+	return r.query(ctx, "SELECT "+silenceColumns+" FROM silences ORDER BY id")
+}
+
+// GetActive retrieves the silences whose window contains at
+func (r *SilenceRepository) GetActive(ctx context.Context, at time.Time) ([]*alert.Silence, error) {
+	return r.query(ctx, "SELECT "+silenceColumns+" FROM silences WHERE starts_at <= $1 AND ends_at > $1 ORDER BY id", at)
+}
+
+// Expire ends a silence at the given time and returns it
+func (r *SilenceRepository) Expire(ctx context.Context, id string, at time.Time) (*alert.Silence, error) {
+	silence, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := silence.Expire(at); err != nil {
+		return nil, err
+	}
+
+	// Only a silence that is still running is updated, so concurrent
+	// expiries cannot move its end time
+	silenceID, _ := strconv.ParseInt(silence.ID, 10, 64)
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE silences SET starts_at = $1, ends_at = $2 WHERE id = $3 AND ends_at > $4",
+		silence.StartsAt, silence.EndsAt, silenceID, at,
+	)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to expire silence", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.NewWithCause("failed to expire silence", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return nil, alert.ErrSilenceExpired
+	}
+
+	return silence, nil
+}
+
+// query runs a query returning silence rows
+func (r *SilenceRepository) query(ctx context.Context, query string, args ...interface{}) ([]*alert.Silence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find silences", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	silences := []*alert.Silence{}
+	for rows.Next() {
+		silence, err := scanSilence(rows)
+		if err != nil {
+			return nil, err
+		}
+		silences = append(silences, silence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode silences", errors.ErrorKindInternal, err)
+	}
+
+	return silences, nil
+}
+
+// scanSilence decodes a single silence row
+func scanSilence(row rowScanner) (*alert.Silence, error) {
+	var (
+		silence  alert.Silence
+		id       int64
+		match    []byte
+		channels []byte
+	)
+
+	err := row.Scan(&id, &match, &channels, &silence.StartsAt, &silence.EndsAt, &silence.CreatedBy,
+		&silence.Comment, &silence.CreatedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, alert.ErrSilenceNotFound
+		}
+		return nil, errors.NewWithCause("failed to find silence", errors.ErrorKindInternal, err)
+	}
+
+	if err := decodeJSON(match, &silence.Match, "silence match"); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(channels, &silence.Channels, "silence channels"); err != nil {
+		return nil, err
+	}
+	silence.ID = strconv.FormatInt(id, 10)
+
+	return &silence, nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+)
+
+const templateColumns = "channel, source, updated_by, updated_at"
+
+// TemplateRepository implements alert.TemplateRepository for PostgreSQL.
+// Templates are keyed by channel name.
+type TemplateRepository struct {
+	db Interface
+}
+
+// NewTemplateRepository creates a new template repository
+func NewTemplateRepository(db Interface) *TemplateRepository {
+	return &TemplateRepository{
+		db: db,
+	}
+}
+
+// Save validates and stores the template of a channel, replacing any existing one
+func (r *TemplateRepository) Save(ctx context.Context, template *alert.Template) error {
+	if err := template.Validate(); err != nil {
+		return errors.NewWithCause("invalid template", errors.ErrorKindValidation, err)
+	}
+
+	template.UpdatedAt = time.Now().UTC()
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO message_templates (channel, source, updated_by, updated_at)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (channel) DO UPDATE
+		 SET source = EXCLUDED.source, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`,
+		template.Channel, template.Source, template.UpdatedBy, template.UpdatedAt,
+	)
+	if err != nil {
+		return errors.NewWithCause("failed to save template", errors.ErrorKindInternal, err)
+	}
+
+	return nil
+}
+
+// Get retrieves the template of a channel
+func (r *TemplateRepository) Get(ctx context.Context, channel string) (*alert.Template, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+templateColumns+" FROM message_templates WHERE channel = $1", channel)
+	return scanTemplate(row)
+}
+
+// GetAll retrieves all templates ordered by channel
+func (r *TemplateRepository) GetAll(ctx context.Context) ([]*alert.Template, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+templateColumns+" FROM message_templates ORDER BY channel")
+	if err != nil {
+		return nil, errors.NewWithCause("failed to find templates", errors.ErrorKindInternal, err)
+	}
+	defer closeRows(ctx, rows)
+
+	templates := []*alert.Template{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewWithCause("failed to decode templates", errors.ErrorKindInternal, err)
+	}
+
+	return templates, nil
+}
+
+// Delete deletes the template of a channel
+func (r *TemplateRepository) Delete(ctx context.Context, channel string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM message_templates WHERE channel = $1", channel)
+	if err != nil {
+		return errors.NewWithCause("failed to delete template", errors.ErrorKindInternal, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewWithCause("failed to delete template", errors.ErrorKindInternal, err)
+	}
+	if affected == 0 {
+		return alert.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// scanTemplate decodes a single template row
+func scanTemplate(row rowScanner) (*alert.Template, error) {
+	var template alert.Template
+	err := row.Scan(&template.Channel, &template.Source, &template.UpdatedBy, &template.UpdatedAt)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, alert.ErrTemplateNotFound
+		}
+		return nil, errors.NewWithCause("failed to find template", errors.ErrorKindInternal, err)
+	}
+
+	return &template, nil
+}
@@ -0,0 +1,139 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Event colors shared by the chat channels
+const (
+	colorDown      = 0xD00000
+	colorDegraded  = 0xF2C744
+	colorRecovered = 0x2EB67D
+)
+
+// ChatOption configures a chat notifier (Slack, Microsoft Teams or Discord)
+type ChatOption func(*chatConfig)
+
+// chatConfig holds the settings shared by chat notifiers
+type chatConfig struct {
+	templates    TemplateSource
+	username     string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// WithChatTemplate sets the message template for the channel
+func WithChatTemplate(tmpl *MessageTemplate) ChatOption {
+	return func(c *chatConfig) {
+		if tmpl != nil {
+			c.templates = tmpl
+		}
+	}
+}
+
+// WithChatTemplates sets where the message template of the channel comes from
+func WithChatTemplates(templates TemplateSource) ChatOption {
+	return func(c *chatConfig) {
+		if templates != nil {
+			c.templates = templates
+		}
+	}
+}
+
+// WithChatUsername overrides the sender name where the platform supports it
+func WithChatUsername(username string) ChatOption {
+	return func(c *chatConfig) {
+		c.username = username
+	}
+}
+
+// WithChatHTTPClient sets a custom HTTP client
+func WithChatHTTPClient(client HTTPClient) ChatOption {
+	return func(c *chatConfig) {
+		c.client = client
+	}
+}
+
+// WithChatRetry sets the number of retries and the initial backoff between them
+func WithChatRetry(maxRetries int, backoff time.Duration) ChatOption {
+	return func(c *chatConfig) {
+		if maxRetries >= 0 {
+			c.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			c.retryBackoff = backoff
+		}
+	}
+}
+
+// newChatConfig applies options over the chat defaults
+func newChatConfig(options []ChatOption) chatConfig {
+	c := chatConfig{
+		templates: DefaultMessageTemplate(),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return c
+}
+
+// send renders the message, builds the platform payload and posts it
+func (c *chatConfig) send(ctx context.Context, name, url string, event Event, build func(Message) interface{}) error {
+	msg, err := c.templates.Template(ctx, name).Render(event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(build(msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", name, err)
+	}
+
+	sender := &httpSender{
+		name:         name,
+		client:       c.client,
+		maxRetries:   c.maxRetries,
+		retryBackoff: c.retryBackoff,
+	}
+	return sender.post(ctx, url, body, nil)
+}
+
+// eventColor returns the RGB color for an event type
+func eventColor(eventType EventType) int {
+	switch eventType {
+	case EventDown:
+		return colorDown
+	case EventDegraded:
+		return colorDegraded
+	default:
+		return colorRecovered
+	}
+}
+
+// eventFacts returns the key details shown alongside chat messages
+func eventFacts(event Event) [][2]string {
+	facts := [][2]string{
+		{"Status", event.Status},
+		{"Previous", event.PreviousStatus},
+	}
+	if event.StatusCode > 0 {
+		facts = append(facts, [2]string{"HTTP status", strconv.Itoa(event.StatusCode)})
+	}
+	if event.Latency > 0 {
+		facts = append(facts, [2]string{"Latency", strconv.FormatInt(event.Latency, 10) + " ms"})
+	}
+	return facts
+}
@@ -0,0 +1,120 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	n := NewSlackNotifier(server.URL, WithChatUsername("uptime"))
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	require.Len(t, receiver.bodies, 1)
+
+	var payload SlackPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+
+	assert.Equal(t, "Incident: API is down", payload.Text)
+	assert.Equal(t, "uptime", payload.Username)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "#D00000", payload.Attachments[0].Color)
+
+	blocks := payload.Attachments[0].Blocks
+	require.Len(t, blocks, 4)
+	assert.Equal(t, "header", blocks[0].Type)
+	assert.Equal(t, "Incident: API is down", blocks[0].Text.Text)
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code", blocks[1].Text.Text)
+	assert.Contains(t, blocks[2].Fields, SlackText{Type: "mrkdwn", Text: "*HTTP status*\n503"})
+	assert.Equal(t, "context", blocks[3].Type)
+}
+
+func TestSlackPayload_EscapesText(t *testing.T) {
+	event := testEvent()
+	payload := NewSlackPayload(event, Message{Title: "t", Text: "<!channel> & <b>"}, "")
+
+	assert.Equal(t, "&lt;!channel&gt; &amp; &lt;b&gt;", payload.Attachments[0].Blocks[1].Text.Text)
+}
+
+func TestTeamsNotifier_Notify(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	event := testEvent()
+	event.Type = EventRecovered
+	event.Status = "operational"
+	event.PreviousStatus = "down"
+
+	require.NoError(t, NewTeamsNotifier(server.URL).Notify(context.Background(), event))
+	require.Len(t, receiver.bodies, 1)
+
+	var payload TeamsPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+
+	assert.Equal(t, "message", payload.Type)
+	require.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", payload.Attachments[0].ContentType)
+
+	card := payload.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	require.Len(t, card.Body, 3)
+	assert.Equal(t, "Resolved: API is operational again", card.Body[0].Text)
+	assert.Equal(t, "Good", card.Body[0].Color)
+	assert.Equal(t, "FactSet", card.Body[2].Type)
+	assert.Contains(t, card.Body[2].Facts, AdaptiveCardFact{Title: "Previous", Value: "down"})
+}
+
+func TestDiscordNotifier_Notify(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	tmpl, err := ParseMessageTemplate(`{{define "text"}}{{.ServiceSlug}} {{.Status}}{{end}}`)
+	require.NoError(t, err)
+
+	event := testEvent()
+	event.Type = EventDegraded
+	event.Status = "degraded"
+
+	n := NewDiscordNotifier(server.URL, WithChatTemplate(tmpl), WithChatUsername("uptime"))
+	require.NoError(t, n.Notify(context.Background(), event))
+	require.Len(t, receiver.bodies, 1)
+
+	var payload DiscordPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &payload))
+
+	assert.Equal(t, "uptime", payload.Username)
+	require.Len(t, payload.Embeds, 1)
+	embed := payload.Embeds[0]
+	assert.Equal(t, "Incident: API is degraded", embed.Title)
+	assert.Equal(t, "api degraded", embed.Description)
+	assert.Equal(t, colorDegraded, embed.Color)
+	assert.Equal(t, "2024-01-02T03:04:05Z", embed.Timestamp)
+	assert.Contains(t, embed.Fields, DiscordEmbedField{Name: "Status", Value: "degraded", Inline: true})
+}
+
+func TestChatNotifier_RetriesServerErrors(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{503, 200}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	n := NewDiscordNotifier(server.URL, WithChatRetry(1, time.Millisecond))
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Equal(t, int32(2), receiver.calls.Load())
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abcd…", truncate("abcdefgh", 5))
+	assert.Len(t, []rune(truncate(strings.Repeat("é", 300), discordTitleLimit)), discordTitleLimit)
+}
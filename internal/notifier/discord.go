@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Discord limits embed titles to 256 characters and descriptions to 4096
+const (
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+)
+
+// DiscordNotifier posts embeds to a Discord webhook
+type DiscordNotifier struct {
+	url string
+	chatConfig
+}
+
+// NewDiscordNotifier creates a Discord notifier for a webhook URL
+func NewDiscordNotifier(url string, options ...ChatOption) *DiscordNotifier {
+	return &DiscordNotifier{
+		url:        url,
+		chatConfig: newChatConfig(options),
+	}
+}
+
+// Name identifies the channel
+func (n *DiscordNotifier) Name() string {
+	return "discord"
+}
+
+// Notify posts the event to Discord
+func (n *DiscordNotifier) Notify(ctx context.Context, event Event) error {
+	return n.send(ctx, n.Name(), n.url, event, func(msg Message) interface{} {
+		return NewDiscordPayload(event, msg, n.username)
+	})
+}
+
+// DiscordPayload is a Discord webhook message
+type DiscordPayload struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a rich embed
+type DiscordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Timestamp   string              `json:"timestamp"`
+}
+
+// DiscordEmbedField is a name/value pair shown in an embed
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// NewDiscordPayload builds the Discord message for an event
+func NewDiscordPayload(event Event, msg Message, username string) DiscordPayload {
+	fields := make([]DiscordEmbedField, 0, 4)
+	for _, fact := range eventFacts(event) {
+		fields = append(fields, DiscordEmbedField{Name: fact[0], Value: fact[1], Inline: true})
+	}
+
+	return DiscordPayload{
+		Username: username,
+		Embeds: []DiscordEmbed{{
+			Title:       truncate(msg.Title, discordTitleLimit),
+			Description: truncate(msg.Text, discordDescriptionLimit),
+			Color:       eventColor(event.Type),
+			Fields:      fields,
+			Timestamp:   event.Timestamp.UTC().Format(time.RFC3339),
+		}},
+	}
+}
+
+// truncate shortens s to at most limit runes, marking the cut with an ellipsis
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
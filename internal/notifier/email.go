@@ -0,0 +1,548 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// Email template file names, both embedded and in a custom template directory
+const (
+	EmailSubjectTemplate = "email_subject.tmpl"
+	EmailTextTemplate    = "email.txt.tmpl"
+	EmailHTMLTemplate    = "email.html.tmpl"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplatesFS embed.FS
+
+// TLSMode selects how the SMTP connection is secured
+type TLSMode string
+
+const (
+	// TLSModeStartTLS upgrades a plain connection with STARTTLS (usually port 587)
+	TLSModeStartTLS TLSMode = "starttls"
+	// TLSModeImplicit connects over TLS from the start (usually port 465)
+	TLSModeImplicit TLSMode = "tls"
+	// TLSModeNone sends without encryption; only for trusted local relays
+	TLSModeNone TLSMode = "none"
+)
+
+// HistoryProvider supplies recent status logs to include in notifications.
+// service.Repository satisfies it.
+type HistoryProvider interface {
+	GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*service.StatusLog, error)
+}
+
+// EmailData is the data available to email templates
+type EmailData struct {
+	Event
+	IsRecovery  bool
+	Color       string
+	IncidentURL string
+	History     []*service.StatusLog
+}
+
+// EmailTemplates renders the subject and the plain-text and HTML bodies
+type EmailTemplates struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// DefaultEmailTemplates returns the built-in email templates
+func DefaultEmailTemplates() *EmailTemplates {
+	templates, err := parseEmailTemplates(defaultTemplatesFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+// LoadEmailTemplates loads templates from dir. Files missing from dir fall
+// back to the built-in templates.
+func LoadEmailTemplates(dir string) (*EmailTemplates, error) {
+	templates := DefaultEmailTemplates()
+
+	custom, err := parseEmailTemplates(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+	if custom.subject != nil {
+		templates.subject = custom.subject
+	}
+	if custom.text != nil {
+		templates.text = custom.text
+	}
+	if custom.html != nil {
+		templates.html = custom.html
+	}
+
+	return templates, nil
+}
+
+// parseEmailTemplates parses the email templates present in dir of fsys
+func parseEmailTemplates(fsys fs.FS, dir string) (*EmailTemplates, error) {
+	read := func(name string) (string, bool, error) {
+		data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read email template %s: %w", name, err)
+		}
+		return string(data), true, nil
+	}
+
+	templates := &EmailTemplates{}
+
+	if src, ok, err := read(EmailSubjectTemplate); err != nil {
+		return nil, err
+	} else if ok {
+		if templates.subject, err = texttemplate.New(EmailSubjectTemplate).Funcs(templateFuncs).Parse(src); err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", EmailSubjectTemplate, err)
+		}
+	}
+
+	if src, ok, err := read(EmailTextTemplate); err != nil {
+		return nil, err
+	} else if ok {
+		if templates.text, err = texttemplate.New(EmailTextTemplate).Funcs(templateFuncs).Parse(src); err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", EmailTextTemplate, err)
+		}
+	}
+
+	if src, ok, err := read(EmailHTMLTemplate); err != nil {
+		return nil, err
+	} else if ok {
+		if templates.html, err = htmltemplate.New(EmailHTMLTemplate).Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(src); err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s: %w", EmailHTMLTemplate, err)
+		}
+	}
+
+	return templates, nil
+}
+
+// Render renders the subject, plain-text body and HTML body
+func (t *EmailTemplates) Render(data EmailData) (subject, text, html string, err error) {
+	var buf bytes.Buffer
+	if err := t.subject.Execute(&buf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render email subject: %w", err)
+	}
+	// Headers cannot span lines
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := t.text.Execute(&buf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render email text body: %w", err)
+	}
+	text = buf.String()
+
+	buf.Reset()
+	if err := t.html.Execute(&buf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render email HTML body: %w", err)
+	}
+	html = buf.String()
+
+	return subject, text, html, nil
+}
+
+// EmailNotifier sends multipart emails over SMTP
+type EmailNotifier struct {
+	host         string
+	port         int
+	from         string
+	to           []string
+	username     string
+	password     string
+	tlsMode      TLSMode
+	tlsConfig    *tls.Config
+	timeout      time.Duration
+	templates    *EmailTemplates
+	messages     TemplateSource
+	history      HistoryProvider
+	historyLimit int
+}
+
+// EmailOption is a function that configures an EmailNotifier
+type EmailOption func(*EmailNotifier)
+
+// WithEmailPort overrides the default port for the TLS mode
+func WithEmailPort(port int) EmailOption {
+	return func(n *EmailNotifier) {
+		if port > 0 {
+			n.port = port
+		}
+	}
+}
+
+// WithEmailAuth enables SMTP PLAIN authentication
+func WithEmailAuth(username, password string) EmailOption {
+	return func(n *EmailNotifier) {
+		n.username = username
+		n.password = password
+	}
+}
+
+// WithEmailTLS sets how the connection is secured
+func WithEmailTLS(mode TLSMode) EmailOption {
+	return func(n *EmailNotifier) {
+		if mode != "" {
+			n.tlsMode = mode
+		}
+	}
+}
+
+// WithEmailTLSConfig sets a custom TLS configuration, e.g. for a private CA
+func WithEmailTLSConfig(config *tls.Config) EmailOption {
+	return func(n *EmailNotifier) {
+		n.tlsConfig = config
+	}
+}
+
+// WithEmailTimeout sets the timeout for connecting and sending a message
+func WithEmailTimeout(timeout time.Duration) EmailOption {
+	return func(n *EmailNotifier) {
+		if timeout > 0 {
+			n.timeout = timeout
+		}
+	}
+}
+
+// WithEmailTemplates sets the email templates
+func WithEmailTemplates(templates *EmailTemplates) EmailOption {
+	return func(n *EmailNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// WithEmailMessageTemplates sets where the message template of the channel
+// comes from. A custom message template replaces the email templates: its
+// title becomes the subject and its text the body. Either way the source's
+// incident URL is available to the email templates.
+func WithEmailMessageTemplates(templates TemplateSource) EmailOption {
+	return func(n *EmailNotifier) {
+		n.messages = templates
+	}
+}
+
+// WithEmailHistory includes the last limit status logs of the service in every email
+func WithEmailHistory(provider HistoryProvider, limit int) EmailOption {
+	return func(n *EmailNotifier) {
+		n.history = provider
+		if limit > 0 {
+			n.historyLimit = limit
+		}
+	}
+}
+
+// NewEmailNotifier creates an email notifier sending from one address to several recipients
+func NewEmailNotifier(host, from string, to []string, options ...EmailOption) *EmailNotifier {
+	n := &EmailNotifier{
+		host:         host,
+		from:         from,
+		to:           to,
+		tlsMode:      TLSModeStartTLS,
+		timeout:      30 * time.Second,
+		templates:    DefaultEmailTemplates(),
+		historyLimit: 5,
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	if n.port == 0 {
+		n.port = defaultSMTPPort(n.tlsMode)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Notify renders and sends the email for event
+func (n *EmailNotifier) Notify(ctx context.Context, event Event) error {
+	if len(n.to) == 0 {
+		return fmt.Errorf("email notifier has no recipients")
+	}
+
+	data := EmailData{
+		Event:      event,
+		IsRecovery: event.Type == EventRecovered,
+		Color:      fmt.Sprintf("#%06X", eventColor(event.Type)),
+	}
+	if len(event.Grouped) == 0 {
+		data.History = n.recentHistory(ctx, event.ServiceName)
+	}
+
+	var custom *MessageTemplate
+	if n.messages != nil {
+		tmpl := n.messages.Template(ctx, n.Name())
+		data.IncidentURL = incidentURL(tmpl.incidentURL, event)
+		if tmpl.custom {
+			custom = tmpl
+		}
+	}
+
+	var subject, text, html string
+	var err error
+	if custom != nil {
+		subject, text, html, err = renderEmailMessage(custom, event)
+	} else {
+		subject, text, html, err = n.templates.Render(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	message, err := buildEmail(n.from, n.to, subject, text, html, event.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return n.send(ctx, message)
+}
+
+// renderEmailMessage renders an email from a message template, with the
+// text as the plain-text body and, escaped, as the HTML body
+func renderEmailMessage(tmpl *MessageTemplate, event Event) (subject, text, html string, err error) {
+	msg, err := tmpl.Render(event)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	html = `<pre style="font-family: sans-serif; white-space: pre-wrap">` + htmltemplate.HTMLEscapeString(msg.Text) + "</pre>"
+	return strings.Join(strings.Fields(msg.Title), " "), msg.Text, html, nil
+}
+
+// recentHistory loads the latest status logs; failures only cost the context section
+func (n *EmailNotifier) recentHistory(ctx context.Context, serviceName string) []*service.StatusLog {
+	if n.history == nil {
+		return nil
+	}
+
+	history, err := n.history.GetStatusHistory(ctx, serviceName, n.historyLimit)
+	if err != nil {
+		log := logger.Get()
+		log.Warn(ctx, "Failed to load status history for email", logger.Fields{
+			"service_name": serviceName,
+			"error":        err.Error(),
+		})
+		return nil
+	}
+
+	return history
+}
+
+// send delivers message over a new SMTP connection
+func (n *EmailNotifier) send(ctx context.Context, message []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	dialer := &net.Dialer{}
+
+	var conn net.Conn
+	var err error
+	if n.tlsMode == TLSModeImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: n.clientTLSConfig()}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	// Bound the whole conversation by the context deadline
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if n.tlsMode == TLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(n.clientTLSConfig()); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if n.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	from, err := envelopeAddress(n.from)
+	if err != nil {
+		return err
+	}
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	for _, to := range n.to {
+		rcpt, err := envelopeAddress(to)
+		if err != nil {
+			return err
+		}
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// clientTLSConfig returns the TLS configuration, verifying the server name by default
+func (n *EmailNotifier) clientTLSConfig() *tls.Config {
+	if n.tlsConfig != nil {
+		config := n.tlsConfig.Clone()
+		if config.ServerName == "" {
+			config.ServerName = n.host
+		}
+		return config
+	}
+	return &tls.Config{ServerName: n.host, MinVersion: tls.VersionTLS12}
+}
+
+// envelopeAddress extracts the bare address from a header address such as "Ops <ops@example.com>"
+func envelopeAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid email address %q: %w", address, err)
+	}
+	return parsed.Address, nil
+}
+
+// defaultSMTPPort returns the conventional port for a TLS mode
+func defaultSMTPPort(mode TLSMode) int {
+	switch mode {
+	case TLSModeImplicit:
+		return 465
+	case TLSModeNone:
+		return 25
+	default:
+		return 587
+	}
+}
+
+// buildEmail assembles a multipart/alternative message with text and HTML parts
+func buildEmail(from string, to []string, subject, text, html string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		w, err := parts.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to encode email part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode email part: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish email: %w", err)
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var message bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", "<" + newMessageID() + "@" + messageIDDomain(from) + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, h := range headers {
+		message.WriteString(h.key + ": " + h.value + "\r\n")
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+// newMessageID returns a random Message-ID local part
+func newMessageID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// messageIDDomain uses the sender's domain for the Message-ID
+func messageIDDomain(from string) string {
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+	return "uptime-monitor"
+}
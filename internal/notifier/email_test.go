@@ -0,0 +1,424 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	stderrors "errors"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+// smtpMessage is a message accepted by the SMTP stand-in
+type smtpMessage struct {
+	From string
+	To   []string
+	Auth string
+	TLS  bool
+	Data string
+}
+
+// smtpStandIn is a minimal in-process SMTP server supporting STARTTLS,
+// implicit TLS and AUTH PLAIN
+type smtpStandIn struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	startTLS  bool
+
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+// newSMTPStandIn starts a stand-in; implicit wraps every connection in TLS
+func newSMTPStandIn(t *testing.T, implicit, startTLS bool) *smtpStandIn {
+	t.Helper()
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{selfSignedCertificate(t)}}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	if implicit {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	s := &smtpStandIn{listener: listener, tlsConfig: tlsConfig, startTLS: startTLS}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, implicit)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpStandIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) Messages() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func (s *smtpStandIn) serve(conn net.Conn, secure bool) {
+	defer func() { _ = conn.Close() }()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = io.WriteString(conn, line+"\r\n")
+	}
+
+	var msg smtpMessage
+	msg.TLS = secure
+	reply("220 stand-in ESMTP")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			if s.startTLS && !msg.TLS {
+				reply("250-stand-in")
+				reply("250-STARTTLS")
+			} else {
+				reply("250-stand-in")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			msg.TLS = true
+		case "AUTH":
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				decoded, _ := base64.StdEncoding.DecodeString(fields[2])
+				msg.Auth = string(decoded)
+			}
+			reply("235 authenticated")
+		case "MAIL":
+			msg.From = strings.Trim(strings.TrimPrefix(line[5:], "FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			msg.To = append(msg.To, strings.Trim(strings.TrimPrefix(line[5:], "TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			msg.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+// selfSignedCertificate returns a certificate valid for 127.0.0.1
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "stand-in"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// trustStandIn returns a client TLS config trusting the stand-in certificate
+func trustStandIn(t *testing.T, s *smtpStandIn) *tls.Config {
+	t.Helper()
+
+	cert, err := x509.ParseCertificate(s.tlsConfig.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+}
+
+// historyStub returns fixed status logs
+type historyStub struct {
+	logs []*service.StatusLog
+	err  error
+}
+
+func (h *historyStub) GetStatusHistory(ctx context.Context, serviceName string, limit int) ([]*service.StatusLog, error) {
+	if len(h.logs) > limit {
+		return h.logs[:limit], h.err
+	}
+	return h.logs, h.err
+}
+
+// parsedEmail is a decoded multipart email
+type parsedEmail struct {
+	Header mail.Header
+	Text   string
+	HTML   string
+}
+
+func parseEmail(t *testing.T, data string) parsedEmail {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parsed := parsedEmail{Header: msg.Header}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if stderrors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		// NextPart decodes quoted-printable transparently
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		switch {
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain"):
+			parsed.Text = string(body)
+		case strings.HasPrefix(part.Header.Get("Content-Type"), "text/html"):
+			parsed.HTML = string(body)
+		}
+	}
+
+	return parsed
+}
+
+func testHistory() *historyStub {
+	base := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	return &historyStub{logs: []*service.StatusLog{
+		{ServiceName: "API", Status: "down", StatusCode: 503, Error: "unexpected status code", Timestamp: base.Add(2 * time.Minute)},
+		{ServiceName: "API", Status: "operational", Latency: 87, StatusCode: 200, Timestamp: base.Add(time.Minute)},
+		{ServiceName: "API", Status: "operational", Latency: 91, StatusCode: 200, Timestamp: base},
+	}}
+}
+
+func TestEmailNotifier_StartTLS(t *testing.T) {
+	standIn := newSMTPStandIn(t, false, true)
+
+	n := NewEmailNotifier("127.0.0.1", "Uptime Monitor <alerts@example.com>", []string{"ops@example.com", "Dev <dev@example.com>"},
+		WithEmailPort(standIn.port()),
+		WithEmailTLSConfig(trustStandIn(t, standIn)),
+		WithEmailAuth("alerts", "s3cret"),
+		WithEmailHistory(testHistory(), 2),
+	)
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	messages := standIn.Messages()
+	require.Len(t, messages, 1)
+	msg := messages[0]
+	assert.True(t, msg.TLS)
+	assert.Equal(t, "\x00alerts\x00s3cret", msg.Auth)
+	assert.Equal(t, "alerts@example.com", msg.From)
+	assert.Equal(t, []string{"ops@example.com", "dev@example.com"}, msg.To)
+
+	email := parseEmail(t, msg.Data)
+	assert.Equal(t, "[DOWN] API is down", email.Header.Get("Subject"))
+	assert.Equal(t, "Uptime Monitor <alerts@example.com>", email.Header.Get("From"))
+	assert.Equal(t, "ops@example.com, Dev <dev@example.com>", email.Header.Get("To"))
+	assert.Contains(t, email.Header.Get("Message-ID"), "@example.com>")
+
+	assert.Contains(t, email.Text, "API changed from operational to down at 2024-01-02 03:04:05 UTC.")
+	assert.Contains(t, email.Text, "Error: unexpected status code")
+	assert.Contains(t, email.Text, "Recent checks:")
+	assert.Contains(t, email.Text, "2024-01-02 03:01:00  operational  87 ms  HTTP 200")
+	assert.NotContains(t, email.Text, "91 ms", "history is limited")
+
+	assert.Contains(t, email.HTML, "<strong>operational</strong> to <strong>down</strong>")
+	assert.Contains(t, email.HTML, "<td>87 ms</td>")
+	assert.Contains(t, email.HTML, "color: #D00000")
+}
+
+func TestEmailNotifier_ImplicitTLS(t *testing.T) {
+	standIn := newSMTPStandIn(t, true, false)
+
+	event := testEvent()
+	event.Type = EventRecovered
+	event.Status = "operational"
+	event.PreviousStatus = "down"
+	event.Error = ""
+
+	n := NewEmailNotifier("127.0.0.1", "alerts@example.com", []string{"ops@example.com"},
+		WithEmailTLS(TLSModeImplicit),
+		WithEmailPort(standIn.port()),
+		WithEmailTLSConfig(trustStandIn(t, standIn)),
+	)
+	require.NoError(t, n.Notify(context.Background(), event))
+
+	messages := standIn.Messages()
+	require.Len(t, messages, 1)
+	assert.True(t, messages[0].TLS)
+	assert.Empty(t, messages[0].Auth)
+
+	email := parseEmail(t, messages[0].Data)
+	assert.Equal(t, "[Resolved] API is operational again", email.Header.Get("Subject"))
+	assert.NotContains(t, email.Text, "Recent checks")
+}
+
+func TestEmailNotifier_RequiresStartTLS(t *testing.T) {
+	standIn := newSMTPStandIn(t, false, false)
+
+	n := NewEmailNotifier("127.0.0.1", "alerts@example.com", []string{"ops@example.com"}, WithEmailPort(standIn.port()))
+	err := n.Notify(context.Background(), testEvent())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support STARTTLS")
+	assert.Empty(t, standIn.Messages())
+}
+
+func TestEmailNotifier_PlainRelay(t *testing.T) {
+	standIn := newSMTPStandIn(t, false, false)
+
+	n := NewEmailNotifier("127.0.0.1", "alerts@example.com", []string{"ops@example.com"},
+		WithEmailTLS(TLSModeNone),
+		WithEmailPort(standIn.port()),
+		WithEmailHistory(&historyStub{err: stderrors.New("database unavailable")}, 5),
+	)
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	messages := standIn.Messages()
+	require.Len(t, messages, 1)
+	assert.False(t, messages[0].TLS)
+}
+
+func TestEmailNotifier_MessageTemplates(t *testing.T) {
+	standIn := newSMTPStandIn(t, false, false)
+	event := testEvent()
+	event.AlertID = "42"
+
+	send := func(tmpl *MessageTemplate) parsedEmail {
+		n := NewEmailNotifier("127.0.0.1", "alerts@example.com", []string{"ops@example.com"},
+			WithEmailTLS(TLSModeNone),
+			WithEmailPort(standIn.port()),
+			WithEmailMessageTemplates(tmpl.WithIncidentURL("https://status.example.com/alerts/{alert_id}")),
+		)
+		require.NoError(t, n.Notify(context.Background(), event))
+
+		messages := standIn.Messages()
+		return parseEmail(t, messages[len(messages)-1].Data)
+	}
+
+	// The email templates get the incident link
+	email := send(DefaultMessageTemplate())
+	assert.Equal(t, "[DOWN] API is down", email.Header.Get("Subject"))
+	assert.Contains(t, email.Text, "Incident: https://status.example.com/alerts/42")
+	assert.Contains(t, email.HTML, `<a href="https://status.example.com/alerts/42">`)
+
+	// A custom message template replaces them
+	custom, err := ParseMessageTemplate(`{{define "title"}}{{.ServiceName}} <{{.Status}}>{{end}}{{define "text"}}Check {{.IncidentURL}}{{end}}`)
+	require.NoError(t, err)
+	email = send(custom)
+	assert.Equal(t, "API <down>", email.Header.Get("Subject"))
+	assert.Equal(t, "Check https://status.example.com/alerts/42", strings.TrimSpace(email.Text))
+	assert.Contains(t, email.HTML, "Check https://status.example.com/alerts/42</pre>")
+	assert.NotContains(t, email.HTML, "<table")
+}
+
+func TestEmailNotifier_InvalidRecipient(t *testing.T) {
+	standIn := newSMTPStandIn(t, false, false)
+
+	n := NewEmailNotifier("127.0.0.1", "alerts@example.com", []string{"not an address"},
+		WithEmailTLS(TLSModeNone),
+		WithEmailPort(standIn.port()),
+	)
+
+	assert.Error(t, n.Notify(context.Background(), testEvent()))
+	assert.Error(t, NewEmailNotifier("127.0.0.1", "alerts@example.com", nil).Notify(context.Background(), testEvent()))
+}
+
+func TestLoadEmailTemplates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, EmailSubjectTemplate), []byte("{{.ServiceSlug}}:\n{{.Status}}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, EmailHTMLTemplate), []byte("<p>{{.Error}}</p>"), 0o600))
+
+	templates, err := LoadEmailTemplates(dir)
+	require.NoError(t, err)
+
+	event := testEvent()
+	event.Error = "<script>alert(1)</script>"
+	subject, text, html, err := templates.Render(EmailData{Event: event})
+	require.NoError(t, err)
+
+	assert.Equal(t, "api: down", subject)
+	assert.Contains(t, text, "API changed from operational to down", "text template falls back to default")
+	assert.Equal(t, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>", html)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, EmailTextTemplate), []byte("{{.Status"), 0o600))
+	_, err = LoadEmailTemplates(dir)
+	assert.Error(t, err)
+}
+
+func TestDefaultSMTPPort(t *testing.T) {
+	assert.Equal(t, 587, defaultSMTPPort(TLSModeStartTLS))
+	assert.Equal(t, 465, defaultSMTPPort(TLSModeImplicit))
+	assert.Equal(t, 25, defaultSMTPPort(TLSModeNone))
+}
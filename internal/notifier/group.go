@@ -0,0 +1,536 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// Keys services can be grouped by
+const (
+	GroupByGroup = "group"
+	GroupByTags  = "tags"
+)
+
+const (
+	defaultGroupWait          = 30 * time.Second
+	defaultGroupInterval      = 5 * time.Minute
+	defaultGroupCheckInterval = time.Second
+)
+
+// Grouper is a Queue that batches failures of services sharing the same
+// grouping keys and channels into a single digest notification.
+//
+// The first failure of a group opens it, and the group is sent once its
+// wait has passed, so the services failing in the same check cycle arrive
+// together. A group holding a single service sends that service's event
+// unchanged. Afterwards, services joining the group and recoveries of its
+// members are sent as update digests at most once per interval, until every
+// member has recovered. A member recovering before the group is sent is
+// dropped from it, since its failure was never announced.
+//
+// Repeats and escalations are sent per service, outside any group. Since
+// they open an incident under the service's own dedup key, the recovery of
+// a member is also sent by itself on the channels they reached, as well as
+// on channels no group holding the service covers.
+//
+// Groups are held in memory until they are sent, and only then handed to
+// the next queue. Close sends the pending groups, but a crash during the
+// wait loses the first notification of the failures they hold.
+type Grouper struct {
+	by       []string
+	wait     time.Duration
+	interval time.Duration
+	check    time.Duration
+	services ServiceLookup
+	next     Queue
+	channels map[string]Notifier
+	logger   logger.Logger
+	now      func() time.Time
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	groups map[string]*notificationGroup
+	// opened holds the channels each service was notified on by itself,
+	// keyed by dedup key, until it recovers
+	opened map[string]map[string]bool
+}
+
+// notificationGroup holds the services notified together on a set of channels
+type notificationGroup struct {
+	label    string
+	channels []string
+	members  map[string]*groupMember
+	order    []string
+	// dueAt is when the pending changes are sent; zero when there are none
+	dueAt time.Time
+	// sentAt is when the group was last sent; zero until it is first sent
+	sentAt time.Time
+}
+
+// groupMember is the latest event of a service in a group
+type groupMember struct {
+	event   Event
+	changed bool
+}
+
+// GroupOption is a function that configures a Grouper
+type GroupOption func(*Grouper)
+
+// WithGroupBy sets the service keys that split groups: GroupByGroup and
+// GroupByTags. Without keys, every service notified on the same channels
+// shares a group.
+func WithGroupBy(keys ...string) GroupOption {
+	return func(g *Grouper) {
+		g.by = keys
+	}
+}
+
+// WithGroupWait sets how long a new group collects failures before it is sent
+func WithGroupWait(wait time.Duration) GroupOption {
+	return func(g *Grouper) {
+		if wait > 0 {
+			g.wait = wait
+		}
+	}
+}
+
+// WithGroupInterval sets the minimum time between updates of a group
+func WithGroupInterval(interval time.Duration) GroupOption {
+	return func(g *Grouper) {
+		if interval > 0 {
+			g.interval = interval
+		}
+	}
+}
+
+// WithGroupCheckInterval sets how often groups are checked for pending changes
+func WithGroupCheckInterval(interval time.Duration) GroupOption {
+	return func(g *Grouper) {
+		if interval > 0 {
+			g.check = interval
+		}
+	}
+}
+
+// WithGroupServices resolves the group and tags of services from lookup
+func WithGroupServices(lookup ServiceLookup) GroupOption {
+	return func(g *Grouper) {
+		g.services = lookup
+	}
+}
+
+// WithGroupQueue hands grouped notifications to q, such as an
+// OutboxDispatcher, instead of sending them directly
+func WithGroupQueue(q Queue) GroupOption {
+	return func(g *Grouper) {
+		g.next = q
+	}
+}
+
+// WithGroupLogger sets the logger used to report sent groups
+func WithGroupLogger(log logger.Logger) GroupOption {
+	return func(g *Grouper) {
+		g.logger = log
+	}
+}
+
+// WithGroupClock overrides the clock used to schedule groups
+func WithGroupClock(now func() time.Time) GroupOption {
+	return func(g *Grouper) {
+		g.now = now
+	}
+}
+
+// NewGrouper creates a grouper over the given channels and starts the loop
+// that sends due groups. Notifications refer to channels by their Name.
+// Close must be called to stop the loop.
+func NewGrouper(channels []Notifier, options ...GroupOption) *Grouper {
+	g := &Grouper{
+		wait:     defaultGroupWait,
+		interval: defaultGroupInterval,
+		check:    defaultGroupCheckInterval,
+		channels: make(map[string]Notifier, len(channels)),
+		logger:   logger.Get(),
+		now:      time.Now,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		groups:   make(map[string]*notificationGroup),
+		opened:   make(map[string]map[string]bool),
+	}
+
+	for _, n := range channels {
+		g.channels[n.Name()] = n
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	go g.run()
+
+	return g
+}
+
+// Enqueue adds the event to the group of its service and channels. Events
+// that are not grouped are passed on immediately.
+func (g *Grouper) Enqueue(ctx context.Context, event Event, channels []string) error {
+	if len(event.Grouped) > 0 {
+		return g.forward(ctx, event, channels)
+	}
+
+	member := dedupKey(event)
+	if event.Repeat || event.EscalationLevel > 0 {
+		g.mu.Lock()
+		if g.opened[member] == nil {
+			g.opened[member] = make(map[string]bool)
+		}
+		for _, name := range channels {
+			g.opened[member][name] = true
+		}
+		g.mu.Unlock()
+		return g.forward(ctx, event, channels)
+	}
+
+	label := g.label(ctx, event)
+	channels = slices.Sorted(slices.Values(channels))
+	key := label + "|" + strings.Join(channels, ",")
+	now := g.now()
+
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return g.forward(ctx, event, channels)
+	}
+
+	if event.Type == EventRecovered {
+		direct := g.recover(label, event, channels)
+		g.mu.Unlock()
+		if len(direct) == 0 {
+			return nil
+		}
+		return g.forward(ctx, event, direct)
+	}
+
+	group := g.groups[key]
+	if group == nil {
+		group = &notificationGroup{
+			label:    label,
+			channels: channels,
+			members:  make(map[string]*groupMember),
+			dueAt:    now.Add(g.wait),
+		}
+		g.groups[key] = group
+	}
+
+	if _, known := group.members[member]; !known {
+		group.order = append(group.order, member)
+	}
+	group.members[member] = &groupMember{event: event, changed: true}
+	if group.dueAt.IsZero() {
+		group.dueAt = group.sentAt.Add(g.interval)
+	}
+	g.mu.Unlock()
+
+	return nil
+}
+
+// recover records the recovery of a service in the groups holding it and
+// returns the channels it is sent on by itself: those no such group covers,
+// and those the service was notified on by itself. A group that was not sent
+// yet drops the service, since its failure was never announced there.
+// The caller must hold g.mu.
+func (g *Grouper) recover(label string, event Event, channels []string) []string {
+	member := dedupKey(event)
+
+	grouped := make(map[string]bool)
+	for key, group := range g.groups {
+		if group.label != label || group.members[member] == nil {
+			continue
+		}
+		for _, name := range group.channels {
+			grouped[name] = true
+		}
+
+		if group.sentAt.IsZero() {
+			group.remove(member)
+			if len(group.members) == 0 {
+				delete(g.groups, key)
+			}
+			continue
+		}
+
+		group.members[member] = &groupMember{event: event, changed: true}
+		if group.dueAt.IsZero() {
+			group.dueAt = group.sentAt.Add(g.interval)
+		}
+	}
+
+	opened := g.opened[member]
+	delete(g.opened, member)
+
+	var direct []string
+	for _, name := range channels {
+		if !grouped[name] || opened[name] {
+			direct = append(direct, name)
+		}
+	}
+	return direct
+}
+
+// Flush sends the groups whose changes are due
+func (g *Grouper) Flush(ctx context.Context) error {
+	return g.flush(ctx, false)
+}
+
+// Close stops the loop and sends every group with pending changes
+func (g *Grouper) Close(ctx context.Context) error {
+	g.mu.Lock()
+	closing := !g.closed
+	if closing {
+		g.closed = true
+		close(g.stopCh)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-g.doneCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if !closing {
+		return nil
+	}
+	return g.flush(ctx, true)
+}
+
+// Len returns the number of open groups
+func (g *Grouper) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.groups)
+}
+
+func (g *Grouper) run() {
+	defer close(g.doneCh)
+
+	ticker := time.NewTicker(g.check)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case <-ticker.C:
+			if err := g.Flush(context.Background()); err != nil {
+				g.logger.Error(context.Background(), "Failed to send notification group", err, nil)
+			}
+		}
+	}
+}
+
+// flush sends the due groups, or every group with changes when all is set
+func (g *Grouper) flush(ctx context.Context, all bool) error {
+	type outgoing struct {
+		event    Event
+		channels []string
+		services int
+	}
+
+	now := g.now()
+
+	g.mu.Lock()
+	var batch []outgoing
+	for key, group := range g.groups {
+		if group.dueAt.IsZero() || (!all && now.Before(group.dueAt)) {
+			continue
+		}
+
+		members := group.events()
+		if group.sentAt.IsZero() && len(members) == 1 {
+			batch = append(batch, outgoing{event: members[0], channels: group.channels, services: 1})
+			delete(g.groups, key)
+			continue
+		}
+
+		digest := NewDigestEvent(group.label, members, !group.sentAt.IsZero(), now)
+		batch = append(batch, outgoing{event: digest, channels: group.channels, services: len(members)})
+
+		group.sentAt = now
+		group.dueAt = time.Time{}
+		for _, event := range members {
+			if event.Type == EventRecovered {
+				group.remove(dedupKey(event))
+			} else {
+				group.members[dedupKey(event)].changed = false
+			}
+		}
+		if len(group.members) == 0 {
+			delete(g.groups, key)
+		}
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for _, out := range batch {
+		if out.services > 1 {
+			g.logger.Info(ctx, "Notification group sent", logger.Fields{
+				"group":      out.event.GroupKey,
+				"event_type": string(out.event.Type),
+				"services":   out.services,
+				"update":     out.event.GroupUpdate,
+				"channels":   out.channels,
+			})
+		}
+		if err := g.forward(ctx, out.event, out.channels); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// forward queues the event on the next queue, or sends it directly when
+// there is none or it cannot be written to
+func (g *Grouper) forward(ctx context.Context, event Event, channels []string) error {
+	if g.next != nil {
+		err := g.next.Enqueue(ctx, event, channels)
+		if err == nil {
+			return nil
+		}
+		g.logger.Error(ctx, "Failed to queue notification, sending directly", err, logger.Fields{
+			"service_name": event.ServiceName,
+			"event_id":     event.ID,
+		})
+	}
+
+	targets := make([]Notifier, 0, len(channels))
+	for _, name := range channels {
+		if n, ok := g.channels[name]; ok {
+			targets = append(targets, n)
+		}
+	}
+	return NewDispatcher(targets...).Notify(ctx, event)
+}
+
+// label describes the group of the event's service by the grouping keys,
+// e.g. "group=platform"
+func (g *Grouper) label(ctx context.Context, event Event) string {
+	if len(g.by) == 0 {
+		return "all"
+	}
+
+	svc := &service.Service{Name: event.ServiceName, Slug: event.ServiceSlug}
+	if g.services != nil && event.ServiceSlug != "" {
+		if found, err := g.services.GetBySlug(ctx, event.ServiceSlug); err == nil {
+			svc = found
+		}
+	}
+
+	parts := make([]string, 0, len(g.by))
+	for _, key := range g.by {
+		var value string
+		switch key {
+		case GroupByGroup:
+			value = svc.Group
+		case GroupByTags:
+			tags := append([]string(nil), svc.Tags...)
+			sort.Strings(tags)
+			value = strings.Join(tags, ",")
+		}
+		parts = append(parts, key+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// events returns the latest event of each member in the order they joined.
+// Members without changes since the group was last sent are marked as repeats.
+func (group *notificationGroup) events() []Event {
+	events := make([]Event, 0, len(group.order))
+	for _, key := range group.order {
+		member := group.members[key]
+		event := member.event
+		if !member.changed {
+			event.Repeat = true
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func (group *notificationGroup) remove(member string) {
+	delete(group.members, member)
+	group.order = slices.DeleteFunc(group.order, func(key string) bool {
+		return key == member
+	})
+}
+
+// NewDigestEvent builds the notification standing for the events of a group
+// labelled label. Its type and status are the worst among the failing
+// services, or a recovery when every service has recovered. Update marks
+// follow-ups to a digest sent earlier.
+func NewDigestEvent(label string, events []Event, update bool, at time.Time) Event {
+	digest := Event{
+		ID:             newEventID(),
+		Type:           EventRecovered,
+		ServiceName:    label,
+		ServiceSlug:    "group:" + label,
+		Status:         service.StatusOperational,
+		PreviousStatus: service.StatusDegraded,
+		Timestamp:      at.UTC(),
+		GroupKey:       label,
+		Grouped:        events,
+		GroupUpdate:    update,
+	}
+
+	for _, event := range events {
+		switch {
+		case event.Type == EventDown:
+			digest.Type = EventDown
+			digest.Status = service.StatusDown
+		case event.Type == EventDegraded && digest.Type != EventDown:
+			digest.Type = EventDegraded
+			digest.Status = service.StatusDegraded
+		case event.Type == EventRecovered && event.PreviousStatus == service.StatusDown:
+			digest.PreviousStatus = service.StatusDown
+		}
+	}
+	if digest.Type != EventRecovered {
+		digest.PreviousStatus = service.StatusOperational
+	}
+
+	return digest
+}
+
+// Failing returns the grouped events of services that are still failing
+func (e Event) Failing() []Event {
+	var failing []Event
+	for _, event := range e.Grouped {
+		if event.Type != EventRecovered {
+			failing = append(failing, event)
+		}
+	}
+	return failing
+}
+
+// Recovered returns the grouped events of services that have recovered
+func (e Event) Recovered() []Event {
+	var recovered []Event
+	for _, event := range e.Grouped {
+		if event.Type == EventRecovered {
+			recovered = append(recovered, event)
+		}
+	}
+	return recovered
+}
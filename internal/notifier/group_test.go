@@ -0,0 +1,372 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+type groupFixture struct {
+	grouper *Grouper
+	slack   *recordingNotifier
+	pager   *recordingNotifier
+
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newGroupFixture(t *testing.T, options ...GroupOption) *groupFixture {
+	t.Helper()
+
+	services := memory.NewServiceRepository()
+	for _, svc := range []*service.Service{
+		{Name: "API", Slug: "api", Group: "platform"},
+		{Name: "Web", Slug: "web", Group: "platform"},
+		{Name: "Billing", Slug: "billing", Group: "platform"},
+		{Name: "Blog", Slug: "blog", Group: "marketing"},
+	} {
+		svc.URL = "https://" + svc.Slug + ".example.com"
+		svc.ExpectedStatus = 200
+		svc.Enabled = true
+		require.NoError(t, services.Create(context.Background(), svc))
+	}
+
+	f := &groupFixture{
+		slack: &recordingNotifier{name: "slack"},
+		pager: &recordingNotifier{name: "pagerduty"},
+		now:   time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+	options = append([]GroupOption{
+		WithGroupBy(GroupByGroup),
+		WithGroupWait(30 * time.Second),
+		WithGroupInterval(5 * time.Minute),
+		WithGroupCheckInterval(time.Hour),
+		WithGroupServices(services),
+		WithGroupClock(f.clock),
+	}, options...)
+	f.grouper = NewGrouper([]Notifier{f.slack, f.pager}, options...)
+	t.Cleanup(func() {
+		_ = f.grouper.Close(context.Background())
+	})
+	return f
+}
+
+func (f *groupFixture) clock() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *groupFixture) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *groupFixture) enqueue(t *testing.T, event Event, channels ...string) {
+	t.Helper()
+	if len(channels) == 0 {
+		channels = []string{"slack"}
+	}
+	require.NoError(t, f.grouper.Enqueue(context.Background(), event, channels))
+}
+
+func (f *groupFixture) flush(t *testing.T) {
+	t.Helper()
+	require.NoError(t, f.grouper.Flush(context.Background()))
+}
+
+func downEvent(name, slug string) Event {
+	event, _ := NewEvent(name, slug, service.StatusOperational, service.StatusDown)
+	return event
+}
+
+func upEvent(name, slug string) Event {
+	event, _ := NewEvent(name, slug, service.StatusDown, service.StatusOperational)
+	return event
+}
+
+func TestGrouper_DigestsFailuresWithinWait(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+	f.enqueue(t, downEvent("Blog", "blog"))
+	f.flush(t)
+	assert.Empty(t, f.slack.Events(), "groups wait before sending")
+
+	f.advance(30 * time.Second)
+	f.flush(t)
+
+	events := f.slack.Events()
+	require.Len(t, events, 2)
+	byGroup := map[string]Event{}
+	for _, event := range events {
+		byGroup[event.ServiceName] = event
+	}
+
+	digest := byGroup["group=platform"]
+	assert.Equal(t, EventDown, digest.Type)
+	assert.Equal(t, "group:group=platform", digest.ServiceSlug)
+	assert.False(t, digest.GroupUpdate)
+	require.Len(t, digest.Grouped, 2)
+	assert.Equal(t, "api", digest.Grouped[0].ServiceSlug)
+	assert.Equal(t, "web", digest.Grouped[1].ServiceSlug)
+
+	// A group of one sends the service's own event
+	assert.Equal(t, "Blog", byGroup["Blog"].ServiceName)
+	assert.Empty(t, byGroup["Blog"].Grouped)
+
+	assert.Empty(t, f.pager.Events())
+	assert.Equal(t, 1, f.grouper.Len())
+}
+
+func TestGrouper_SendsUpdatesAsServicesJoinAndRecover(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+	f.advance(30 * time.Second)
+	f.flush(t)
+	require.Len(t, f.slack.Events(), 1)
+
+	// Changes are held until the interval since the last digest has passed
+	f.advance(time.Minute)
+	f.enqueue(t, downEvent("Billing", "billing"))
+	f.enqueue(t, upEvent("API", "api"))
+	f.flush(t)
+	require.Len(t, f.slack.Events(), 1)
+
+	f.advance(4 * time.Minute)
+	f.flush(t)
+	events := f.slack.Events()
+	require.Len(t, events, 2)
+
+	update := events[1]
+	assert.True(t, update.GroupUpdate)
+	assert.Equal(t, EventDown, update.Type)
+	require.Len(t, update.Grouped, 3)
+	assert.Equal(t, EventRecovered, update.Grouped[0].Type)
+	assert.True(t, update.Grouped[1].Repeat, "web is unchanged since the last digest")
+	assert.False(t, update.Grouped[2].Repeat)
+	assert.Len(t, update.Failing(), 2)
+	assert.Len(t, update.Recovered(), 1)
+
+	// The group closes once its last members recover
+	f.enqueue(t, upEvent("Web", "web"))
+	f.enqueue(t, upEvent("Billing", "billing"))
+	f.advance(5 * time.Minute)
+	f.flush(t)
+	events = f.slack.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, EventRecovered, events[2].Type)
+	assert.Equal(t, service.StatusDown, events[2].PreviousStatus)
+	assert.Equal(t, events[0].ServiceSlug, events[2].ServiceSlug, "digests of a group share a dedup key")
+	assert.Equal(t, 0, f.grouper.Len())
+}
+
+func TestGrouper_DropsFailuresRecoveredBeforeSending(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, upEvent("API", "api"))
+	assert.Equal(t, 0, f.grouper.Len())
+
+	f.advance(time.Minute)
+	f.flush(t)
+	assert.Empty(t, f.slack.Events())
+}
+
+func TestGrouper_PassesThroughUngroupedEvents(t *testing.T) {
+	f := newGroupFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.grouper.Enqueue(ctx, repeatEvent(), []string{"slack"}))
+
+	escalation := downEvent("API", "api")
+	escalation.EscalationLevel = 2
+	require.NoError(t, f.grouper.Enqueue(ctx, escalation, []string{"pagerduty"}))
+
+	// A recovery of a service announced on its own is not held
+	require.NoError(t, f.grouper.Enqueue(ctx, upEvent("Web", "web"), []string{"slack"}))
+
+	assert.Len(t, f.slack.Events(), 2)
+	assert.Len(t, f.pager.Events(), 1)
+	assert.Equal(t, 0, f.grouper.Len())
+}
+
+func TestGrouper_RecoveryResolvesEveryOpenedIncident(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+	f.advance(30 * time.Second)
+	f.flush(t)
+	require.Len(t, f.slack.Events(), 1)
+
+	// The repeat and the escalation go out under the service's own dedup key
+	repeat := downEvent("API", "api")
+	repeat.Repeat = true
+	f.enqueue(t, repeat, "slack")
+	escalation := downEvent("API", "api")
+	escalation.EscalationLevel = 2
+	f.enqueue(t, escalation, "pagerduty")
+	require.Len(t, f.slack.Events(), 2)
+	require.Len(t, f.pager.Events(), 1)
+
+	// The recovery reaches the policy's channels and the escalated ones
+	f.enqueue(t, upEvent("API", "api"), "slack", "pagerduty")
+	f.enqueue(t, upEvent("Web", "web"))
+	f.advance(5 * time.Minute)
+	f.flush(t)
+
+	for _, channel := range []*recordingNotifier{f.slack, f.pager} {
+		open := map[string]bool{}
+		for _, event := range channel.Events() {
+			open[dedupKey(event)] = event.Type != EventRecovered
+		}
+		for key, failing := range open {
+			assert.False(t, failing, "%s left %s open", channel.name, key)
+		}
+	}
+	assert.Len(t, f.slack.Events(), 4)
+	assert.Len(t, f.pager.Events(), 2)
+	assert.Equal(t, 0, f.grouper.Len())
+}
+
+func TestGrouper_GroupsPerChannelSet(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"), "slack", "pagerduty")
+	f.enqueue(t, downEvent("Web", "web"), "pagerduty", "slack")
+	f.enqueue(t, downEvent("Billing", "billing"), "slack")
+	f.advance(30 * time.Second)
+	f.flush(t)
+
+	require.Len(t, f.pager.Events(), 1)
+	assert.Len(t, f.pager.Events()[0].Grouped, 2)
+	assert.Len(t, f.slack.Events(), 2)
+}
+
+func TestGrouper_WithoutKeysGroupsEverything(t *testing.T) {
+	f := newGroupFixture(t, WithGroupBy())
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Blog", "blog"))
+	f.advance(30 * time.Second)
+	f.flush(t)
+
+	events := f.slack.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "all", events[0].GroupKey)
+	assert.Len(t, events[0].Grouped, 2)
+}
+
+func TestGrouper_CloseSendsPendingGroups(t *testing.T) {
+	f := newGroupFixture(t)
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+	require.NoError(t, f.grouper.Close(context.Background()))
+
+	require.Len(t, f.slack.Events(), 1)
+	assert.Len(t, f.slack.Events()[0].Grouped, 2)
+
+	// Once closed, events are sent as they arrive
+	f.enqueue(t, downEvent("Billing", "billing"))
+	assert.Len(t, f.slack.Events(), 2)
+}
+
+func TestGrouper_QueuesThroughOutbox(t *testing.T) {
+	outbox := newOutboxFixture(t)
+	f := newGroupFixture(t, WithGroupQueue(outbox.dispatcher))
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+	f.advance(30 * time.Second)
+	f.flush(t)
+	assert.Empty(t, f.slack.Events())
+
+	outbox.flush(t)
+	events := outbox.slack.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "group=platform", events[0].GroupKey)
+	require.Len(t, events[0].Grouped, 2)
+	assert.Equal(t, "Web", events[0].Grouped[1].ServiceName)
+}
+
+func TestGrouper_PendingGroupsAreLostOnCrash(t *testing.T) {
+	outbox := newOutboxFixture(t)
+	f := newGroupFixture(t, WithGroupQueue(outbox.dispatcher))
+	ctx := context.Background()
+
+	stored := func() int64 {
+		counts, err := outbox.repo.Count(ctx)
+		require.NoError(t, err)
+		return counts[alert.DeliveryPending] + counts[alert.DeliveryDelivered]
+	}
+
+	f.enqueue(t, downEvent("API", "api"))
+	f.enqueue(t, downEvent("Web", "web"))
+
+	// During the wait the group only lives in memory, so a grouper started
+	// after a crash has nothing to send
+	f.advance(29 * time.Second)
+	f.flush(t)
+	assert.Zero(t, stored())
+
+	restarted := newGroupFixture(t, WithGroupQueue(outbox.dispatcher))
+	restarted.advance(time.Hour)
+	restarted.flush(t)
+	assert.Zero(t, stored())
+
+	// Once the wait has passed the group is in the outbox and survives a crash
+	f.advance(time.Second)
+	f.flush(t)
+	assert.Equal(t, int64(1), stored())
+}
+
+func TestDigest_Templates(t *testing.T) {
+	at := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	web := downEvent("Web", "web")
+	web.Repeat = true
+	api := upEvent("API", "api")
+	billing := downEvent("Billing", "billing")
+	billing.Error = "connection refused"
+
+	digest := NewDigestEvent("group=platform", []Event{api, web, billing}, true, at)
+
+	msg, err := DefaultMessageTemplate().Render(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: 2 services failing (group=platform)", msg.Title)
+	assert.Equal(t, "Update: 2 failing, 1 recovered.\n"+
+		"- API changed from down to operational\n"+
+		"- Web is still down\n"+
+		"- Billing changed from operational to down: connection refused", msg.Text)
+
+	payload := NewWebhookPayload(digest)
+	require.NotNil(t, payload.Group)
+	assert.Equal(t, "group=platform", payload.Group.Key)
+	assert.True(t, payload.Group.Update)
+	require.Len(t, payload.Group.Services, 3)
+	assert.False(t, payload.Group.Services[1].Changed)
+	assert.Equal(t, "connection refused", payload.Group.Services[2].Error)
+
+	subject, text, _, err := DefaultEmailTemplates().Render(EmailData{Event: digest})
+	require.NoError(t, err)
+	assert.Equal(t, "[DOWN] 2 services failing (group=platform)", subject)
+	assert.Contains(t, text, "Billing  operational -> down  connection refused")
+
+	resolved := NewDigestEvent("group=platform", []Event{upEvent("API", "api"), upEvent("Web", "web")}, true, at)
+	msg, err = DefaultMessageTemplate().Render(resolved)
+	require.NoError(t, err)
+	assert.Equal(t, "Resolved: 2 services are operational again (group=platform)", msg.Title)
+}
@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPClient interface for mocking HTTP requests
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// httpSender POSTs JSON bodies, retrying network errors, 429 and 5xx
+// responses with exponential backoff
+type httpSender struct {
+	name         string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// post delivers body to url; prepare is called on every attempt to set headers
+func (s *httpSender) post(ctx context.Context, url string, body []byte, prepare func(req *http.Request)) error {
+	backoff := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.attempt(ctx, url, body, prepare)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.maxRetries {
+			return fmt.Errorf("%s delivery failed after %d attempts: %w", s.name, attempt+1, err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("%s delivery cancelled: %w", s.name, ctx.Err())
+		}
+		backoff *= 2
+	}
+}
+
+// attempt makes a single delivery and reports whether a failure is retryable
+func (s *httpSender) attempt(ctx context.Context, url string, body []byte, prepare func(req *http.Request)) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create %s request: %w", s.name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if prepare != nil {
+		prepare(req)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("%s receiver returned status %d", s.name, resp.StatusCode)
+}
@@ -0,0 +1,202 @@
+// Package notifier delivers service state changes to external channels.
+package notifier
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+)
+
+// EventType identifies the kind of state change
+type EventType string
+
+const (
+	// EventDown is emitted when a service starts failing its health check
+	EventDown EventType = "service.down"
+	// EventDegraded is emitted when a service responds but not as expected
+	EventDegraded EventType = "service.degraded"
+	// EventRecovered is emitted when a failing service becomes operational again
+	EventRecovered EventType = "service.recovered"
+)
+
+// Event describes a change in a service's status
+type Event struct {
+	ID             string
+	Type           EventType
+	ServiceName    string
+	ServiceSlug    string
+	Status         string
+	PreviousStatus string
+	Latency        int64
+	StatusCode     int
+	Error          string
+	Timestamp      time.Time
+
+	// Repeat is set on repeat notifications for a service that is still failing
+	Repeat bool
+
+	// FailingSince is when the service's current outage began. It is set on
+	// failures, repeats and recoveries when known.
+	FailingSince time.Time
+
+	// AlertID identifies the alert the event belongs to, when alerts are tracked
+	AlertID string
+
+	// EscalationLevel is set on notifications sent because an alert stayed
+	// unacknowledged. The policy's own channels are level 1, so the first
+	// escalation step is level 2.
+	EscalationLevel int
+
+	// GroupKey, Grouped and GroupUpdate are set on digests standing for
+	// several services notified together. Grouped holds the latest event of
+	// each service, with Repeat set on those unchanged since the last digest
+	// of the group, and GroupUpdate marks follow-ups to that digest.
+	GroupKey    string
+	Grouped     []Event
+	GroupUpdate bool
+}
+
+// OutageDuration returns how long the service had been failing when the
+// event occurred, or zero when the start of the outage is unknown
+func (e Event) OutageDuration() time.Duration {
+	if e.FailingSince.IsZero() || e.Timestamp.Before(e.FailingSince) {
+		return 0
+	}
+	return e.Timestamp.Sub(e.FailingSince)
+}
+
+// Notifier delivers events to a single channel
+type Notifier interface {
+	// Name identifies the channel in logs and errors
+	Name() string
+
+	// Notify delivers the event, returning an error if delivery failed
+	Notify(ctx context.Context, event Event) error
+}
+
+// Repeater is implemented by notifiers that re-send notifications while a
+// service stays in a failing state. Repeat is called after every check that
+// finds a service still down or degraded; implementations decide whether
+// enough time has passed to notify again.
+type Repeater interface {
+	Repeat(ctx context.Context, event Event) error
+}
+
+// NewEvent builds the event for a transition from previous to current status.
+// It returns false when the transition does not warrant a notification.
+func NewEvent(name, slug, previous, current string) (Event, bool) {
+	if previous == current {
+		return Event{}, false
+	}
+
+	var eventType EventType
+	switch current {
+	case service.StatusDown:
+		eventType = EventDown
+	case service.StatusDegraded:
+		eventType = EventDegraded
+	case service.StatusOperational:
+		// A service seen for the first time as operational is not news
+		if previous == "" || previous == service.StatusUnknown {
+			return Event{}, false
+		}
+		eventType = EventRecovered
+	default:
+		return Event{}, false
+	}
+
+	if previous == "" {
+		previous = service.StatusUnknown
+	}
+
+	return Event{
+		ID:             newEventID(),
+		Type:           eventType,
+		ServiceName:    name,
+		ServiceSlug:    slug,
+		Status:         current,
+		PreviousStatus: previous,
+		Timestamp:      time.Now().UTC(),
+	}, true
+}
+
+// NewRepeatEvent builds a repeat notification for a service that is still in
+// a failing status. It returns false for statuses that do not alert.
+func NewRepeatEvent(name, slug, status string) (Event, bool) {
+	var eventType EventType
+	switch status {
+	case service.StatusDown:
+		eventType = EventDown
+	case service.StatusDegraded:
+		eventType = EventDegraded
+	default:
+		return Event{}, false
+	}
+
+	return Event{
+		ID:             newEventID(),
+		Type:           eventType,
+		ServiceName:    name,
+		ServiceSlug:    slug,
+		Status:         status,
+		PreviousStatus: status,
+		Timestamp:      time.Now().UTC(),
+		Repeat:         true,
+	}, true
+}
+
+// Dispatcher fans an event out to several notifiers concurrently
+type Dispatcher struct {
+	notifiers []Notifier
+}
+
+// NewDispatcher creates a dispatcher for the given notifiers
+func NewDispatcher(notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{
+		notifiers: notifiers,
+	}
+}
+
+// Name identifies the dispatcher
+func (d *Dispatcher) Name() string {
+	return "dispatcher"
+}
+
+// Notify delivers the event to every notifier and joins their errors
+func (d *Dispatcher) Notify(ctx context.Context, event Event) error {
+	errs := make([]error, len(d.notifiers))
+
+	var wg sync.WaitGroup
+	for i, n := range d.notifiers {
+		wg.Add(1)
+		go func(i int, n Notifier) {
+			defer wg.Done()
+			if err := n.Notify(ctx, event); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", n.Name(), err)
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	return stderrors.Join(errs...)
+}
+
+// Len returns the number of notifiers
+func (d *Dispatcher) Len() int {
+	return len(d.notifiers)
+}
+
+// newEventID returns a random identifier used to deduplicate deliveries
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
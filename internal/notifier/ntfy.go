@@ -0,0 +1,166 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultNtfyURL is the public ntfy server
+const DefaultNtfyURL = "https://ntfy.sh"
+
+// ntfy message priorities
+const (
+	ntfyPriorityDefault = 3
+	ntfyPriorityHigh    = 4
+	ntfyPriorityUrgent  = 5
+)
+
+// NtfyNotifier publishes messages to an ntfy topic. Failures are urgent,
+// degradations high priority and recoveries default priority.
+type NtfyNotifier struct {
+	serverURL    string
+	topic        string
+	token        string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// NtfyOption is a function that configures an NtfyNotifier
+type NtfyOption func(*NtfyNotifier)
+
+// WithNtfyServerURL overrides the ntfy server, e.g. for a self-hosted instance
+func WithNtfyServerURL(serverURL string) NtfyOption {
+	return func(n *NtfyNotifier) {
+		if serverURL != "" {
+			n.serverURL = strings.TrimSuffix(serverURL, "/")
+		}
+	}
+}
+
+// WithNtfyToken sets the access token for protected topics
+func WithNtfyToken(token string) NtfyOption {
+	return func(n *NtfyNotifier) {
+		n.token = token
+	}
+}
+
+// WithNtfyHTTPClient sets a custom HTTP client
+func WithNtfyHTTPClient(client HTTPClient) NtfyOption {
+	return func(n *NtfyNotifier) {
+		n.client = client
+	}
+}
+
+// WithNtfyRetry sets the number of retries and the initial backoff between them
+func WithNtfyRetry(maxRetries int, backoff time.Duration) NtfyOption {
+	return func(n *NtfyNotifier) {
+		if maxRetries >= 0 {
+			n.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			n.retryBackoff = backoff
+		}
+	}
+}
+
+// WithNtfyTemplates sets where the message template of the channel comes from
+func WithNtfyTemplates(templates TemplateSource) NtfyOption {
+	return func(n *NtfyNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// NewNtfyNotifier creates an ntfy notifier for a topic
+func NewNtfyNotifier(topic string, options ...NtfyOption) *NtfyNotifier {
+	n := &NtfyNotifier{
+		serverURL: DefaultNtfyURL,
+		topic:     topic,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+		templates:    DefaultMessageTemplate(),
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *NtfyNotifier) Name() string {
+	return "ntfy"
+}
+
+// Notify publishes the event to the topic
+func (n *NtfyNotifier) Notify(ctx context.Context, event Event) error {
+	msg, err := n.templates.Template(ctx, n.Name()).Render(event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(NewNtfyMessage(n.topic, event, msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode ntfy message: %w", err)
+	}
+
+	sender := &httpSender{
+		name:         n.Name(),
+		client:       n.client,
+		maxRetries:   n.maxRetries,
+		retryBackoff: n.retryBackoff,
+	}
+	// JSON messages are published to the server root and name their topic
+	return sender.post(ctx, n.serverURL+"/", body, func(req *http.Request) {
+		if n.token != "" {
+			req.Header.Set("Authorization", "Bearer "+n.token)
+		}
+	})
+}
+
+// NtfyMessage is an ntfy JSON publish request
+type NtfyMessage struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// NewNtfyMessage builds the publish request for an event
+func NewNtfyMessage(topic string, event Event, msg Message) NtfyMessage {
+	message := NtfyMessage{
+		Topic:   topic,
+		Title:   msg.Title,
+		Message: msg.Text,
+	}
+
+	// Tags matching emoji short codes are shown as emojis
+	switch event.Type {
+	case EventDown:
+		message.Priority = ntfyPriorityUrgent
+		message.Tags = []string{"rotating_light"}
+	case EventDegraded:
+		message.Priority = ntfyPriorityHigh
+		message.Tags = []string{"warning"}
+	default:
+		message.Priority = ntfyPriorityDefault
+		message.Tags = []string{"white_check_mark"}
+	}
+	if event.ServiceSlug != "" {
+		message.Tags = append(message.Tags, event.ServiceSlug)
+	}
+
+	return message
+}
@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ntfyStandIn mimics an ntfy server accepting JSON publishes to protected topics
+type ntfyStandIn struct {
+	mu       sync.Mutex
+	token    string
+	messages []NtfyMessage
+}
+
+func (s *ntfyStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && req.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var msg NtfyMessage
+	if req.Method != http.MethodPost || req.URL.Path != "/" ||
+		json.NewDecoder(req.Body).Decode(&msg) != nil || msg.Topic == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.messages = append(s.messages, msg)
+
+	_ = json.NewEncoder(w).Encode(map[string]string{"id": "msg", "event": "message", "topic": msg.Topic})
+}
+
+func (s *ntfyStandIn) Messages() []NtfyMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]NtfyMessage(nil), s.messages...)
+}
+
+func TestNtfyNotifier_Notify(t *testing.T) {
+	degraded := testEvent()
+	degraded.Type = EventDegraded
+	degraded.Status = "degraded"
+
+	tests := []struct {
+		name             string
+		event            Event
+		expectedTitle    string
+		expectedPriority int
+	}{
+		{name: "failure is urgent", event: testEvent(), expectedTitle: "Incident: API is down", expectedPriority: 5},
+		{name: "degradation is high", event: degraded, expectedTitle: "Incident: API is degraded", expectedPriority: 4},
+		{name: "recovery is default", event: recoveredEvent(), expectedTitle: "Resolved: API is operational again", expectedPriority: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &ntfyStandIn{token: "tk_secret"}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			n := NewNtfyNotifier("uptime", WithNtfyServerURL(server.URL), WithNtfyToken("tk_secret"))
+			assert.Equal(t, "ntfy", n.Name())
+			require.NoError(t, n.Notify(context.Background(), tt.event))
+
+			messages := standIn.Messages()
+			require.Len(t, messages, 1)
+			assert.Equal(t, "uptime", messages[0].Topic)
+			assert.Equal(t, tt.expectedTitle, messages[0].Title)
+			assert.Equal(t, tt.expectedPriority, messages[0].Priority)
+			assert.Contains(t, messages[0].Tags, "api")
+		})
+	}
+}
+
+func TestNtfyNotifier_FailureMessage(t *testing.T) {
+	standIn := &ntfyStandIn{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewNtfyNotifier("uptime", WithNtfyServerURL(server.URL))
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	messages := standIn.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code", messages[0].Message)
+	assert.Equal(t, []string{"rotating_light", "api"}, messages[0].Tags)
+}
+
+func TestNtfyNotifier_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		topic         string
+		expectedError string
+	}{
+		{name: "missing token", topic: "uptime", expectedError: "after 1 attempts"},
+		{name: "wrong token", token: "tk_other", topic: "uptime", expectedError: "after 1 attempts"},
+		{name: "missing topic", token: "tk_secret", expectedError: "status 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &ntfyStandIn{token: "tk_secret"}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			options := []NtfyOption{WithNtfyServerURL(server.URL), WithNtfyRetry(2, time.Millisecond)}
+			if tt.token != "" {
+				options = append(options, WithNtfyToken(tt.token))
+			}
+			n := NewNtfyNotifier(tt.topic, options...)
+			err := n.Notify(context.Background(), testEvent())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError, "client errors are not retried")
+			assert.Empty(t, standIn.Messages())
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// Observer is a checker.HealthCheckObserver that tracks the last known
+// status of each service and notifies on state changes only
+type Observer struct {
+	notifier Notifier
+	logger   logger.Logger
+	alerts   alert.AlertRepository
+
+	mu     sync.Mutex
+	states map[string]string
+	// since holds when each failing service started failing
+	since map[string]time.Time
+}
+
+// ObserverOption is a function that configures an Observer
+type ObserverOption func(*Observer)
+
+// WithObserverAlerts seeds the status of a service seen for the first time
+// from its open alert in repo, so after a restart a failure that began
+// earlier is recovered or repeated instead of announced again
+func WithObserverAlerts(repo alert.AlertRepository) ObserverOption {
+	return func(o *Observer) {
+		o.alerts = repo
+	}
+}
+
+// NewObserver creates an observer that delivers state changes to n
+func NewObserver(n Notifier, log logger.Logger, options ...ObserverOption) *Observer {
+	o := &Observer{
+		notifier: n,
+		logger:   log,
+		states:   make(map[string]string),
+		since:    make(map[string]time.Time),
+	}
+
+	for _, option := range options {
+		option(o)
+	}
+
+	return o
+}
+
+// OnHealthCheckCompleted notifies when the service status differs from the
+// last one seen. Services that stay down or degraded are passed to the
+// notifier's Repeat method when it implements Repeater. Notifications carry
+// the time the service started failing.
+func (o *Observer) OnHealthCheckCompleted(ctx context.Context, event checker.HealthCheckEvent) {
+	key := event.ServiceSlug
+	if key == "" {
+		key = event.ServiceName
+	}
+
+	checkedAt := time.Now().UTC()
+	if event.Timestamp > 0 {
+		checkedAt = time.Unix(event.Timestamp, 0).UTC()
+	}
+
+	o.mu.Lock()
+	_, seen := o.states[key]
+	o.mu.Unlock()
+
+	var open *alert.Alert
+	if !seen {
+		open = o.openAlert(ctx, key)
+	}
+
+	o.mu.Lock()
+	previous, seen := o.states[key]
+	if !seen && open != nil {
+		previous = alert.StatusForSeverity(open.Severity)
+		o.since[key] = open.TriggeredAt
+	}
+	o.states[key] = event.Status
+	since, failing := o.since[key]
+	switch event.Status {
+	case service.StatusDown, service.StatusDegraded:
+		if !failing {
+			since = checkedAt
+			o.since[key] = since
+		}
+	default:
+		delete(o.since, key)
+	}
+	o.mu.Unlock()
+
+	deliver := o.notifier.Notify
+	notification, ok := NewEvent(event.ServiceName, event.ServiceSlug, previous, event.Status)
+	if !ok && previous == event.Status {
+		repeater, isRepeater := o.notifier.(Repeater)
+		if !isRepeater {
+			return
+		}
+		deliver = repeater.Repeat
+		notification, ok = NewRepeatEvent(event.ServiceName, event.ServiceSlug, event.Status)
+	}
+	if !ok {
+		return
+	}
+
+	notification.Latency = event.Latency
+	notification.StatusCode = event.StatusCode
+	notification.Error = event.Error
+	notification.FailingSince = since
+	if event.Timestamp > 0 {
+		notification.Timestamp = checkedAt
+	}
+
+	fields := logger.Fields{
+		"service_name": notification.ServiceName,
+		"event_type":   string(notification.Type),
+		"event_id":     notification.ID,
+	}
+	if err := deliver(ctx, notification); err != nil {
+		o.logger.Error(ctx, "Failed to deliver notification", err, fields)
+		return
+	}
+
+	if !notification.Repeat {
+		o.logger.Info(ctx, "Notification delivered", fields)
+	}
+}
+
+// openAlert returns the unresolved alert of a service, or nil when there is
+// none or alerts are not tracked
+func (o *Observer) openAlert(ctx context.Context, key string) *alert.Alert {
+	if o.alerts == nil {
+		return nil
+	}
+
+	open, err := o.alerts.GetOpen(ctx, key)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			o.logger.Error(ctx, "Failed to load open alert", err, logger.Fields{"service_slug": key})
+		}
+		return nil
+	}
+	return open
+}
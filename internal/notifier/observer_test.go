@@ -0,0 +1,195 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// recordingNotifier records delivered events
+type recordingNotifier struct {
+	mu     sync.Mutex
+	name   string
+	events []Event
+	err    error
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(ctx context.Context, event Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+	return n.err
+}
+
+func (n *recordingNotifier) Events() []Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Event(nil), n.events...)
+}
+
+func TestNewEvent(t *testing.T) {
+	tests := []struct {
+		name         string
+		previous     string
+		current      string
+		wantType     EventType
+		wantPrevious string
+		wantNotify   bool
+	}{
+		{name: "first check operational", previous: "", current: "operational"},
+		{name: "first check down", previous: "", current: "down", wantType: EventDown, wantPrevious: "unknown", wantNotify: true},
+		{name: "still operational", previous: "operational", current: "operational"},
+		{name: "still down", previous: "down", current: "down"},
+		{name: "goes down", previous: "operational", current: "down", wantType: EventDown, wantPrevious: "operational", wantNotify: true},
+		{name: "degrades", previous: "operational", current: "degraded", wantType: EventDegraded, wantPrevious: "operational", wantNotify: true},
+		{name: "degraded to down", previous: "degraded", current: "down", wantType: EventDown, wantPrevious: "degraded", wantNotify: true},
+		{name: "recovers", previous: "down", current: "operational", wantType: EventRecovered, wantPrevious: "down", wantNotify: true},
+		{name: "unknown status ignored", previous: "operational", current: "bogus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := NewEvent("API", "api", tt.previous, tt.current)
+
+			assert.Equal(t, tt.wantNotify, ok)
+			if !tt.wantNotify {
+				return
+			}
+			assert.Equal(t, tt.wantType, event.Type)
+			assert.Equal(t, tt.wantPrevious, event.PreviousStatus)
+			assert.Equal(t, tt.current, event.Status)
+			assert.Equal(t, "api", event.ServiceSlug)
+			assert.NotEmpty(t, event.ID)
+		})
+	}
+}
+
+func TestObserver_NotifiesOnStateChangesOnly(t *testing.T) {
+	ctx := context.Background()
+	recorder := &recordingNotifier{name: "recorder"}
+	observer := NewObserver(recorder, logger.Get())
+
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, status := range []string{"operational", "operational", "down", "down", "operational"} {
+		observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{
+			ServiceName: "API",
+			ServiceSlug: "api",
+			Status:      status,
+			StatusCode:  503,
+			Timestamp:   timestamp.Unix(),
+		})
+	}
+
+	events := recorder.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, EventDown, events[0].Type)
+	assert.Equal(t, timestamp, events[0].Timestamp)
+	assert.Equal(t, 503, events[0].StatusCode)
+	assert.Equal(t, EventRecovered, events[1].Type)
+}
+
+func TestObserver_TracksOutageStart(t *testing.T) {
+	ctx := context.Background()
+	recorder := &recordingNotifier{name: "recorder"}
+	observer := NewObserver(recorder, logger.Get())
+
+	start := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	for i, status := range []string{"operational", "degraded", "down", "operational", "down"} {
+		observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{
+			ServiceName: "API",
+			ServiceSlug: "api",
+			Status:      status,
+			Timestamp:   start.Add(time.Duration(i) * 10 * time.Minute).Unix(),
+		})
+	}
+
+	events := recorder.Events()
+	require.Len(t, events, 4)
+	assert.Equal(t, start.Add(10*time.Minute), events[0].FailingSince)
+	assert.Equal(t, 10*time.Minute, events[1].OutageDuration(), "degraded to down continues the outage")
+	assert.Equal(t, EventRecovered, events[2].Type)
+	assert.Equal(t, 20*time.Minute, events[2].OutageDuration())
+	assert.Equal(t, start.Add(40*time.Minute), events[3].FailingSince)
+}
+
+func TestObserver_SeedsStatusFromOpenAlertsAfterRestart(t *testing.T) {
+	triggeredAt := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		severity     string
+		status       string
+		wantType     EventType
+		wantPrevious string
+	}{
+		{name: "recovers", severity: alert.SeverityCritical, status: "operational", wantType: EventRecovered, wantPrevious: "down"},
+		{name: "still down is not announced again", severity: alert.SeverityCritical, status: "down"},
+		{name: "degraded goes down", severity: alert.SeverityWarning, status: "down", wantType: EventDown, wantPrevious: "degraded"},
+		{name: "no open alert", status: "operational"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			alerts := memory.NewAlertRepository()
+			if tt.severity != "" {
+				require.NoError(t, alerts.Create(ctx, alert.NewAlert("API", "api", tt.severity, "timeout", triggeredAt)))
+			}
+
+			// A fresh observer stands for a restarted checker
+			recorder := &recordingNotifier{name: "recorder"}
+			observer := NewObserver(recorder, logger.Get(), WithObserverAlerts(alerts))
+			observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{ServiceName: "API", ServiceSlug: "api", Status: tt.status})
+
+			events := recorder.Events()
+			if tt.wantType == "" {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.wantType, events[0].Type)
+			assert.Equal(t, tt.wantPrevious, events[0].PreviousStatus)
+			assert.Equal(t, triggeredAt, events[0].FailingSince)
+		})
+	}
+}
+
+func TestObserver_TracksServicesIndependently(t *testing.T) {
+	ctx := context.Background()
+	recorder := &recordingNotifier{name: "recorder"}
+	observer := NewObserver(recorder, logger.Get())
+
+	observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{ServiceName: "API", ServiceSlug: "api", Status: "operational"})
+	observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{ServiceName: "Web", ServiceSlug: "web", Status: "down"})
+	observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{ServiceName: "API", ServiceSlug: "api", Status: "operational"})
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "web", events[0].ServiceSlug)
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	ok := &recordingNotifier{name: "ok"}
+	failing := &recordingNotifier{name: "failing", err: stderrors.New("boom")}
+	dispatcher := NewDispatcher(ok, failing)
+
+	err := dispatcher.Notify(context.Background(), testEvent())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing: boom")
+	assert.Len(t, ok.Events(), 1)
+	assert.Len(t, failing.Events(), 1)
+	assert.Equal(t, 2, dispatcher.Len())
+}
@@ -0,0 +1,182 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultOpsgenieAPIURL is the Opsgenie API base URL; EU accounts use https://api.eu.opsgenie.com
+const DefaultOpsgenieAPIURL = "https://api.opsgenie.com"
+
+// OpsgenieNotifier creates and closes Opsgenie alerts, using the service
+// slug as the alert alias so a recovery closes the alert it opened
+type OpsgenieNotifier struct {
+	apiKey       string
+	apiURL       string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// OpsgenieOption is a function that configures an OpsgenieNotifier
+type OpsgenieOption func(*OpsgenieNotifier)
+
+// WithOpsgenieAPIURL overrides the API base URL, e.g. for EU accounts
+func WithOpsgenieAPIURL(apiURL string) OpsgenieOption {
+	return func(n *OpsgenieNotifier) {
+		if apiURL != "" {
+			n.apiURL = strings.TrimSuffix(apiURL, "/")
+		}
+	}
+}
+
+// WithOpsgenieHTTPClient sets a custom HTTP client
+func WithOpsgenieHTTPClient(client HTTPClient) OpsgenieOption {
+	return func(n *OpsgenieNotifier) {
+		n.client = client
+	}
+}
+
+// WithOpsgenieRetry sets the number of retries and the initial backoff between them
+func WithOpsgenieRetry(maxRetries int, backoff time.Duration) OpsgenieOption {
+	return func(n *OpsgenieNotifier) {
+		if maxRetries >= 0 {
+			n.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			n.retryBackoff = backoff
+		}
+	}
+}
+
+// WithOpsgenieTemplates sets where the message template of the channel comes from
+func WithOpsgenieTemplates(templates TemplateSource) OpsgenieOption {
+	return func(n *OpsgenieNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// NewOpsgenieNotifier creates an Opsgenie notifier for an API integration key
+func NewOpsgenieNotifier(apiKey string, options ...OpsgenieOption) *OpsgenieNotifier {
+	n := &OpsgenieNotifier{
+		apiKey: apiKey,
+		apiURL: DefaultOpsgenieAPIURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+		templates:    DefaultMessageTemplate(),
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *OpsgenieNotifier) Name() string {
+	return "opsgenie"
+}
+
+// Notify creates an alert for down and degraded events and closes it on recovery
+func (n *OpsgenieNotifier) Notify(ctx context.Context, event Event) error {
+	var (
+		endpoint string
+		payload  interface{}
+	)
+
+	if event.Type == EventRecovered {
+		endpoint = n.apiURL + "/v2/alerts/" + url.PathEscape(dedupKey(event)) + "/close?identifierType=alias"
+		payload = OpsgenieCloseRequest{
+			Source: "uptime-monitor",
+			Note:   fmt.Sprintf("%s recovered: %s", event.ServiceName, event.Status),
+		}
+	} else {
+		alert, err := newOpsgenieAlert(event, n.templates.Template(ctx, n.Name()))
+		if err != nil {
+			return err
+		}
+		endpoint = n.apiURL + "/v2/alerts"
+		payload = alert
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode opsgenie request: %w", err)
+	}
+
+	sender := &httpSender{
+		name:         n.Name(),
+		client:       n.client,
+		maxRetries:   n.maxRetries,
+		retryBackoff: n.retryBackoff,
+	}
+	return sender.post(ctx, endpoint, body, func(req *http.Request) {
+		req.Header.Set("Authorization", "GenieKey "+n.apiKey)
+	})
+}
+
+// OpsgenieAlert is a create alert request
+type OpsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+// OpsgenieCloseRequest is a close alert request
+type OpsgenieCloseRequest struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+// NewOpsgenieAlert builds the create alert request for an event, worded
+// with the built-in message template
+func NewOpsgenieAlert(event Event) (OpsgenieAlert, error) {
+	return newOpsgenieAlert(event, DefaultMessageTemplate())
+}
+
+// newOpsgenieAlert builds the create alert request with the message
+// rendered by tmpl
+func newOpsgenieAlert(event Event, tmpl *MessageTemplate) (OpsgenieAlert, error) {
+	msg, err := tmpl.Render(event)
+	if err != nil {
+		return OpsgenieAlert{}, err
+	}
+
+	priority := "P1"
+	if event.Type == EventDegraded {
+		priority = "P3"
+	}
+
+	details := make(map[string]string)
+	for key, value := range eventDetails(event) {
+		details[key] = fmt.Sprint(value)
+	}
+
+	return OpsgenieAlert{
+		Message:     truncate(msg.Title, 130),
+		Alias:       dedupKey(event),
+		Description: truncate(msg.Text, 15000),
+		Entity:      event.ServiceName,
+		Source:      "uptime-monitor",
+		Priority:    priority,
+		Tags:        []string{"uptime-monitor", event.Status},
+		Details:     details,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// opsgenieStandIn mimics the Opsgenie alert API, tracking open alerts by alias
+type opsgenieStandIn struct {
+	mu     sync.Mutex
+	apiKey string
+	alerts map[string]OpsgenieAlert
+	closed []string
+	notes  []string
+}
+
+func newOpsgenieStandIn(apiKey string) *opsgenieStandIn {
+	return &opsgenieStandIn{apiKey: apiKey, alerts: make(map[string]OpsgenieAlert)}
+}
+
+func (s *opsgenieStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Header.Get("Authorization") != "GenieKey "+s.apiKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == "/v2/alerts":
+		var alert OpsgenieAlert
+		if json.NewDecoder(req.Body).Decode(&alert) != nil || alert.Message == "" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		s.alerts[alert.Alias] = alert
+	case req.Method == http.MethodPost && strings.HasPrefix(req.URL.Path, "/v2/alerts/") &&
+		strings.HasSuffix(req.URL.Path, "/close"):
+		if req.URL.Query().Get("identifierType") != "alias" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		alias := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v2/alerts/"), "/close")
+		var close OpsgenieCloseRequest
+		_ = json.NewDecoder(req.Body).Decode(&close)
+		delete(s.alerts, alias)
+		s.closed = append(s.closed, alias)
+		s.notes = append(s.notes, close.Note)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]string{"result": "Request will be processed"})
+}
+
+func TestOpsgenieNotifier_CreateAndClose(t *testing.T) {
+	standIn := newOpsgenieStandIn("genie-key")
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewOpsgenieNotifier("genie-key", WithOpsgenieAPIURL(server.URL+"/"))
+	assert.Equal(t, "opsgenie", n.Name())
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	require.Contains(t, standIn.alerts, "api")
+
+	alert := standIn.alerts["api"]
+	assert.Equal(t, "Incident: API is down", alert.Message)
+	assert.Equal(t, "P1", alert.Priority)
+	assert.Equal(t, "API", alert.Entity)
+	assert.Equal(t, "503", alert.Details["status_code"])
+	assert.Contains(t, alert.Tags, "down")
+
+	recovered := testEvent()
+	recovered.Type = EventRecovered
+	recovered.Status = "operational"
+	recovered.PreviousStatus = "down"
+	require.NoError(t, n.Notify(context.Background(), recovered))
+
+	assert.Empty(t, standIn.alerts)
+	assert.Equal(t, []string{"api"}, standIn.closed)
+	assert.Equal(t, []string{"API recovered: operational"}, standIn.notes)
+}
+
+func TestOpsgenieNotifier_Unauthorized(t *testing.T) {
+	standIn := newOpsgenieStandIn("genie-key")
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewOpsgenieNotifier("wrong-key", WithOpsgenieAPIURL(server.URL))
+	assert.Error(t, n.Notify(context.Background(), testEvent()))
+	assert.Empty(t, standIn.alerts)
+}
+
+func TestNewOpsgenieAlert_Degraded(t *testing.T) {
+	event := testEvent()
+	event.Type = EventDegraded
+	event.Status = "degraded"
+	event.ServiceName = strings.Repeat("x", 200)
+
+	alert, err := NewOpsgenieAlert(event)
+	require.NoError(t, err)
+	assert.Equal(t, "P3", alert.Priority)
+	assert.Len(t, []rune(alert.Message), 130)
+	assert.Equal(t, "api", alert.Alias)
+}
@@ -0,0 +1,493 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+const (
+	defaultOutboxInterval    = 2 * time.Second
+	defaultOutboxBatchSize   = 50
+	defaultOutboxMaxAttempts = 10
+	defaultOutboxBackoff     = 30 * time.Second
+	defaultOutboxMaxBackoff  = 30 * time.Minute
+	defaultOutboxLease       = 2 * time.Minute
+	defaultOutboxTimeout     = 30 * time.Second
+	// defaultOutboxRetention matches the TTL index on the Mongo outbox
+	defaultOutboxRetention     = 7 * 24 * time.Hour
+	defaultOutboxPurgeInterval = time.Hour
+)
+
+// ErrOutboxClosed is returned when enqueueing on a closed OutboxDispatcher
+var ErrOutboxClosed = stderrors.New("notification outbox is closed")
+
+// Queue accepts events for later delivery to named channels
+type Queue interface {
+	Enqueue(ctx context.Context, event Event, channels []string) error
+}
+
+// OutboxStats is a snapshot of the dispatcher counters
+type OutboxStats struct {
+	Enqueued     int64 `json:"enqueued"`
+	Delivered    int64 `json:"delivered"`
+	Retries      int64 `json:"retries"`
+	DeadLettered int64 `json:"dead_lettered"`
+	ClaimErrors  int64 `json:"claim_errors"`
+}
+
+// OutboxDispatcher delivers notifications through a durable outbox. Events
+// are stored as one delivery per channel before anything is sent, then a
+// background loop claims due deliveries and sends them. Failed deliveries
+// are retried with exponential backoff and dead-lettered after the maximum
+// number of attempts. A delivery claimed by a dispatcher that dies is
+// claimed again once its lease expires, so delivery is at least once and
+// receivers should deduplicate on the event ID.
+type OutboxDispatcher struct {
+	repo        alert.OutboxRepository
+	channels    map[string]Notifier
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	lease       time.Duration
+	timeout     time.Duration
+	logger      logger.Logger
+	metrics     *metrics.Metrics
+	now         func() time.Time
+	lastPurge   time.Time
+
+	// passMu serialises delivery passes from the loop and Flush, and purges
+	passMu sync.Mutex
+	wakeCh chan struct{}
+	stopCh chan struct{}
+	doneCh chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	enqueued     atomic.Int64
+	delivered    atomic.Int64
+	retries      atomic.Int64
+	deadLettered atomic.Int64
+	claimErrors  atomic.Int64
+}
+
+// OutboxOption is a function that configures an OutboxDispatcher
+type OutboxOption func(*OutboxDispatcher)
+
+// WithOutboxInterval sets how often the outbox is polled for due deliveries
+func WithOutboxInterval(interval time.Duration) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		if interval > 0 {
+			d.interval = interval
+		}
+	}
+}
+
+// WithOutboxBatchSize sets how many deliveries are claimed per pass
+func WithOutboxBatchSize(size int) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		if size > 0 {
+			d.batchSize = size
+		}
+	}
+}
+
+// WithOutboxMaxAttempts sets how many attempts are made before a delivery is dead-lettered
+func WithOutboxMaxAttempts(attempts int) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		if attempts > 0 {
+			d.maxAttempts = attempts
+		}
+	}
+}
+
+// WithOutboxBackoff sets the delay before the first retry and the cap it doubles up to
+func WithOutboxBackoff(initial, max time.Duration) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		if initial > 0 {
+			d.backoff = initial
+		}
+		if max > 0 {
+			d.maxBackoff = max
+		}
+	}
+}
+
+// WithOutboxLease sets how long a claimed delivery is reserved for this dispatcher
+func WithOutboxLease(lease time.Duration) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		if lease > 0 {
+			d.lease = lease
+		}
+	}
+}
+
+// WithOutboxLogger sets the logger used to report delivery failures
+func WithOutboxLogger(log logger.Logger) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		d.logger = log
+	}
+}
+
+// WithOutboxMetrics reports deliveries and the outbox backlog to m
+func WithOutboxMetrics(m *metrics.Metrics) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		d.metrics = m
+	}
+}
+
+// WithOutboxClock overrides the clock used to schedule deliveries
+func WithOutboxClock(now func() time.Time) OutboxOption {
+	return func(d *OutboxDispatcher) {
+		d.now = now
+	}
+}
+
+// NewOutboxDispatcher creates a dispatcher over the given channels and
+// starts its delivery loop. Deliveries refer to channels by their Name.
+// Close must be called to stop the loop.
+func NewOutboxDispatcher(repo alert.OutboxRepository, channels []Notifier, options ...OutboxOption) *OutboxDispatcher {
+	d := &OutboxDispatcher{
+		repo:        repo,
+		channels:    make(map[string]Notifier, len(channels)),
+		interval:    defaultOutboxInterval,
+		batchSize:   defaultOutboxBatchSize,
+		maxAttempts: defaultOutboxMaxAttempts,
+		backoff:     defaultOutboxBackoff,
+		maxBackoff:  defaultOutboxMaxBackoff,
+		lease:       defaultOutboxLease,
+		timeout:     defaultOutboxTimeout,
+		logger:      logger.Get(),
+		now:         time.Now,
+		wakeCh:      make(chan struct{}, 1),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+
+	for _, n := range channels {
+		d.channels[n.Name()] = n
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	go d.run()
+
+	return d
+}
+
+// Enqueue stores one delivery of event per channel and wakes the delivery loop
+func (d *OutboxDispatcher) Enqueue(ctx context.Context, event Event, channels []string) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return ErrOutboxClosed
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	now := d.now()
+	notification := toNotification(event)
+	deliveries := make([]*alert.Delivery, len(channels))
+	for i, channel := range channels {
+		deliveries[i] = alert.NewDelivery(channel, notification, now)
+	}
+
+	if err := d.repo.Enqueue(ctx, deliveries); err != nil {
+		return err
+	}
+	d.enqueued.Add(int64(len(deliveries)))
+	if d.metrics != nil {
+		for _, channel := range channels {
+			d.metrics.OutboxEnqueued(channel)
+		}
+	}
+
+	select {
+	case d.wakeCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Flush delivers every delivery that is currently due and waits for it
+func (d *OutboxDispatcher) Flush(ctx context.Context) error {
+	defer d.observeBacklog(ctx)
+
+	for {
+		n, err := d.pass(ctx)
+		if err != nil || n < d.batchSize {
+			return err
+		}
+	}
+}
+
+// observeBacklog reports the number of deliveries in each state to metrics
+func (d *OutboxDispatcher) observeBacklog(ctx context.Context) {
+	if d.metrics == nil {
+		return
+	}
+
+	counts, err := d.repo.Count(ctx)
+	if err != nil {
+		d.logger.Error(ctx, "Failed to count outbox deliveries", err, logger.Fields{})
+		return
+	}
+	for _, state := range []string{alert.DeliveryPending, alert.DeliveryDelivered, alert.DeliveryDead} {
+		d.metrics.SetOutboxDeliveries(state, counts[state])
+	}
+}
+
+// Close stops the delivery loop. Deliveries still pending stay in the
+// outbox and are sent by the next dispatcher to run.
+func (d *OutboxDispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	closing := !d.closed
+	if closing {
+		d.closed = true
+		close(d.stopCh)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.doneCh:
+		if !closing {
+			return nil
+		}
+		stats := d.Stats()
+		d.logger.Info(ctx, "Notification outbox stopped", logger.Fields{
+			"enqueued":      stats.Enqueued,
+			"delivered":     stats.Delivered,
+			"retries":       stats.Retries,
+			"dead_lettered": stats.DeadLettered,
+		})
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the dispatcher counters
+func (d *OutboxDispatcher) Stats() OutboxStats {
+	return OutboxStats{
+		Enqueued:     d.enqueued.Load(),
+		Delivered:    d.delivered.Load(),
+		Retries:      d.retries.Load(),
+		DeadLettered: d.deadLettered.Load(),
+		ClaimErrors:  d.claimErrors.Load(),
+	}
+}
+
+// run is the delivery loop
+func (d *OutboxDispatcher) run() {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	ctx := context.Background()
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.wakeCh:
+		case <-d.stopCh:
+			return
+		}
+
+		if err := d.Flush(ctx); err != nil {
+			d.logger.Error(ctx, "Failed to claim outbox deliveries", err, logger.Fields{})
+		}
+		d.purge(ctx)
+	}
+}
+
+// purge deletes deliveries delivered longer ago than the retention period,
+// at most once per purge interval, when the outbox store does not expire
+// them by itself
+func (d *OutboxDispatcher) purge(ctx context.Context) {
+	purger, ok := d.repo.(alert.OutboxPurger)
+	if !ok {
+		return
+	}
+
+	d.passMu.Lock()
+	defer d.passMu.Unlock()
+
+	now := d.now()
+	if now.Sub(d.lastPurge) < defaultOutboxPurgeInterval {
+		return
+	}
+	d.lastPurge = now
+
+	purged, err := purger.PurgeDelivered(ctx, now.Add(-defaultOutboxRetention))
+	if err != nil {
+		d.logger.Error(ctx, "Failed to purge delivered outbox deliveries", err, logger.Fields{})
+		return
+	}
+	if purged > 0 {
+		d.logger.Info(ctx, "Purged delivered outbox deliveries", logger.Fields{"purged": purged})
+	}
+}
+
+// pass claims one batch of due deliveries and sends them, returning how
+// many were claimed
+func (d *OutboxDispatcher) pass(ctx context.Context) (int, error) {
+	d.passMu.Lock()
+	defer d.passMu.Unlock()
+
+	claimed, err := d.repo.Claim(ctx, d.now(), d.lease, d.batchSize)
+	if err != nil {
+		d.claimErrors.Add(1)
+		if d.metrics != nil {
+			d.metrics.OutboxClaimFailed()
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range claimed {
+		wg.Add(1)
+		go func(delivery *alert.Delivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(claimed), err
+}
+
+// deliver sends a claimed delivery and records the outcome
+func (d *OutboxDispatcher) deliver(ctx context.Context, delivery *alert.Delivery) {
+	fields := logger.Fields{
+		"delivery_id":  delivery.ID,
+		"channel":      delivery.Channel,
+		"event_id":     delivery.Notification.EventID,
+		"service_name": delivery.Notification.ServiceName,
+		"attempt":      delivery.Attempts,
+	}
+
+	n, ok := d.channels[delivery.Channel]
+	if !ok {
+		d.bury(ctx, delivery, "channel is not configured", fields)
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	err := n.Notify(sendCtx, fromNotification(delivery.Notification))
+	cancel()
+
+	if err == nil {
+		d.delivered.Add(1)
+		if d.metrics != nil {
+			d.metrics.OutboxDelivered(delivery.Channel)
+		}
+		if err := d.repo.Complete(ctx, delivery.ID, d.now()); err != nil {
+			// The notification went out; at worst it is sent again after the lease
+			d.logger.Error(ctx, "Failed to mark outbox delivery as delivered", err, fields)
+		}
+		return
+	}
+
+	if delivery.Attempts >= d.maxAttempts {
+		d.bury(ctx, delivery, err.Error(), fields)
+		return
+	}
+
+	retryAt := d.now().Add(d.retryDelay(delivery.Attempts))
+	d.retries.Add(1)
+	if d.metrics != nil {
+		d.metrics.OutboxRetried(delivery.Channel)
+	}
+	fields["retry_at"] = retryAt
+	fields["error"] = err.Error()
+	d.logger.Warn(ctx, "Notification delivery failed, will retry", fields)
+	if err := d.repo.Fail(ctx, delivery.ID, err.Error(), retryAt); err != nil {
+		d.logger.Error(ctx, "Failed to schedule outbox retry", err, fields)
+	}
+}
+
+// bury dead-letters a delivery that cannot be sent
+func (d *OutboxDispatcher) bury(ctx context.Context, delivery *alert.Delivery, reason string, fields logger.Fields) {
+	d.deadLettered.Add(1)
+	if d.metrics != nil {
+		d.metrics.OutboxDeadLettered(delivery.Channel)
+	}
+	d.logger.Error(ctx, "Notification delivery dead-lettered", stderrors.New(reason), fields)
+	if err := d.repo.Bury(ctx, delivery.ID, reason); err != nil {
+		d.logger.Error(ctx, "Failed to dead-letter outbox delivery", err, fields)
+	}
+}
+
+// retryDelay returns the backoff after the given number of attempts
+func (d *OutboxDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
+
+// toNotification converts an event into its stored form
+func toNotification(event Event) alert.Notification {
+	n := alert.Notification{
+		EventID:         event.ID,
+		Type:            string(event.Type),
+		ServiceName:     event.ServiceName,
+		ServiceSlug:     event.ServiceSlug,
+		Status:          event.Status,
+		PreviousStatus:  event.PreviousStatus,
+		Latency:         event.Latency,
+		StatusCode:      event.StatusCode,
+		Error:           event.Error,
+		Timestamp:       event.Timestamp,
+		Repeat:          event.Repeat,
+		FailingSince:    event.FailingSince,
+		AlertID:         event.AlertID,
+		EscalationLevel: event.EscalationLevel,
+		GroupKey:        event.GroupKey,
+		GroupUpdate:     event.GroupUpdate,
+	}
+	for _, grouped := range event.Grouped {
+		n.Grouped = append(n.Grouped, toNotification(grouped))
+	}
+	return n
+}
+
+// fromNotification restores an event from its stored form
+func fromNotification(n alert.Notification) Event {
+	event := Event{
+		ID:              n.EventID,
+		Type:            EventType(n.Type),
+		ServiceName:     n.ServiceName,
+		ServiceSlug:     n.ServiceSlug,
+		Status:          n.Status,
+		PreviousStatus:  n.PreviousStatus,
+		Latency:         n.Latency,
+		StatusCode:      n.StatusCode,
+		Error:           n.Error,
+		Timestamp:       n.Timestamp,
+		Repeat:          n.Repeat,
+		FailingSince:    n.FailingSince,
+		AlertID:         n.AlertID,
+		EscalationLevel: n.EscalationLevel,
+		GroupKey:        n.GroupKey,
+		GroupUpdate:     n.GroupUpdate,
+	}
+	for _, grouped := range n.Grouped {
+		event.Grouped = append(event.Grouped, fromNotification(grouped))
+	}
+	return event
+}
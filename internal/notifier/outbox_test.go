@@ -0,0 +1,323 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/shared/metrics"
+)
+
+type outboxFixture struct {
+	repo       *memory.OutboxRepository
+	dispatcher *OutboxDispatcher
+	slack      *recordingNotifier
+	pager      *recordingNotifier
+
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newOutboxFixture(t *testing.T, options ...OutboxOption) *outboxFixture {
+	t.Helper()
+
+	f := &outboxFixture{
+		repo:  memory.NewOutboxRepository(),
+		slack: &recordingNotifier{name: "slack"},
+		pager: &recordingNotifier{name: "pagerduty"},
+		now:   time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+	options = append([]OutboxOption{
+		WithOutboxInterval(time.Hour),
+		WithOutboxClock(f.clock),
+	}, options...)
+	f.dispatcher = NewOutboxDispatcher(f.repo, []Notifier{f.slack, f.pager}, options...)
+	t.Cleanup(func() {
+		_ = f.dispatcher.Close(context.Background())
+	})
+	return f
+}
+
+func (f *outboxFixture) clock() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *outboxFixture) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *outboxFixture) flush(t *testing.T) {
+	t.Helper()
+	require.NoError(t, f.dispatcher.Flush(context.Background()))
+}
+
+func (n *recordingNotifier) setErr(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+}
+
+func TestOutboxDispatcher_DeliversQueuedEvents(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+
+	event := testEvent()
+	event.AlertID = "alert-1"
+	event.EscalationLevel = 2
+	event.FailingSince = event.Timestamp.Add(-time.Hour)
+	require.NoError(t, f.dispatcher.Enqueue(ctx, event, []string{"slack", "pagerduty"}))
+
+	f.flush(t)
+
+	require.Len(t, f.slack.Events(), 1)
+	require.Len(t, f.pager.Events(), 1)
+	assert.Equal(t, event, f.slack.Events()[0])
+
+	delivered, err := f.repo.List(ctx, alert.DeliveryDelivered, 10)
+	require.NoError(t, err)
+	assert.Len(t, delivered, 2)
+
+	// Delivered notifications are not sent again
+	f.advance(time.Hour)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 1)
+
+	stats := f.dispatcher.Stats()
+	assert.Equal(t, int64(2), stats.Enqueued)
+	assert.Equal(t, int64(2), stats.Delivered)
+	assert.Zero(t, stats.Retries)
+}
+
+func TestOutboxDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	f := newOutboxFixture(t, WithOutboxMaxAttempts(3), WithOutboxBackoff(time.Minute, time.Hour))
+	f.slack.setErr(stderrors.New("HTTP 502"))
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 1)
+
+	// Not due again until the backoff has passed
+	f.advance(30 * time.Second)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 1)
+
+	f.advance(30 * time.Second)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 2)
+
+	// The backoff doubles
+	f.advance(time.Minute)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 2)
+	f.advance(time.Minute)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 3)
+
+	dead, err := f.repo.List(ctx, alert.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "HTTP 502", dead[0].LastError)
+	assert.Equal(t, 3, dead[0].Attempts)
+
+	f.advance(time.Hour)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 3)
+
+	stats := f.dispatcher.Stats()
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(1), stats.DeadLettered)
+	assert.Zero(t, stats.Delivered)
+}
+
+func TestOutboxDispatcher_ReportsMetrics(t *testing.T) {
+	m := metrics.New()
+	f := newOutboxFixture(t, WithOutboxMaxAttempts(2), WithOutboxBackoff(time.Minute, time.Hour), WithOutboxMetrics(m))
+	f.pager.setErr(stderrors.New("HTTP 502"))
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack", "pagerduty"}))
+	f.flush(t)
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	body := scrape()
+	assert.Contains(t, body, `uptime_outbox_enqueued_total{channel="pagerduty"} 1`)
+	assert.Contains(t, body, `uptime_outbox_enqueued_total{channel="slack"} 1`)
+	assert.Contains(t, body, `uptime_outbox_delivered_total{channel="slack"} 1`)
+	assert.Contains(t, body, `uptime_outbox_retries_total{channel="pagerduty"} 1`)
+	assert.Contains(t, body, `uptime_outbox_deliveries{state="pending"} 1`)
+	assert.Contains(t, body, `uptime_outbox_deliveries{state="delivered"} 1`)
+
+	f.advance(time.Minute)
+	f.flush(t)
+
+	body = scrape()
+	assert.Contains(t, body, `uptime_outbox_dead_lettered_total{channel="pagerduty"} 1`)
+	assert.Contains(t, body, `uptime_outbox_deliveries{state="pending"} 0`)
+	assert.Contains(t, body, `uptime_outbox_deliveries{state="dead"} 1`)
+}
+
+func TestOutboxDispatcher_RecoversAfterFailure(t *testing.T) {
+	f := newOutboxFixture(t, WithOutboxBackoff(time.Minute, time.Hour))
+	f.slack.setErr(stderrors.New("timeout"))
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack", "pagerduty"}))
+	f.flush(t)
+	assert.Len(t, f.pager.Events(), 1)
+
+	f.slack.setErr(nil)
+	f.advance(time.Minute)
+	f.flush(t)
+
+	assert.Len(t, f.slack.Events(), 2)
+	assert.Len(t, f.pager.Events(), 1, "a channel that succeeded is not retried")
+
+	counts, err := f.repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counts[alert.DeliveryDelivered])
+}
+
+func TestOutboxDispatcher_UnknownChannelIsDeadLettered(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"telegram"}))
+	f.flush(t)
+
+	dead, err := f.repo.List(ctx, alert.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "channel is not configured", dead[0].LastError)
+}
+
+func TestOutboxDispatcher_RedeliversAfterCrash(t *testing.T) {
+	f := newOutboxFixture(t, WithOutboxLease(time.Minute))
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+
+	// A dispatcher claims the delivery and dies before sending it
+	claimed, err := f.repo.Claim(ctx, f.clock(), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	f.flush(t)
+	assert.Empty(t, f.slack.Events())
+
+	f.advance(time.Minute)
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 1)
+}
+
+func TestOutboxDispatcher_PurgesDeliveredAfterRetention(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+
+	countState := func(state string) int64 {
+		counts, err := f.repo.Count(ctx)
+		require.NoError(t, err)
+		return counts[state]
+	}
+
+	// Delivered at the start and 30 minutes later, plus one still pending
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+	f.flush(t)
+	f.advance(30 * time.Minute)
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+	f.flush(t)
+	f.slack.setErr(stderrors.New("HTTP 502"))
+	require.NoError(t, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+	f.flush(t)
+
+	f.dispatcher.purge(ctx)
+	assert.Equal(t, int64(2), countState(alert.DeliveryDelivered), "nothing is old enough yet")
+
+	f.advance(defaultOutboxRetention - 29*time.Minute)
+	f.dispatcher.purge(ctx)
+	assert.Equal(t, int64(1), countState(alert.DeliveryDelivered))
+
+	// The second delivery is now old enough, but purges run once per interval
+	f.advance(30 * time.Minute)
+	f.dispatcher.purge(ctx)
+	assert.Equal(t, int64(1), countState(alert.DeliveryDelivered))
+
+	f.advance(defaultOutboxPurgeInterval)
+	f.dispatcher.purge(ctx)
+	assert.Zero(t, countState(alert.DeliveryDelivered))
+	assert.Equal(t, int64(1), countState(alert.DeliveryPending), "undelivered deliveries are kept")
+}
+
+func TestOutboxDispatcher_EnqueueWakesLoop(t *testing.T) {
+	f := newOutboxFixture(t)
+
+	require.NoError(t, f.dispatcher.Enqueue(context.Background(), testEvent(), []string{"slack"}))
+
+	assert.Eventually(t, func() bool {
+		return len(f.slack.Events()) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOutboxDispatcher_Close(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.dispatcher.Close(ctx))
+	require.NoError(t, f.dispatcher.Close(ctx))
+	assert.Equal(t, ErrOutboxClosed, f.dispatcher.Enqueue(ctx, testEvent(), []string{"slack"}))
+}
+
+func TestOutboxDispatcher_RetryDelay(t *testing.T) {
+	d := &OutboxDispatcher{backoff: 30 * time.Second, maxBackoff: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, d.retryDelay(1))
+	assert.Equal(t, time.Minute, d.retryDelay(2))
+	assert.Equal(t, 4*time.Minute, d.retryDelay(4))
+	assert.Equal(t, 5*time.Minute, d.retryDelay(5))
+	assert.Equal(t, 5*time.Minute, d.retryDelay(50))
+}
+
+func TestRouter_QueuesThroughOutbox(t *testing.T) {
+	f := newOutboxFixture(t)
+	router := NewRouter(nil, nil, []Notifier{f.slack, f.pager}, WithRouterQueue(f.dispatcher))
+	ctx := context.Background()
+
+	require.NoError(t, router.Notify(ctx, testEvent()))
+
+	counts, err := f.repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counts[alert.DeliveryPending])
+
+	f.flush(t)
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Len(t, f.pager.Events(), 1)
+}
+
+func TestRouter_SendsDirectlyWhenQueueFails(t *testing.T) {
+	f := newOutboxFixture(t)
+	require.NoError(t, f.dispatcher.Close(context.Background()))
+	router := NewRouter(nil, nil, []Notifier{f.slack}, WithRouterQueue(f.dispatcher))
+
+	require.NoError(t, router.Notify(context.Background(), testEvent()))
+	assert.Len(t, f.slack.Events(), 1)
+}
@@ -0,0 +1,215 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultPagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty event actions
+const (
+	pagerDutyTrigger = "trigger"
+	pagerDutyResolve = "resolve"
+)
+
+// PagerDutyNotifier triggers and resolves PagerDuty incidents through the
+// Events API v2, deduplicated by service slug so a recovery resolves the page
+type PagerDutyNotifier struct {
+	routingKey   string
+	url          string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// PagerDutyOption is a function that configures a PagerDutyNotifier
+type PagerDutyOption func(*PagerDutyNotifier)
+
+// WithPagerDutyURL overrides the Events API endpoint
+func WithPagerDutyURL(url string) PagerDutyOption {
+	return func(n *PagerDutyNotifier) {
+		if url != "" {
+			n.url = url
+		}
+	}
+}
+
+// WithPagerDutyHTTPClient sets a custom HTTP client
+func WithPagerDutyHTTPClient(client HTTPClient) PagerDutyOption {
+	return func(n *PagerDutyNotifier) {
+		n.client = client
+	}
+}
+
+// WithPagerDutyRetry sets the number of retries and the initial backoff between them
+func WithPagerDutyRetry(maxRetries int, backoff time.Duration) PagerDutyOption {
+	return func(n *PagerDutyNotifier) {
+		if maxRetries >= 0 {
+			n.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			n.retryBackoff = backoff
+		}
+	}
+}
+
+// WithPagerDutyTemplates sets where the message template of the channel comes from
+func WithPagerDutyTemplates(templates TemplateSource) PagerDutyOption {
+	return func(n *PagerDutyNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// NewPagerDutyNotifier creates a PagerDuty notifier for an integration routing key
+func NewPagerDutyNotifier(routingKey string, options ...PagerDutyOption) *PagerDutyNotifier {
+	n := &PagerDutyNotifier{
+		routingKey: routingKey,
+		url:        DefaultPagerDutyEventsURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+		templates:    DefaultMessageTemplate(),
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+// Notify triggers an incident for down and degraded events and resolves it on recovery
+func (n *PagerDutyNotifier) Notify(ctx context.Context, event Event) error {
+	payload, err := newPagerDutyEvent(n.routingKey, event, n.templates.Template(ctx, n.Name()))
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode pagerduty event: %w", err)
+	}
+
+	sender := &httpSender{
+		name:         n.Name(),
+		client:       n.client,
+		maxRetries:   n.maxRetries,
+		retryBackoff: n.retryBackoff,
+	}
+	return sender.post(ctx, n.url, body, nil)
+}
+
+// PagerDutyEvent is an Events API v2 request
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Client      string            `json:"client,omitempty"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+// PagerDutyPayload describes the alert for trigger events
+type PagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Component     string                 `json:"component,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// NewPagerDutyEvent builds the Events API request for an event, summarized
+// with the built-in message template
+func NewPagerDutyEvent(routingKey string, event Event) (PagerDutyEvent, error) {
+	return newPagerDutyEvent(routingKey, event, DefaultMessageTemplate())
+}
+
+// newPagerDutyEvent builds the Events API request, summarizing triggers
+// with the title rendered by tmpl
+func newPagerDutyEvent(routingKey string, event Event, tmpl *MessageTemplate) (PagerDutyEvent, error) {
+	request := PagerDutyEvent{
+		RoutingKey: routingKey,
+		DedupKey:   dedupKey(event),
+		Client:     "Uptime Monitor",
+	}
+
+	if event.Type == EventRecovered {
+		request.EventAction = pagerDutyResolve
+		return request, nil
+	}
+
+	msg, err := tmpl.Render(event)
+	if err != nil {
+		return PagerDutyEvent{}, err
+	}
+
+	severity := "critical"
+	if event.Type == EventDegraded {
+		severity = "warning"
+	}
+
+	request.EventAction = pagerDutyTrigger
+	request.Payload = &PagerDutyPayload{
+		Summary:       truncate(msg.Title, 1024),
+		Source:        event.ServiceName,
+		Severity:      severity,
+		Timestamp:     event.Timestamp.UTC().Format(time.RFC3339),
+		Component:     event.ServiceSlug,
+		Class:         string(event.Type),
+		CustomDetails: eventDetails(event),
+	}
+
+	return request, nil
+}
+
+// dedupKey identifies a service across trigger and resolve events
+func dedupKey(event Event) string {
+	if event.ServiceSlug != "" {
+		return event.ServiceSlug
+	}
+	return event.ServiceName
+}
+
+// eventDetails returns the event fields attached to incident alerts
+func eventDetails(event Event) map[string]interface{} {
+	details := map[string]interface{}{
+		"status":          event.Status,
+		"previous_status": event.PreviousStatus,
+		"latency_ms":      event.Latency,
+	}
+	if event.StatusCode > 0 {
+		details["status_code"] = event.StatusCode
+	}
+	if event.Error != "" {
+		details["error"] = event.Error
+	}
+	if !event.FailingSince.IsZero() {
+		details["failing_since"] = event.FailingSince.UTC().Format(time.RFC3339)
+	}
+	if len(event.Grouped) > 0 {
+		failing := make([]string, 0, len(event.Grouped))
+		for _, grouped := range event.Failing() {
+			failing = append(failing, grouped.ServiceName+" ("+grouped.Status+")")
+		}
+		details["group"] = event.GroupKey
+		details["failing_services"] = strings.Join(failing, ", ")
+	}
+	return details
+}
@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagerDutyStandIn mimics the Events API v2, tracking open incidents by dedup key
+type pagerDutyStandIn struct {
+	mu         sync.Mutex
+	routingKey string
+	events     []PagerDutyEvent
+	open       map[string]bool
+	statuses   []int
+}
+
+func newPagerDutyStandIn(routingKey string) *pagerDutyStandIn {
+	return &pagerDutyStandIn{routingKey: routingKey, open: make(map[string]bool)}
+}
+
+func (s *pagerDutyStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.statuses) > 0 {
+		status := s.statuses[0]
+		s.statuses = s.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+
+	var event PagerDutyEvent
+	if req.Method != http.MethodPost || req.URL.Path != "/v2/enqueue" ||
+		json.NewDecoder(req.Body).Decode(&event) != nil || event.RoutingKey != s.routingKey {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.events = append(s.events, event)
+
+	switch event.EventAction {
+	case pagerDutyTrigger:
+		if event.Payload == nil || event.Payload.Summary == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.open[event.DedupKey] = true
+	case pagerDutyResolve:
+		delete(s.open, event.DedupKey)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "success", "dedup_key": event.DedupKey})
+}
+
+func TestPagerDutyNotifier_TriggerAndResolve(t *testing.T) {
+	standIn := newPagerDutyStandIn("routing-key")
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewPagerDutyNotifier("routing-key", WithPagerDutyURL(server.URL+"/v2/enqueue"))
+	assert.Equal(t, "pagerduty", n.Name())
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.True(t, standIn.open["api"])
+
+	trigger := standIn.events[0]
+	assert.Equal(t, "api", trigger.DedupKey)
+	require.NotNil(t, trigger.Payload)
+	assert.Equal(t, "Incident: API is down", trigger.Payload.Summary)
+	assert.Equal(t, "critical", trigger.Payload.Severity)
+	assert.Equal(t, "API", trigger.Payload.Source)
+	assert.Equal(t, "2024-01-02T03:04:05Z", trigger.Payload.Timestamp)
+	assert.Equal(t, float64(503), trigger.Payload.CustomDetails["status_code"])
+
+	recovered := testEvent()
+	recovered.Type = EventRecovered
+	recovered.Status = "operational"
+	recovered.PreviousStatus = "down"
+	require.NoError(t, n.Notify(context.Background(), recovered))
+
+	assert.Empty(t, standIn.open)
+	require.Len(t, standIn.events, 2)
+	assert.Equal(t, pagerDutyResolve, standIn.events[1].EventAction)
+	assert.Equal(t, "api", standIn.events[1].DedupKey)
+	assert.Nil(t, standIn.events[1].Payload)
+}
+
+func TestPagerDutyNotifier_RetriesRateLimit(t *testing.T) {
+	standIn := newPagerDutyStandIn("routing-key")
+	standIn.statuses = []int{http.StatusTooManyRequests}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewPagerDutyNotifier("routing-key",
+		WithPagerDutyURL(server.URL+"/v2/enqueue"),
+		WithPagerDutyRetry(1, time.Millisecond),
+	)
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.True(t, standIn.open["api"])
+}
+
+func TestPagerDutyNotifier_RejectedEvent(t *testing.T) {
+	standIn := newPagerDutyStandIn("other-key")
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	n := NewPagerDutyNotifier("routing-key",
+		WithPagerDutyURL(server.URL+"/v2/enqueue"),
+		WithPagerDutyRetry(2, time.Millisecond),
+	)
+	assert.Error(t, n.Notify(context.Background(), testEvent()))
+	assert.Empty(t, standIn.events)
+}
+
+func TestNewPagerDutyEvent_Degraded(t *testing.T) {
+	event := testEvent()
+	event.Type = EventDegraded
+	event.Status = "degraded"
+	event.ServiceSlug = ""
+
+	request, err := NewPagerDutyEvent("key", event)
+	require.NoError(t, err)
+	assert.Equal(t, pagerDutyTrigger, request.EventAction)
+	assert.Equal(t, "API", request.DedupKey)
+	assert.Equal(t, "warning", request.Payload.Severity)
+}
@@ -0,0 +1,160 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultPushoverAPIURL is the Pushover message API endpoint
+const DefaultPushoverAPIURL = "https://api.pushover.net/1/messages.json"
+
+// Pushover limits
+const (
+	pushoverTitleLimit   = 250
+	pushoverMessageLimit = 1024
+)
+
+// Pushover message priorities
+const (
+	pushoverPriorityLow    = -1
+	pushoverPriorityNormal = 0
+	pushoverPriorityHigh   = 1
+)
+
+// PushoverNotifier sends push messages through Pushover. Failures are high
+// priority, bypassing quiet hours on the device, degradations normal and
+// recoveries low priority.
+type PushoverNotifier struct {
+	appToken     string
+	userKey      string
+	url          string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// PushoverOption is a function that configures a PushoverNotifier
+type PushoverOption func(*PushoverNotifier)
+
+// WithPushoverURL overrides the message API endpoint
+func WithPushoverURL(url string) PushoverOption {
+	return func(n *PushoverNotifier) {
+		if url != "" {
+			n.url = url
+		}
+	}
+}
+
+// WithPushoverHTTPClient sets a custom HTTP client
+func WithPushoverHTTPClient(client HTTPClient) PushoverOption {
+	return func(n *PushoverNotifier) {
+		n.client = client
+	}
+}
+
+// WithPushoverRetry sets the number of retries and the initial backoff between them
+func WithPushoverRetry(maxRetries int, backoff time.Duration) PushoverOption {
+	return func(n *PushoverNotifier) {
+		if maxRetries >= 0 {
+			n.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			n.retryBackoff = backoff
+		}
+	}
+}
+
+// WithPushoverTemplates sets where the message template of the channel comes from
+func WithPushoverTemplates(templates TemplateSource) PushoverOption {
+	return func(n *PushoverNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// NewPushoverNotifier creates a Pushover notifier for an application token
+// and a user or group key
+func NewPushoverNotifier(appToken, userKey string, options ...PushoverOption) *PushoverNotifier {
+	n := &PushoverNotifier{
+		appToken: appToken,
+		userKey:  userKey,
+		url:      DefaultPushoverAPIURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+		templates:    DefaultMessageTemplate(),
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *PushoverNotifier) Name() string {
+	return "pushover"
+}
+
+// Notify sends the event as a push message
+func (n *PushoverNotifier) Notify(ctx context.Context, event Event) error {
+	msg, err := n.templates.Template(ctx, n.Name()).Render(event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(NewPushoverMessage(n.appToken, n.userKey, event, msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode pushover message: %w", err)
+	}
+
+	sender := &httpSender{
+		name:         n.Name(),
+		client:       n.client,
+		maxRetries:   n.maxRetries,
+		retryBackoff: n.retryBackoff,
+	}
+	return sender.post(ctx, n.url, body, nil)
+}
+
+// PushoverMessage is a Pushover message API request
+type PushoverMessage struct {
+	Token     string `json:"token"`
+	User      string `json:"user"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Priority  int    `json:"priority"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+}
+
+// NewPushoverMessage builds the message API request for an event
+func NewPushoverMessage(appToken, userKey string, event Event, msg Message) PushoverMessage {
+	priority := pushoverPriorityLow
+	switch event.Type {
+	case EventDown:
+		priority = pushoverPriorityHigh
+	case EventDegraded:
+		priority = pushoverPriorityNormal
+	}
+
+	message := PushoverMessage{
+		Token:    appToken,
+		User:     userKey,
+		Title:    truncate(msg.Title, pushoverTitleLimit),
+		Message:  truncate(msg.Text, pushoverMessageLimit),
+		Priority: priority,
+	}
+	if !event.Timestamp.IsZero() {
+		message.Timestamp = event.Timestamp.Unix()
+	}
+
+	return message
+}
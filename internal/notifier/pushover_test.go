@@ -0,0 +1,143 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushoverStandIn mimics the Pushover message API for one application
+type pushoverStandIn struct {
+	mu       sync.Mutex
+	token    string
+	messages []PushoverMessage
+	statuses []int
+}
+
+func (s *pushoverStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.statuses) > 0 {
+		status := s.statuses[0]
+		s.statuses = s.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+
+	var msg PushoverMessage
+	if req.Method != http.MethodPost || req.URL.Path != "/1/messages.json" ||
+		json.NewDecoder(req.Body).Decode(&msg) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if msg.Token != s.token || msg.User == "" || msg.Message == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": 0, "errors": []string{"application token is invalid"}})
+		return
+	}
+	s.messages = append(s.messages, msg)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": 1, "request": "req-1"})
+}
+
+func (s *pushoverStandIn) Messages() []PushoverMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PushoverMessage(nil), s.messages...)
+}
+
+func TestPushoverNotifier_Notify(t *testing.T) {
+	degraded := testEvent()
+	degraded.Type = EventDegraded
+	degraded.Status = "degraded"
+
+	tests := []struct {
+		name             string
+		event            Event
+		statuses         []int
+		expectedTitle    string
+		expectedPriority int
+	}{
+		{name: "failure is high", event: testEvent(), expectedTitle: "Incident: API is down", expectedPriority: 1},
+		{name: "degradation is normal", event: degraded, expectedTitle: "Incident: API is degraded", expectedPriority: 0},
+		{name: "recovery is quiet", event: recoveredEvent(), expectedTitle: "Resolved: API is operational again", expectedPriority: -1},
+		{
+			name:             "server error is retried",
+			event:            testEvent(),
+			statuses:         []int{http.StatusInternalServerError},
+			expectedTitle:    "Incident: API is down",
+			expectedPriority: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &pushoverStandIn{token: "app-token", statuses: tt.statuses}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			n := NewPushoverNotifier("app-token", "user-key",
+				WithPushoverURL(server.URL+"/1/messages.json"),
+				WithPushoverRetry(1, time.Millisecond),
+			)
+			assert.Equal(t, "pushover", n.Name())
+			require.NoError(t, n.Notify(context.Background(), tt.event))
+
+			messages := standIn.Messages()
+			require.Len(t, messages, 1)
+			assert.Equal(t, "user-key", messages[0].User)
+			assert.Equal(t, tt.expectedTitle, messages[0].Title)
+			assert.Equal(t, tt.expectedPriority, messages[0].Priority)
+			assert.Equal(t, tt.event.Timestamp.Unix(), messages[0].Timestamp)
+		})
+	}
+}
+
+func TestPushoverNotifier_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		statuses      []int
+		expectedError string
+	}{
+		{name: "invalid token is not retried", token: "wrong", expectedError: "after 1 attempts"},
+		{
+			name:          "retries exhausted",
+			token:         "app-token",
+			statuses:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectedError: "after 3 attempts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &pushoverStandIn{token: "app-token", statuses: tt.statuses}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			n := NewPushoverNotifier(tt.token, "user-key",
+				WithPushoverURL(server.URL+"/1/messages.json"),
+				WithPushoverRetry(2, time.Millisecond),
+			)
+			err := n.Notify(context.Background(), testEvent())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Empty(t, standIn.Messages())
+		})
+	}
+}
+
+func TestNewPushoverMessage_Truncates(t *testing.T) {
+	msg := NewPushoverMessage("app", "user", testEvent(), Message{Title: "Title", Text: strings.Repeat("x", 2000)})
+	assert.Len(t, []rune(msg.Message), pushoverMessageLimit)
+}
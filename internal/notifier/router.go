@@ -0,0 +1,607 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// ServiceLookup resolves the service an event refers to, so policies can
+// match on its group and tags
+type ServiceLookup interface {
+	GetBySlug(ctx context.Context, slug string) (*service.Service, error)
+}
+
+// Router delivers events to the channels selected by notification policies.
+//
+// Every enabled policy whose matcher and severities select the event's
+// service is applied, and each channel is notified at most once per event.
+// Events that no policy selects go to every channel, so services nobody has
+// claimed keep alerting. A policy sends nothing during its quiet hours, and
+// alerts it held back are sent once the window ends if the service is still
+// failing. Recoveries are sent on every policy that announced the failure,
+// even during quiet hours, so pages opened earlier get resolved.
+//
+// When an alert repository is configured, each failure opens an alert that
+// stays open until the service recovers. The alert records the policies the
+// failure was announced on, so recoveries are sent after a restart too.
+// While it is unacknowledged, the escalation steps of the matching policies
+// notify further channels as their delays pass; acknowledging it stops
+// escalation and repeats.
+//
+// When a silence repository is configured, channels muted by an active
+// silence of the event's service are dropped before anything is sent.
+//
+// With a reminder interval, services still failing are notified again on the
+// same channels once the interval has passed, for events no policy selects
+// and for policies without a repeat interval of their own. Reminders, like
+// repeats, stop once the alert is acknowledged or the service recovers.
+//
+// When a queue is configured, notifications are queued for the selected
+// channels instead of being sent directly.
+type Router struct {
+	policies alert.PolicyRepository
+	services ServiceLookup
+	alerts   alert.AlertRepository
+	silences alert.SilenceRepository
+	queue    Queue
+	reminder time.Duration
+	channels map[string]Notifier
+	all      []Notifier
+	logger   logger.Logger
+	now      func() time.Time
+
+	mu sync.Mutex
+	// notified holds, per policy and service, when the failure was last sent;
+	// a zero time marks a failure held back by quiet hours
+	notified map[string]time.Time
+}
+
+// RouterOption is a function that configures a Router
+type RouterOption func(*Router)
+
+// WithRouterLogger sets the logger used to report routing decisions
+func WithRouterLogger(log logger.Logger) RouterOption {
+	return func(r *Router) {
+		r.logger = log
+	}
+}
+
+// WithRouterClock overrides the clock used for repeat intervals and quiet hours
+func WithRouterClock(now func() time.Time) RouterOption {
+	return func(r *Router) {
+		r.now = now
+	}
+}
+
+// WithRouterAlerts stores an alert per failure in repo, enabling
+// acknowledgement and escalation
+func WithRouterAlerts(repo alert.AlertRepository) RouterOption {
+	return func(r *Router) {
+		r.alerts = repo
+	}
+}
+
+// WithRouterSilences mutes the channels selected by the active silences in repo
+func WithRouterSilences(repo alert.SilenceRepository) RouterOption {
+	return func(r *Router) {
+		r.silences = repo
+	}
+}
+
+// WithRouterReminders re-sends failures every interval while they last,
+// unless a policy sets its own repeat interval
+func WithRouterReminders(interval time.Duration) RouterOption {
+	return func(r *Router) {
+		r.reminder = interval
+	}
+}
+
+// WithRouterQueue queues notifications on q, such as an OutboxDispatcher,
+// instead of sending them directly
+func WithRouterQueue(q Queue) RouterOption {
+	return func(r *Router) {
+		r.queue = q
+	}
+}
+
+// NewRouter creates a router over the given channels. Policies refer to
+// channels by their Name.
+func NewRouter(policies alert.PolicyRepository, services ServiceLookup, channels []Notifier, options ...RouterOption) *Router {
+	r := &Router{
+		policies: policies,
+		services: services,
+		channels: make(map[string]Notifier, len(channels)),
+		all:      channels,
+		logger:   logger.Get(),
+		now:      time.Now,
+		notified: make(map[string]time.Time),
+	}
+
+	for _, n := range channels {
+		r.channels[n.Name()] = n
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Name identifies the router
+func (r *Router) Name() string {
+	return "router"
+}
+
+// Len returns the number of channels the router can deliver to
+func (r *Router) Len() int {
+	return len(r.all)
+}
+
+// Notify routes a state change to the channels of the matching policies
+func (r *Router) Notify(ctx context.Context, event Event) error {
+	open := r.track(ctx, &event)
+
+	now := r.now()
+	policies, ok := r.match(ctx, event)
+	if !ok {
+		r.mu.Lock()
+		if event.Type == EventRecovered {
+			delete(r.notified, fallbackKey(event))
+		} else {
+			r.notified[fallbackKey(event)] = now
+		}
+		r.mu.Unlock()
+
+		return r.send(ctx, event, r.all)
+	}
+	quiet := make(map[string]bool)
+
+	r.mu.Lock()
+	var selected []route
+	for _, policy := range policies {
+		key := routeKey(policy, event)
+		if event.Type == EventRecovered {
+			last, known := r.notified[key]
+			if (known && !last.IsZero()) || (open != nil && open.WasAnnounced(policy.ID)) {
+				selected = append(selected, route{policy: policy, channels: escalatedChannels(policy, open)})
+			}
+			delete(r.notified, key)
+			continue
+		}
+
+		if policy.QuietHours != nil && policy.QuietHours.Active(now) {
+			r.notified[key] = time.Time{}
+			quiet[policy.Name] = true
+			continue
+		}
+		r.notified[key] = now
+		selected = append(selected, route{policy: policy, channels: policy.Channels})
+	}
+	r.mu.Unlock()
+
+	if len(quiet) > 0 {
+		r.logger.Info(ctx, "Notification held during quiet hours", logger.Fields{
+			"service_name": event.ServiceName,
+			"event_type":   string(event.Type),
+			"policies":     keys(quiet),
+		})
+	}
+
+	if event.Type != EventRecovered {
+		r.announce(ctx, event, open, selected)
+	}
+	return r.deliver(ctx, event, selected)
+}
+
+// Repeat re-sends a failure on the policies whose repeat interval has
+// elapsed, and on those that held it back during quiet hours that have ended.
+// It also sends the escalation steps that have become due, and reminders
+// for failures no policy selects.
+func (r *Router) Repeat(ctx context.Context, event Event) error {
+	open := r.open(ctx, event)
+	if open != nil {
+		if open.IsAcknowledged() {
+			return nil
+		}
+		event.AlertID = open.ID
+		event.FailingSince = open.TriggeredAt
+	}
+
+	now := r.now()
+	policies, ok := r.match(ctx, event)
+	if !ok {
+		return r.remind(ctx, event, now)
+	}
+
+	r.mu.Lock()
+	var selected []route
+	for _, policy := range policies {
+		if policy.QuietHours != nil && policy.QuietHours.Active(now) {
+			continue
+		}
+
+		interval := time.Duration(policy.RepeatInterval)
+		if interval == 0 {
+			interval = r.reminder
+		}
+
+		key := routeKey(policy, event)
+		last, known := r.notified[key]
+		held := known && last.IsZero()
+		due := known && interval > 0 && now.Sub(last) >= interval
+		if !held && !due {
+			continue
+		}
+
+		r.notified[key] = now
+		selected = append(selected, route{policy: policy, channels: policy.Channels})
+	}
+	r.mu.Unlock()
+
+	r.announce(ctx, event, open, selected)
+	return stderrors.Join(
+		r.deliver(ctx, event, selected),
+		r.escalate(ctx, event, open, policies, now),
+	)
+}
+
+// remind re-sends a failure no policy selects to every channel once the
+// reminder interval has passed since it was last sent
+func (r *Router) remind(ctx context.Context, event Event, now time.Time) error {
+	if r.reminder <= 0 {
+		return nil
+	}
+
+	key := fallbackKey(event)
+
+	r.mu.Lock()
+	last, known := r.notified[key]
+	due := known && now.Sub(last) >= r.reminder
+	if due {
+		r.notified[key] = now
+	}
+	r.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+
+	return r.send(ctx, event, r.all)
+}
+
+// escalate sends the escalation steps of each policy that became due since
+// the alert was triggered, and records them on the alert
+func (r *Router) escalate(ctx context.Context, event Event, open *alert.Alert, policies []*alert.Policy, now time.Time) error {
+	if open == nil {
+		return nil
+	}
+
+	levels := make(map[int][]route)
+	for _, policy := range policies {
+		if policy.QuietHours != nil && policy.QuietHours.Active(now) {
+			continue
+		}
+
+		sent := open.Escalated(policy.ID)
+		due := policy.DueSteps(now.Sub(open.TriggeredAt))
+		if due <= sent {
+			continue
+		}
+
+		if err := r.alerts.SetEscalated(ctx, open.ID, policy.ID, due); err != nil {
+			r.logger.Error(ctx, "Failed to record alert escalation", err, logger.Fields{
+				"alert_id": open.ID,
+				"policy":   policy.Name,
+			})
+			continue
+		}
+
+		var channels []string
+		for _, step := range policy.Escalation[sent:due] {
+			channels = append(channels, step.Channels...)
+		}
+		// The policy's own channels are level 1, so step n is level n+1
+		levels[due+1] = append(levels[due+1], route{policy: policy, channels: channels})
+	}
+
+	var errs []error
+	for level, routes := range levels {
+		escalation := event
+		escalation.EscalationLevel = level
+
+		r.logger.Warn(ctx, "Escalating unacknowledged alert", logger.Fields{
+			"alert_id":     open.ID,
+			"service_name": event.ServiceName,
+			"level":        level,
+		})
+		errs = append(errs, r.deliver(ctx, escalation, routes))
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// announce records on the event's alert the policies the failure is sent on
+func (r *Router) announce(ctx context.Context, event Event, open *alert.Alert, routes []route) {
+	if r.alerts == nil || event.AlertID == "" {
+		return
+	}
+
+	for _, rt := range routes {
+		if open != nil && open.WasAnnounced(rt.policy.ID) {
+			continue
+		}
+		if err := r.alerts.SetAnnounced(ctx, event.AlertID, rt.policy.ID); err != nil {
+			r.logger.Error(ctx, "Failed to record alert announcement", err, logger.Fields{
+				"alert_id": event.AlertID,
+				"policy":   rt.policy.Name,
+			})
+		}
+	}
+}
+
+// track opens an alert for a failure, or resolves the open one on recovery,
+// and stamps the event with its ID. It returns the alert that was open
+// before the event, if any.
+func (r *Router) track(ctx context.Context, event *Event) *alert.Alert {
+	if r.alerts == nil {
+		return nil
+	}
+
+	open := r.open(ctx, *event)
+	fields := logger.Fields{
+		"service_name": event.ServiceName,
+		"event_type":   string(event.Type),
+	}
+
+	if event.Type == EventRecovered {
+		if open == nil {
+			return nil
+		}
+		event.AlertID = open.ID
+		event.FailingSince = open.TriggeredAt
+		if err := r.alerts.Resolve(ctx, open.ID, r.now()); err != nil {
+			r.logger.Error(ctx, "Failed to resolve alert", err, fields)
+		}
+		return open
+	}
+
+	if open != nil {
+		event.AlertID = open.ID
+		event.FailingSince = open.TriggeredAt
+		return open
+	}
+
+	created := alert.NewAlert(event.ServiceName, dedupKey(*event), alert.SeverityForStatus(event.Status), event.Error, r.now())
+	if err := r.alerts.Create(ctx, created); err != nil {
+		r.logger.Error(ctx, "Failed to open alert", err, fields)
+		return nil
+	}
+	event.AlertID = created.ID
+
+	return nil
+}
+
+// open returns the unresolved alert of the event's service, or nil when
+// there is none or alerts are not tracked
+func (r *Router) open(ctx context.Context, event Event) *alert.Alert {
+	if r.alerts == nil {
+		return nil
+	}
+
+	open, err := r.alerts.GetOpen(ctx, dedupKey(event))
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			r.logger.Error(ctx, "Failed to load open alert", err, logger.Fields{
+				"service_name": event.ServiceName,
+			})
+		}
+		return nil
+	}
+
+	return open
+}
+
+// match returns the policies that select the event's service, or false when
+// none do (or they cannot be loaded) and the event should go everywhere
+func (r *Router) match(ctx context.Context, event Event) ([]*alert.Policy, bool) {
+	if r.policies == nil {
+		return nil, false
+	}
+
+	policies, err := r.policies.GetAll(ctx)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to load notification policies, notifying every channel", err, logger.Fields{
+			"service_name": event.ServiceName,
+		})
+		return nil, false
+	}
+
+	svc := r.lookup(ctx, event)
+	severity := alert.SeverityForStatus(event.Status)
+	if event.Type == EventRecovered {
+		severity = alert.SeverityForStatus(event.PreviousStatus)
+	}
+
+	var matched []*alert.Policy
+	for _, policy := range policies {
+		if policy.Matches(svc, severity) {
+			matched = append(matched, policy)
+		}
+	}
+
+	return matched, len(matched) > 0
+}
+
+// lookup returns the event's service, or a stand-in carrying only its name
+// and slug when it cannot be loaded
+func (r *Router) lookup(ctx context.Context, event Event) *service.Service {
+	if r.services != nil && event.ServiceSlug != "" {
+		if svc, err := r.services.GetBySlug(ctx, event.ServiceSlug); err == nil {
+			return svc
+		}
+	}
+	return &service.Service{Name: event.ServiceName, Slug: event.ServiceSlug}
+}
+
+// route is a policy and the channels an event is sent to on its behalf
+type route struct {
+	policy   *alert.Policy
+	channels []string
+}
+
+// deliver sends the event once to every channel of the selected routes
+func (r *Router) deliver(ctx context.Context, event Event, routes []route) error {
+	seen := make(map[string]bool)
+	var targets []Notifier
+	for _, rt := range routes {
+		for _, name := range rt.channels {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			n, ok := r.channels[name]
+			if !ok {
+				r.logger.Warn(ctx, "Notification policy refers to an unconfigured channel", logger.Fields{
+					"policy":  rt.policy.Name,
+					"channel": name,
+				})
+				continue
+			}
+			targets = append(targets, n)
+		}
+	}
+
+	return r.send(ctx, event, targets)
+}
+
+// send queues the event for the targets that are not silenced, or sends it
+// directly when there is no queue or it cannot be written to
+func (r *Router) send(ctx context.Context, event Event, targets []Notifier) error {
+	targets = r.unsilenced(ctx, event, targets)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	if r.queue != nil {
+		names := make([]string, len(targets))
+		for i, n := range targets {
+			names[i] = n.Name()
+		}
+
+		err := r.queue.Enqueue(ctx, event, names)
+		if err == nil {
+			return nil
+		}
+		r.logger.Error(ctx, "Failed to queue notification, sending directly", err, logger.Fields{
+			"service_name": event.ServiceName,
+			"event_id":     event.ID,
+		})
+	}
+
+	return NewDispatcher(targets...).Notify(ctx, event)
+}
+
+// releases reports whether a recovery goes through the silence because its
+// failure began, and was announced, before the silence started
+func releases(silence *alert.Silence, event Event) bool {
+	return event.Type == EventRecovered && !event.FailingSince.IsZero() && event.FailingSince.Before(silence.StartsAt)
+}
+
+// escalatedChannels returns the policy's channels plus those of the
+// escalation steps already sent for the alert
+func escalatedChannels(policy *alert.Policy, open *alert.Alert) []string {
+	if open == nil {
+		return policy.Channels
+	}
+
+	channels := append([]string(nil), policy.Channels...)
+	for _, step := range policy.Escalation[:min(open.Escalated(policy.ID), len(policy.Escalation))] {
+		channels = append(channels, step.Channels...)
+	}
+	return channels
+}
+
+// fallbackKey identifies a service notified on every channel because no
+// policy selects it
+func fallbackKey(event Event) string {
+	return "*/" + dedupKey(event)
+}
+
+// routeKey identifies a service on a policy
+func routeKey(policy *alert.Policy, event Event) string {
+	return policy.ID + "/" + dedupKey(event)
+}
+
+// unsilenced drops the targets muted by an active silence. Recoveries of
+// failures that began before a silence started go through it, so incidents
+// opened before the silence are closed. When silences cannot be loaded every
+// target is kept, so pages still go out.
+func (r *Router) unsilenced(ctx context.Context, event Event, targets []Notifier) []Notifier {
+	if r.silences == nil || len(targets) == 0 {
+		return targets
+	}
+
+	now := r.now()
+	silences, err := r.silences.GetActive(ctx, now)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to load silences, notifying anyway", err, logger.Fields{
+			"service_name": event.ServiceName,
+		})
+		return targets
+	}
+	if len(silences) == 0 {
+		return targets
+	}
+
+	svc := r.lookup(ctx, event)
+	applied := make(map[string]bool)
+	var kept []Notifier
+	var muted []string
+	for _, n := range targets {
+		silenced := false
+		for _, silence := range silences {
+			if releases(silence, event) {
+				continue
+			}
+			if silence.Mutes(svc, n.Name(), now) {
+				applied[silence.ID] = true
+				silenced = true
+				break
+			}
+		}
+
+		if silenced {
+			muted = append(muted, n.Name())
+			continue
+		}
+		kept = append(kept, n)
+	}
+
+	if len(muted) > 0 {
+		r.logger.Info(ctx, "Notification silenced", logger.Fields{
+			"service_name": event.ServiceName,
+			"event_type":   string(event.Type),
+			"channels":     muted,
+			"silences":     keys(applied),
+		})
+	}
+
+	return kept
+}
+
+func keys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,510 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+type routerFixture struct {
+	router   *Router
+	policies *memory.PolicyRepository
+	alerts   *memory.AlertRepository
+	silences *memory.SilenceRepository
+	slack    *recordingNotifier
+	pager    *recordingNotifier
+	email    *recordingNotifier
+	now      time.Time
+}
+
+func newRouterFixture(t *testing.T, options ...RouterOption) *routerFixture {
+	t.Helper()
+
+	services := memory.NewServiceRepository()
+	require.NoError(t, services.Create(context.Background(), &service.Service{
+		Name: "API", Slug: "api", URL: "https://api.example.com", ExpectedStatus: 200, Enabled: true,
+		Group: "platform", Tags: []string{"core"},
+	}))
+
+	f := &routerFixture{
+		policies: memory.NewPolicyRepository(),
+		alerts:   memory.NewAlertRepository(),
+		silences: memory.NewSilenceRepository(),
+		slack:    &recordingNotifier{name: "slack"},
+		pager:    &recordingNotifier{name: "pagerduty"},
+		email:    &recordingNotifier{name: "email"},
+		now:      time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+	options = append([]RouterOption{
+		WithRouterClock(func() time.Time { return f.now }),
+		WithRouterAlerts(f.alerts),
+		WithRouterSilences(f.silences),
+	}, options...)
+	f.router = NewRouter(f.policies, services, []Notifier{f.slack, f.pager, f.email}, options...)
+	return f
+}
+
+func (f *routerFixture) addPolicy(t *testing.T, policy *alert.Policy) {
+	t.Helper()
+	policy.Enabled = true
+	require.NoError(t, f.policies.Create(context.Background(), policy))
+}
+
+func recoveredEvent() Event {
+	event := testEvent()
+	event.Type = EventRecovered
+	event.Status = service.StatusOperational
+	event.PreviousStatus = service.StatusDown
+	return event
+}
+
+func repeatEvent() Event {
+	event, _ := NewRepeatEvent("API", "api", service.StatusDown)
+	return event
+}
+
+func TestRouter_NoPoliciesNotifiesEveryChannel(t *testing.T) {
+	f := newRouterFixture(t)
+
+	require.NoError(t, f.router.Notify(context.Background(), testEvent()))
+
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Len(t, f.pager.Events(), 1)
+	assert.Len(t, f.email.Events(), 1)
+	assert.Equal(t, 3, f.router.Len())
+}
+
+func TestRouter_RoutesByGroupAndSeverity(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:       "platform pages",
+		Match:      alert.Matcher{Groups: []string{"platform"}},
+		Severities: []string{alert.SeverityCritical},
+		Channels:   []string{"pagerduty", "slack"},
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "core chat",
+		Match:    alert.Matcher{Tags: []string{"core"}},
+		Channels: []string{"slack"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	assert.Len(t, f.pager.Events(), 1)
+	assert.Len(t, f.slack.Events(), 1, "a channel shared by two policies is notified once")
+	assert.Empty(t, f.email.Events())
+
+	// Degraded is a warning, so only the chat policy applies
+	degraded := testEvent()
+	degraded.Type = EventDegraded
+	degraded.Status = service.StatusDegraded
+	require.NoError(t, f.router.Notify(ctx, degraded))
+	assert.Len(t, f.pager.Events(), 1)
+	assert.Len(t, f.slack.Events(), 2)
+}
+
+func TestRouter_UnmatchedServiceNotifiesEveryChannel(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:     "web only",
+		Match:    alert.Matcher{Services: []string{"web"}},
+		Channels: []string{"email"},
+	})
+
+	require.NoError(t, f.router.Notify(context.Background(), testEvent()))
+
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Len(t, f.pager.Events(), 1)
+	assert.Len(t, f.email.Events(), 1)
+}
+
+func TestRouter_RepeatInterval(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:           "repeat",
+		Channels:       []string{"pagerduty"},
+		RepeatInterval: alert.Duration(30 * time.Minute),
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "once",
+		Channels: []string{"slack"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+
+	f.now = f.now.Add(10 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.pager.Events(), 1)
+
+	f.now = f.now.Add(20 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	require.Len(t, f.pager.Events(), 2)
+	assert.True(t, f.pager.Events()[1].Repeat)
+	assert.Len(t, f.slack.Events(), 1)
+
+	// Recovery stops repeats
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	f.now = f.now.Add(time.Hour)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.pager.Events(), 3)
+	assert.Equal(t, EventRecovered, f.pager.Events()[2].Type)
+}
+
+func TestRouter_QuietHours(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:       "business hours",
+		Channels:   []string{"email"},
+		QuietHours: &alert.QuietHours{Start: "11:00", End: "13:00"},
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "always",
+		Channels: []string{"pagerduty"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	assert.Empty(t, f.email.Events())
+	assert.Len(t, f.pager.Events(), 1)
+
+	// Still quiet
+	f.now = f.now.Add(30 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Empty(t, f.email.Events())
+
+	// The held alert is sent once quiet hours end
+	f.now = f.now.Add(time.Hour)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	require.Len(t, f.email.Events(), 1)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.email.Events(), 1)
+	assert.Len(t, f.pager.Events(), 1)
+}
+
+func TestRouter_RecoveryOnlyWhereFailureWasSent(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:       "quiet",
+		Channels:   []string{"email"},
+		QuietHours: &alert.QuietHours{Start: "11:00", End: "13:00"},
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "pages",
+		Channels: []string{"pagerduty"},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+
+	assert.Empty(t, f.email.Events())
+	require.Len(t, f.pager.Events(), 2)
+	assert.Equal(t, EventRecovered, f.pager.Events()[1].Type)
+}
+
+func TestRouter_RecoveryAfterRestart(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:       "quiet",
+		Channels:   []string{"email"},
+		QuietHours: &alert.QuietHours{Start: "11:00", End: "13:00"},
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "pages",
+		Channels: []string{"pagerduty"},
+	})
+
+	ctx := context.Background()
+	check := func(observer *Observer, status string) {
+		observer.OnHealthCheckCompleted(ctx, checker.HealthCheckEvent{ServiceName: "API", ServiceSlug: "api", Status: status})
+	}
+
+	check(NewObserver(f.router, logger.Get(), WithObserverAlerts(f.alerts)), service.StatusDown)
+	require.Len(t, f.pager.Events(), 1)
+
+	// A new checker over the same stores knows nothing the old one held in memory
+	restarted := NewRouter(f.policies, f.router.services, []Notifier{f.slack, f.pager, f.email},
+		WithRouterClock(func() time.Time { return f.now }),
+		WithRouterAlerts(f.alerts),
+	)
+	check(NewObserver(restarted, logger.Get(), WithObserverAlerts(f.alerts)), service.StatusOperational)
+
+	// The recovery resolves the page, and nothing is sent where the failure was held
+	require.Len(t, f.pager.Events(), 2)
+	assert.Equal(t, EventRecovered, f.pager.Events()[1].Type)
+	assert.Equal(t, f.pager.Events()[0].AlertID, f.pager.Events()[1].AlertID)
+	assert.Empty(t, f.email.Events())
+	_, err := f.alerts.GetOpen(ctx, "api")
+	assert.Equal(t, alert.ErrAlertNotFound, err)
+}
+
+func TestRouter_TracksAlerts(t *testing.T) {
+	f := newRouterFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	open, err := f.alerts.GetOpen(ctx, "api")
+	require.NoError(t, err)
+	assert.Equal(t, alert.StateTriggered, open.State)
+	assert.Equal(t, alert.SeverityCritical, open.Severity)
+	assert.Equal(t, f.now, open.TriggeredAt)
+	assert.Equal(t, open.ID, f.slack.Events()[0].AlertID)
+
+	// A later failure belongs to the same alert
+	degraded := testEvent()
+	degraded.Type = EventDegraded
+	degraded.Status = service.StatusDegraded
+	require.NoError(t, f.router.Notify(ctx, degraded))
+	assert.Equal(t, open.ID, f.slack.Events()[1].AlertID)
+
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	assert.Equal(t, open.ID, f.slack.Events()[2].AlertID)
+
+	resolved, err := f.alerts.GetByID(ctx, open.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alert.StateResolved, resolved.State)
+	_, err = f.alerts.GetOpen(ctx, "api")
+	assert.Equal(t, alert.ErrAlertNotFound, err)
+}
+
+func TestRouter_EscalatesUnacknowledgedAlerts(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:     "platform",
+		Channels: []string{"slack"},
+		Escalation: []alert.Step{
+			{After: alert.Duration(15 * time.Minute), Channels: []string{"pagerduty"}},
+			{After: alert.Duration(time.Hour), Channels: []string{"email"}},
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Empty(t, f.pager.Events())
+
+	f.now = f.now.Add(10 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Empty(t, f.pager.Events())
+
+	f.now = f.now.Add(5 * time.Minute)
+	repeat := repeatEvent()
+	repeat.Timestamp = f.now
+	require.NoError(t, f.router.Repeat(ctx, repeat))
+	require.Len(t, f.pager.Events(), 1)
+	assert.Equal(t, 2, f.pager.Events()[0].EscalationLevel)
+	assert.NotEmpty(t, f.pager.Events()[0].AlertID)
+	assert.Equal(t, "API is still down after 15m. Not acknowledged, escalated to level 2.", mustRender(t, f.pager.Events()[0]).Text)
+	assert.Len(t, f.slack.Events(), 1, "escalation only notifies the new level")
+
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.pager.Events(), 1)
+
+	f.now = f.now.Add(45 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	require.Len(t, f.email.Events(), 1)
+	assert.Equal(t, 3, f.email.Events()[0].EscalationLevel)
+	assert.Len(t, f.pager.Events(), 1)
+
+	// Recovery reaches every level that heard about the failure
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	assert.Equal(t, EventRecovered, f.slack.Events()[1].Type)
+	assert.Equal(t, EventRecovered, f.pager.Events()[1].Type)
+	assert.Equal(t, EventRecovered, f.email.Events()[1].Type)
+}
+
+func TestRouter_Reminders(t *testing.T) {
+	f := newRouterFixture(t, WithRouterReminders(time.Hour))
+	f.addPolicy(t, &alert.Policy{
+		Name:           "own interval",
+		Match:          alert.Matcher{Services: []string{"api"}},
+		Channels:       []string{"pagerduty"},
+		RepeatInterval: alert.Duration(10 * time.Minute),
+	})
+	f.addPolicy(t, &alert.Policy{
+		Name:     "default interval",
+		Match:    alert.Matcher{Services: []string{"api"}},
+		Channels: []string{"slack"},
+	})
+
+	ctx := context.Background()
+	down := testEvent()
+	down.Timestamp = f.now
+	require.NoError(t, f.router.Notify(ctx, down))
+
+	f.now = f.now.Add(time.Hour)
+	reminder := repeatEvent()
+	reminder.Timestamp = f.now
+	require.NoError(t, f.router.Repeat(ctx, reminder))
+	require.Len(t, f.slack.Events(), 2)
+	assert.True(t, f.slack.Events()[1].Repeat)
+	assert.Equal(t, time.Hour, f.slack.Events()[1].OutageDuration(), "the outage starts when the alert was opened")
+	assert.Len(t, f.pager.Events(), 2)
+
+	// Reminders stop on recovery, which reports the whole outage
+	f.now = f.now.Add(30 * time.Minute)
+	recovered := recoveredEvent()
+	recovered.Timestamp = f.now
+	require.NoError(t, f.router.Notify(ctx, recovered))
+	require.Len(t, f.slack.Events(), 3)
+	assert.Equal(t, 90*time.Minute, f.slack.Events()[2].OutageDuration())
+
+	f.now = f.now.Add(2 * time.Hour)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.slack.Events(), 3)
+}
+
+func TestRouter_RemindersWithoutPolicies(t *testing.T) {
+	f := newRouterFixture(t, WithRouterReminders(30*time.Minute))
+	ctx := context.Background()
+
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	f.now = f.now.Add(20 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.email.Events(), 1)
+
+	f.now = f.now.Add(10 * time.Minute)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	require.Len(t, f.email.Events(), 2)
+	assert.Len(t, f.slack.Events(), 2)
+
+	// Acknowledging the alert stops reminders
+	open, err := f.alerts.GetOpen(ctx, "api")
+	require.NoError(t, err)
+	_, err = f.alerts.Acknowledge(ctx, open.ID, "alice", f.now)
+	require.NoError(t, err)
+
+	f.now = f.now.Add(time.Hour)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.email.Events(), 2)
+}
+
+func TestRouter_AcknowledgementStopsEscalationAndRepeats(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:           "platform",
+		Channels:       []string{"slack"},
+		RepeatInterval: alert.Duration(10 * time.Minute),
+		Escalation: []alert.Step{
+			{After: alert.Duration(15 * time.Minute), Channels: []string{"pagerduty"}},
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	open, err := f.alerts.GetOpen(ctx, "api")
+	require.NoError(t, err)
+
+	_, err = f.alerts.Acknowledge(ctx, open.ID, "alice", f.now.Add(time.Minute))
+	require.NoError(t, err)
+
+	f.now = f.now.Add(time.Hour)
+	require.NoError(t, f.router.Repeat(ctx, repeatEvent()))
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Empty(t, f.pager.Events())
+
+	// The acknowledged alert is still resolved on recovery
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	assert.Len(t, f.slack.Events(), 2)
+	got, err := f.alerts.GetByID(ctx, open.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alert.StateResolved, got.State)
+	assert.Equal(t, "alice", got.AcknowledgedBy)
+}
+
+func TestObserver_RepeatsThroughRepeater(t *testing.T) {
+	f := newRouterFixture(t)
+	f.addPolicy(t, &alert.Policy{
+		Name:           "repeat",
+		Channels:       []string{"slack"},
+		RepeatInterval: alert.Duration(time.Minute),
+	})
+
+	ctx := context.Background()
+	observer := NewObserver(f.router, f.router.logger)
+	down := checker.HealthCheckEvent{ServiceName: "API", ServiceSlug: "api", Status: service.StatusDown, Timestamp: f.now.Unix()}
+
+	observer.OnHealthCheckCompleted(ctx, down)
+	observer.OnHealthCheckCompleted(ctx, down)
+	assert.Len(t, f.slack.Events(), 1)
+
+	f.now = f.now.Add(time.Minute)
+	down.Timestamp = f.now.Unix()
+	observer.OnHealthCheckCompleted(ctx, down)
+	require.Len(t, f.slack.Events(), 2)
+	assert.True(t, f.slack.Events()[1].Repeat)
+	assert.Equal(t, "API is still down after 1m.", mustRender(t, f.slack.Events()[1]).Text)
+}
+
+func mustRender(t *testing.T, event Event) Message {
+	t.Helper()
+	msg, err := DefaultMessageTemplate().Render(event)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestRouter_Silences(t *testing.T) {
+	f := newRouterFixture(t)
+	ctx := context.Background()
+
+	silence := &alert.Silence{
+		Match:     alert.Matcher{Services: []string{"api"}},
+		Channels:  []string{"pagerduty"},
+		StartsAt:  f.now.Add(-time.Minute),
+		EndsAt:    f.now.Add(time.Hour),
+		CreatedBy: "alice",
+		Comment:   "database migration",
+	}
+	require.NoError(t, f.silences.Create(ctx, silence))
+
+	// A pending silence does not mute anything yet
+	require.NoError(t, f.silences.Create(ctx, &alert.Silence{
+		Match:     alert.Matcher{Groups: []string{"platform"}},
+		StartsAt:  f.now.Add(30 * time.Minute),
+		EndsAt:    f.now.Add(2 * time.Hour),
+		CreatedBy: "bob",
+		Comment:   "network maintenance",
+	}))
+
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	assert.Len(t, f.slack.Events(), 1)
+	assert.Len(t, f.email.Events(), 1)
+	assert.Empty(t, f.pager.Events())
+
+	// The alert is still tracked while notifications are silenced
+	open, err := f.alerts.GetOpen(ctx, "api")
+	require.NoError(t, err)
+	assert.True(t, open.IsOpen())
+
+	// Once the group silence starts every channel is muted, but the recovery
+	// of a failure announced before it started still goes out
+	f.now = f.now.Add(45 * time.Minute)
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	require.Len(t, f.slack.Events(), 2)
+	assert.Equal(t, EventRecovered, f.slack.Events()[1].Type)
+	assert.Len(t, f.email.Events(), 2)
+	assert.Empty(t, f.pager.Events(), "the failure was never sent to pagerduty")
+
+	// Failures that begin during the silence are muted, and so are their recoveries
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	f.now = f.now.Add(10 * time.Minute)
+	require.NoError(t, f.router.Notify(ctx, recoveredEvent()))
+	assert.Len(t, f.slack.Events(), 2)
+	assert.Len(t, f.email.Events(), 2)
+
+	// Expired silences mute nothing
+	f.now = f.now.Add(2 * time.Hour)
+	require.NoError(t, f.router.Notify(ctx, testEvent()))
+	assert.Len(t, f.slack.Events(), 3)
+	assert.Len(t, f.pager.Events(), 1)
+}
@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// slackHeaderLimit is the maximum length of a Block Kit header
+const slackHeaderLimit = 150
+
+// SlackNotifier posts Block Kit messages to a Slack incoming webhook
+type SlackNotifier struct {
+	url string
+	chatConfig
+}
+
+// NewSlackNotifier creates a Slack notifier for an incoming webhook URL
+func NewSlackNotifier(url string, options ...ChatOption) *SlackNotifier {
+	return &SlackNotifier{
+		url:        url,
+		chatConfig: newChatConfig(options),
+	}
+}
+
+// Name identifies the channel
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Notify posts the event to Slack
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	return n.send(ctx, n.Name(), n.url, event, func(msg Message) interface{} {
+		return NewSlackPayload(event, msg, n.username)
+	})
+}
+
+// SlackPayload is a Slack incoming webhook message
+type SlackPayload struct {
+	Text        string            `json:"text"`
+	Username    string            `json:"username,omitempty"`
+	Attachments []SlackAttachment `json:"attachments"`
+}
+
+// SlackAttachment carries the colored bar and the message blocks
+type SlackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Fields   []SlackText `json:"fields,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// NewSlackPayload builds the Slack message for an event
+func NewSlackPayload(event Event, msg Message, username string) SlackPayload {
+	fields := make([]SlackText, 0, 4)
+	for _, fact := range eventFacts(event) {
+		fields = append(fields, SlackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", fact[0], fact[1])})
+	}
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: truncate(msg.Title, slackHeaderLimit)}},
+		{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: escapeSlack(msg.Text)}},
+		{Type: "section", Fields: fields},
+		{Type: "context", Elements: []SlackText{{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("<!date^%d^{date_short_pretty} {time_secs}|%s>", event.Timestamp.Unix(), event.Timestamp.Format("2006-01-02 15:04:05 MST")),
+		}}},
+	}
+
+	return SlackPayload{
+		Text:     msg.Title,
+		Username: username,
+		Attachments: []SlackAttachment{{
+			Color:  fmt.Sprintf("#%06X", eventColor(event.Type)),
+			Blocks: blocks,
+		}},
+	}
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences in mrkdwn
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"context"
+)
+
+// TeamsNotifier posts Adaptive Cards to a Microsoft Teams incoming webhook or workflow
+type TeamsNotifier struct {
+	url string
+	chatConfig
+}
+
+// NewTeamsNotifier creates a Microsoft Teams notifier for a webhook URL
+func NewTeamsNotifier(url string, options ...ChatOption) *TeamsNotifier {
+	return &TeamsNotifier{
+		url:        url,
+		chatConfig: newChatConfig(options),
+	}
+}
+
+// Name identifies the channel
+func (n *TeamsNotifier) Name() string {
+	return "teams"
+}
+
+// Notify posts the event to Microsoft Teams
+func (n *TeamsNotifier) Notify(ctx context.Context, event Event) error {
+	return n.send(ctx, n.Name(), n.url, event, func(msg Message) interface{} {
+		return NewTeamsPayload(event, msg)
+	})
+}
+
+// TeamsPayload is a Teams message carrying a single Adaptive Card
+type TeamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment wraps the Adaptive Card
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is the subset of the Adaptive Card schema used for notifications
+type AdaptiveCard struct {
+	Schema  string              `json:"$schema"`
+	Type    string              `json:"type"`
+	Version string              `json:"version"`
+	Body    []AdaptiveCardBlock `json:"body"`
+}
+
+// AdaptiveCardBlock is a TextBlock or FactSet element
+type AdaptiveCardBlock struct {
+	Type   string             `json:"type"`
+	Text   string             `json:"text,omitempty"`
+	Size   string             `json:"size,omitempty"`
+	Weight string             `json:"weight,omitempty"`
+	Color  string             `json:"color,omitempty"`
+	Wrap   bool               `json:"wrap,omitempty"`
+	Facts  []AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+// AdaptiveCardFact is a title/value pair in a FactSet
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// NewTeamsPayload builds the Teams message for an event
+func NewTeamsPayload(event Event, msg Message) TeamsPayload {
+	facts := make([]AdaptiveCardFact, 0, 4)
+	for _, fact := range eventFacts(event) {
+		facts = append(facts, AdaptiveCardFact{Title: fact[0], Value: fact[1]})
+	}
+	facts = append(facts, AdaptiveCardFact{Title: "Time", Value: event.Timestamp.Format("2006-01-02 15:04:05 MST")})
+
+	return TeamsPayload{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: AdaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body: []AdaptiveCardBlock{
+					{Type: "TextBlock", Text: msg.Title, Size: "Large", Weight: "Bolder", Color: teamsColor(event.Type), Wrap: true},
+					{Type: "TextBlock", Text: msg.Text, Wrap: true},
+					{Type: "FactSet", Facts: facts},
+				},
+			},
+		}},
+	}
+}
+
+// teamsColor maps an event type to an Adaptive Card color name
+func teamsColor(eventType EventType) string {
+	switch eventType {
+	case EventDown:
+		return "Attention"
+	case EventDegraded:
+		return "Warning"
+	default:
+		return "Good"
+	}
+}
@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTelegramAPIURL is the Telegram Bot API base URL
+const DefaultTelegramAPIURL = "https://api.telegram.org"
+
+// Telegram limits, counted on the text after HTML entities are parsed
+const (
+	telegramTextLimit  = 4096
+	telegramTitleLimit = 256
+)
+
+// TelegramNotifier sends messages to a Telegram chat through the Bot API.
+// Failures notify with sound; degradations and recoveries arrive silently.
+type TelegramNotifier struct {
+	token        string
+	chatID       string
+	apiURL       string
+	client       HTTPClient
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// TelegramOption is a function that configures a TelegramNotifier
+type TelegramOption func(*TelegramNotifier)
+
+// WithTelegramAPIURL overrides the Bot API base URL, e.g. for a local Bot API server
+func WithTelegramAPIURL(apiURL string) TelegramOption {
+	return func(n *TelegramNotifier) {
+		if apiURL != "" {
+			n.apiURL = strings.TrimSuffix(apiURL, "/")
+		}
+	}
+}
+
+// WithTelegramHTTPClient sets a custom HTTP client
+func WithTelegramHTTPClient(client HTTPClient) TelegramOption {
+	return func(n *TelegramNotifier) {
+		n.client = client
+	}
+}
+
+// WithTelegramRetry sets the number of retries and the initial backoff between them
+func WithTelegramRetry(maxRetries int, backoff time.Duration) TelegramOption {
+	return func(n *TelegramNotifier) {
+		if maxRetries >= 0 {
+			n.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			n.retryBackoff = backoff
+		}
+	}
+}
+
+// WithTelegramTemplates sets where the message template of the channel comes from
+func WithTelegramTemplates(templates TemplateSource) TelegramOption {
+	return func(n *TelegramNotifier) {
+		if templates != nil {
+			n.templates = templates
+		}
+	}
+}
+
+// NewTelegramNotifier creates a Telegram notifier for a bot token and chat ID
+func NewTelegramNotifier(token, chatID string, options ...TelegramOption) *TelegramNotifier {
+	n := &TelegramNotifier{
+		token:  token,
+		chatID: chatID,
+		apiURL: DefaultTelegramAPIURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+		templates:    DefaultMessageTemplate(),
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Name identifies the channel
+func (n *TelegramNotifier) Name() string {
+	return "telegram"
+}
+
+// Notify sends the event to the chat
+func (n *TelegramNotifier) Notify(ctx context.Context, event Event) error {
+	msg, err := n.templates.Template(ctx, n.Name()).Render(event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(NewTelegramMessage(n.chatID, event, msg))
+	if err != nil {
+		return fmt.Errorf("failed to encode telegram message: %w", err)
+	}
+
+	sender := &httpSender{
+		name:         n.Name(),
+		client:       n.client,
+		maxRetries:   n.maxRetries,
+		retryBackoff: n.retryBackoff,
+	}
+	err = sender.post(ctx, n.apiURL+"/bot"+n.token+"/sendMessage", body, nil)
+	if err != nil && n.token != "" {
+		// The token is part of the URL, which transport errors quote
+		return stderrors.New(strings.ReplaceAll(err.Error(), n.token, "<token>"))
+	}
+	return err
+}
+
+// TelegramMessage is a Bot API sendMessage request
+type TelegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// NewTelegramMessage builds the sendMessage request for an event. Only
+// failures notify with sound.
+func NewTelegramMessage(chatID string, event Event, msg Message) TelegramMessage {
+	title := truncate(msg.Title, telegramTitleLimit)
+	text := truncate(msg.Text, telegramTextLimit-len([]rune(title))-1)
+
+	return TelegramMessage{
+		ChatID:                chatID,
+		Text:                  "<b>" + html.EscapeString(title) + "</b>\n" + html.EscapeString(text),
+		ParseMode:             "HTML",
+		DisableNotification:   event.Type != EventDown,
+		DisableWebPagePreview: true,
+	}
+}
@@ -0,0 +1,147 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// telegramStandIn mimics the Bot API sendMessage method for one bot
+type telegramStandIn struct {
+	mu       sync.Mutex
+	token    string
+	messages []TelegramMessage
+	statuses []int
+}
+
+func (s *telegramStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.statuses) > 0 {
+		status := s.statuses[0]
+		s.statuses = s.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+
+	if req.URL.Path != "/bot"+s.token+"/sendMessage" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "description": "Unauthorized"})
+		return
+	}
+
+	var msg TelegramMessage
+	if json.NewDecoder(req.Body).Decode(&msg) != nil || msg.ChatID == "" || msg.Text == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.messages = append(s.messages, msg)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+}
+
+func (s *telegramStandIn) Messages() []TelegramMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]TelegramMessage(nil), s.messages...)
+}
+
+func TestTelegramNotifier_Notify(t *testing.T) {
+	failure := testEvent()
+	failure.Error = "status <503>"
+
+	tests := []struct {
+		name          string
+		event         Event
+		statuses      []int
+		expectedText  string
+		expectedQuiet bool
+	}{
+		{
+			name:         "failure escapes HTML and notifies with sound",
+			event:        failure,
+			expectedText: "<b>Incident: API is down</b>\nAPI changed from operational to down. Error: status &lt;503&gt;",
+		},
+		{
+			name:          "recovery is silent",
+			event:         recoveredEvent(),
+			expectedText:  "<b>Resolved: API is operational again</b>",
+			expectedQuiet: true,
+		},
+		{
+			name:         "rate limited request is retried",
+			event:        failure,
+			statuses:     []int{http.StatusTooManyRequests},
+			expectedText: "<b>Incident: API is down</b>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &telegramStandIn{token: "123:abc", statuses: tt.statuses}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+
+			n := NewTelegramNotifier("123:abc", "-100200", WithTelegramAPIURL(server.URL+"/"), WithTelegramRetry(1, time.Millisecond))
+			assert.Equal(t, "telegram", n.Name())
+			require.NoError(t, n.Notify(context.Background(), tt.event))
+
+			messages := standIn.Messages()
+			require.Len(t, messages, 1)
+			assert.Equal(t, "-100200", messages[0].ChatID)
+			assert.Equal(t, "HTML", messages[0].ParseMode)
+			assert.True(t, strings.HasPrefix(messages[0].Text, tt.expectedText), messages[0].Text)
+			assert.Equal(t, tt.expectedQuiet, messages[0].DisableNotification)
+		})
+	}
+}
+
+func TestTelegramNotifier_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		statuses      []int
+		closed        bool
+		expectedError string
+	}{
+		{name: "wrong token", token: "other", expectedError: "status 401"},
+		{name: "retries exhausted", token: "123:secret", statuses: []int{http.StatusBadGateway, http.StatusBadGateway}, expectedError: "status 502"},
+		{name: "transport error hides the token", token: "123:secret", closed: true, expectedError: "/bot<token>/sendMessage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standIn := &telegramStandIn{token: tt.token, statuses: tt.statuses}
+			server := httptest.NewServer(standIn)
+			defer server.Close()
+			if tt.closed {
+				server.Close()
+			}
+
+			n := NewTelegramNotifier("123:secret", "42", WithTelegramAPIURL(server.URL), WithTelegramRetry(1, time.Millisecond))
+			err := n.Notify(context.Background(), testEvent())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.NotContains(t, err.Error(), "123:secret")
+			assert.Empty(t, standIn.Messages())
+		})
+	}
+}
+
+func TestNewTelegramMessage_Truncates(t *testing.T) {
+	msg := NewTelegramMessage("42", testEvent(), Message{Title: "Title", Text: strings.Repeat("&", 5000)})
+
+	// The limit applies to the text once entities are parsed
+	text := strings.TrimPrefix(msg.Text, "<b>Title</b>\n")
+	assert.Len(t, []rune(strings.ReplaceAll(text, "&amp;", "&")), telegramTextLimit-len("Title")-1)
+}
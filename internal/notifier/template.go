@@ -0,0 +1,190 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultMessageTemplate defines the "title" and "text" templates used by
+// chat channels. Custom templates may redefine either one.
+const defaultMessageTemplate = `
+{{- define "title" -}}
+{{- if .Grouped}}
+{{- if .IsRecovery}}Resolved: {{len .Grouped}} services are operational again ({{.GroupKey}})
+{{- else}}Incident: {{len .Failing}} services failing ({{.GroupKey}}){{end}}
+{{- else if .IsRecovery}}Resolved: {{.ServiceName}} is operational again
+{{- else}}Incident: {{.ServiceName}} is {{.Status}}{{end -}}
+{{- end -}}
+
+{{- define "text" -}}
+{{- if .Grouped}}
+{{- if .GroupUpdate}}Update: {{end}}{{len .Failing}} failing, {{len .Recovered}} recovered.
+{{- range .Grouped}}
+- {{.ServiceName}} {{if .Repeat}}is still {{.Status}}{{else}}changed from {{.PreviousStatus}} to {{.Status}}{{end}}{{if .Error}}: {{.Error}}{{end}}
+{{- end}}
+{{- else}}
+{{- if .Repeat}}{{.ServiceName}} is still {{.Status}}{{with .OutageDuration}} after {{formatDuration .}}{{end}}.
+{{- else}}{{.ServiceName}} changed from {{.PreviousStatus}} to {{.Status}}.{{end}}
+{{- if .Error}} Error: {{.Error}}{{end}}
+{{- if .IsRecovery}}{{with .OutageDuration}} Outage lasted {{formatDuration .}}.{{end}}{{end}}
+{{- end}}
+{{- if .EscalationLevel}} Not acknowledged, escalated to level {{.EscalationLevel}}.{{end}}
+{{- with .IncidentURL}}
+{{.}}{{end}}
+{{- end -}}
+`
+
+// Message is a rendered chat message
+type Message struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// MessageData is the data available to message templates. Besides the
+// event fields it carries IsRecovery and, when an incident URL is
+// configured, a link to the incident.
+type MessageData struct {
+	Event
+	IsRecovery  bool
+	IncidentURL string
+}
+
+// MessageTemplate renders chat messages for events
+type MessageTemplate struct {
+	tmpl        *template.Template
+	incidentURL string
+
+	// custom is set on templates parsed from user source
+	custom bool
+}
+
+// TemplateSource provides the message template of a channel. A
+// *MessageTemplate is a source that serves itself to every channel.
+type TemplateSource interface {
+	Template(ctx context.Context, channel string) *MessageTemplate
+}
+
+// Template returns t whatever the channel
+func (t *MessageTemplate) Template(ctx context.Context, channel string) *MessageTemplate {
+	return t
+}
+
+// WithIncidentURL returns a copy of t that links messages to the incident.
+// The pattern may contain {alert_id}, {event_id} and {service}, which are
+// replaced by the event's values; the link is left out when a placeholder
+// has no value.
+func (t *MessageTemplate) WithIncidentURL(pattern string) *MessageTemplate {
+	clone := *t
+	clone.incidentURL = pattern
+	return &clone
+}
+
+// templateFuncs are available in all message templates
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"formatTime": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"formatDuration": formatDuration,
+}
+
+// formatDuration renders d to the second below a minute and to the minute
+// above, e.g. "45s" or "2h5m"
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// DefaultMessageTemplate returns the built-in chat message template
+func DefaultMessageTemplate() *MessageTemplate {
+	return &MessageTemplate{
+		tmpl: template.Must(template.New("message").Funcs(templateFuncs).Parse(defaultMessageTemplate)),
+	}
+}
+
+// ParseMessageTemplate parses a custom template. The source may redefine the
+// "title" and "text" templates; anything it does not define keeps the default.
+func ParseMessageTemplate(src string) (*MessageTemplate, error) {
+	return DefaultMessageTemplate().Parse(src)
+}
+
+// Parse parses src over a copy of t, so that anything src does not define
+// keeps t's definition
+func (t *MessageTemplate) Parse(src string) (*MessageTemplate, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+
+	if _, err := tmpl.Parse(src); err != nil {
+		return nil, fmt.Errorf("failed to parse message template: %w", err)
+	}
+
+	return &MessageTemplate{tmpl: tmpl, incidentURL: t.incidentURL, custom: true}, nil
+}
+
+// Render renders the title and text for event
+func (t *MessageTemplate) Render(event Event) (Message, error) {
+	data := MessageData{
+		Event:       event,
+		IsRecovery:  event.Type == EventRecovered,
+		IncidentURL: incidentURL(t.incidentURL, event),
+	}
+
+	title, err := t.execute("title", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	text, err := t.execute("text", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Title: title, Text: text}, nil
+}
+
+// execute renders a named template and trims surrounding whitespace
+func (t *MessageTemplate) execute(name string, data MessageData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// incidentURL expands the placeholders of an incident URL pattern
+func incidentURL(pattern string, event Event) string {
+	if pattern == "" {
+		return ""
+	}
+
+	values := map[string]string{
+		"{alert_id}": event.AlertID,
+		"{event_id}": event.ID,
+		"{service}":  event.ServiceSlug,
+	}
+	link := pattern
+	for placeholder, value := range values {
+		if !strings.Contains(link, placeholder) {
+			continue
+		}
+		if value == "" {
+			return ""
+		}
+		link = strings.ReplaceAll(link, placeholder, url.PathEscape(value))
+	}
+	return link
+}
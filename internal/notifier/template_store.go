@@ -0,0 +1,220 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sukhera/uptime-monitor/internal/checker"
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/shared/errors"
+	"github.com/sukhera/uptime-monitor/internal/shared/logger"
+)
+
+// TemplateStore serves the message template of each channel: the template
+// stored for the channel, parsed over the channel's configured template,
+// or the configured or built-in template when none is stored. Stored
+// templates are reloaded once the cache TTL has passed, so edits made
+// through the API reach running checkers without a restart.
+type TemplateStore struct {
+	repo        alert.TemplateRepository
+	defaults    map[string]*MessageTemplate
+	incidentURL string
+	ttl         time.Duration
+	now         func() time.Time
+	logger      logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedTemplate
+}
+
+// cachedTemplate is a channel template and when it was loaded
+type cachedTemplate struct {
+	tmpl      *MessageTemplate
+	updatedAt time.Time
+	loadedAt  time.Time
+}
+
+// TemplateStoreOption configures a TemplateStore
+type TemplateStoreOption func(*TemplateStore)
+
+// WithChannelTemplate sets the template a channel falls back to, and that
+// its stored template is parsed over
+func WithChannelTemplate(channel string, tmpl *MessageTemplate) TemplateStoreOption {
+	return func(s *TemplateStore) {
+		if tmpl != nil {
+			s.defaults[channel] = tmpl
+		}
+	}
+}
+
+// WithTemplateIncidentURL links every message to its incident, see
+// MessageTemplate.WithIncidentURL
+func WithTemplateIncidentURL(pattern string) TemplateStoreOption {
+	return func(s *TemplateStore) {
+		s.incidentURL = pattern
+	}
+}
+
+// WithTemplateCacheTTL sets how long a loaded template is used before the
+// repository is asked again
+func WithTemplateCacheTTL(ttl time.Duration) TemplateStoreOption {
+	return func(s *TemplateStore) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+// WithTemplateLogger sets the logger
+func WithTemplateLogger(l logger.Logger) TemplateStoreOption {
+	return func(s *TemplateStore) {
+		s.logger = l
+	}
+}
+
+// WithTemplateClock overrides the clock used for cache expiry
+func WithTemplateClock(now func() time.Time) TemplateStoreOption {
+	return func(s *TemplateStore) {
+		s.now = now
+	}
+}
+
+// NewTemplateStore creates a template store backed by repo. Without a
+// repository it serves the configured templates only.
+func NewTemplateStore(repo alert.TemplateRepository, options ...TemplateStoreOption) *TemplateStore {
+	s := &TemplateStore{
+		repo:     repo,
+		defaults: make(map[string]*MessageTemplate),
+		ttl:      30 * time.Second,
+		now:      time.Now,
+		logger:   logger.Get(),
+		cache:    make(map[string]cachedTemplate),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// Template returns the template of channel. Storage failures are logged and
+// fall back to the last loaded or the configured template.
+func (s *TemplateStore) Template(ctx context.Context, channel string) *MessageTemplate {
+	base := s.base(channel)
+	if s.repo == nil {
+		return base
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.cache[channel]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.loadedAt) < s.ttl {
+		return cached.tmpl
+	}
+
+	fresh := cachedTemplate{tmpl: base, loadedAt: now}
+	stored, err := s.repo.Get(ctx, channel)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		s.logger.Error(ctx, "Failed to load message template, using the last known one", err, logger.Fields{
+			"channel": channel,
+		})
+		if ok {
+			fresh = cachedTemplate{tmpl: cached.tmpl, updatedAt: cached.updatedAt, loadedAt: now}
+		}
+	case ok && stored.UpdatedAt.Equal(cached.updatedAt):
+		fresh = cachedTemplate{tmpl: cached.tmpl, updatedAt: cached.updatedAt, loadedAt: now}
+	default:
+		tmpl, err := base.Parse(stored.Source)
+		if err != nil {
+			// Templates are checked when saved, so this is a template
+			// written to storage directly
+			s.logger.Error(ctx, "Stored message template is invalid, using the default", err, logger.Fields{
+				"channel": channel,
+			})
+		} else {
+			fresh.tmpl = tmpl
+		}
+		fresh.updatedAt = stored.UpdatedAt
+	}
+
+	s.mu.Lock()
+	s.cache[channel] = fresh
+	s.mu.Unlock()
+
+	return fresh.tmpl
+}
+
+// Invalidate drops the cached template of channel, so the next message
+// loads it again
+func (s *TemplateStore) Invalidate(channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, channel)
+}
+
+// Preview parses src as the template of channel and renders it for event
+// without storing it
+func (s *TemplateStore) Preview(channel, src string, event Event) (Message, error) {
+	tmpl, err := s.base(channel).Parse(src)
+	if err != nil {
+		return Message{}, err
+	}
+	return tmpl.Render(event)
+}
+
+// base returns the configured template of channel, or the built-in one
+func (s *TemplateStore) base(channel string) *MessageTemplate {
+	tmpl, ok := s.defaults[channel]
+	if !ok {
+		tmpl = DefaultMessageTemplate()
+	}
+	return tmpl.WithIncidentURL(s.incidentURL)
+}
+
+// previewOutage is how long the service of a preview event has been failing
+const previewOutage = 25 * time.Minute
+
+// SampleHealthCheckEvent returns a failed check of an example service
+func SampleHealthCheckEvent() checker.HealthCheckEvent {
+	return checker.HealthCheckEvent{
+		ServiceName: "API",
+		ServiceSlug: "api",
+		Status:      service.StatusDown,
+		Latency:     1532,
+		StatusCode:  503,
+		Error:       "unexpected status code: 503",
+		Timestamp:   time.Now().Unix(),
+	}
+}
+
+// PreviewEvent builds the event notified when check follows a check in the
+// previous status, for rendering previews. A check that leaves the status
+// unchanged gives a repeat of an ongoing outage. Failures and recoveries are
+// given an outage that began 25 minutes before the check. It returns false
+// when the checks warrant no notification.
+func PreviewEvent(check checker.HealthCheckEvent, previous string) (Event, bool) {
+	event, ok := NewEvent(check.ServiceName, check.ServiceSlug, previous, check.Status)
+	if !ok && previous == check.Status {
+		event, ok = NewRepeatEvent(check.ServiceName, check.ServiceSlug, check.Status)
+	}
+	if !ok {
+		return Event{}, false
+	}
+
+	event.Latency = check.Latency
+	event.StatusCode = check.StatusCode
+	event.Error = check.Error
+	event.AlertID = "preview"
+	if check.Timestamp > 0 {
+		event.Timestamp = time.Unix(check.Timestamp, 0).UTC()
+	}
+	event.FailingSince = event.Timestamp.Add(-previewOutage)
+
+	return event, true
+}
@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sukhera/uptime-monitor/internal/domain/alert"
+	"github.com/sukhera/uptime-monitor/internal/domain/service"
+	"github.com/sukhera/uptime-monitor/internal/infrastructure/database/memory"
+)
+
+// flakyTemplates fails lookups while err is set
+type flakyTemplates struct {
+	*memory.TemplateRepository
+	err   error
+	calls int
+}
+
+func (r *flakyTemplates) Get(ctx context.Context, channel string) (*alert.Template, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.TemplateRepository.Get(ctx, channel)
+}
+
+func TestTemplateStore_Template(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewTemplateRepository()
+	slack, err := ParseMessageTemplate(`{{define "text"}}slack: {{.ServiceName}}{{end}}`)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	store := NewTemplateStore(repo,
+		WithChannelTemplate("slack", slack),
+		WithTemplateCacheTTL(time.Minute),
+		WithTemplateClock(func() time.Time { return now }),
+	)
+
+	// Without stored templates channels keep their configured template
+	msg, err := store.Template(ctx, "slack").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: API is down", msg.Title)
+	assert.Equal(t, "slack: API", msg.Text)
+
+	msg, err = store.Template(ctx, "telegram").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code", msg.Text)
+
+	// A stored template is parsed over the configured one once the cache expires
+	require.NoError(t, repo.Save(ctx, &alert.Template{Channel: "slack", Source: `{{define "title"}}[{{upper .Status}}] {{.ServiceName}}{{end}}`}))
+	msg, err = store.Template(ctx, "slack").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: API is down", msg.Title, "cached until the TTL passes")
+
+	now = now.Add(time.Minute)
+	msg, err = store.Template(ctx, "slack").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "[DOWN] API", msg.Title)
+	assert.Equal(t, "slack: API", msg.Text)
+
+	// Invalidate forgets the cached template at once
+	require.NoError(t, repo.Delete(ctx, "slack"))
+	store.Invalidate("slack")
+	msg, err = store.Template(ctx, "slack").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: API is down", msg.Title)
+}
+
+func TestTemplateStore_StorageFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := &flakyTemplates{TemplateRepository: memory.NewTemplateRepository()}
+	require.NoError(t, repo.Save(ctx, &alert.Template{Channel: "ntfy", Source: `{{define "title"}}custom{{end}}`}))
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	store := NewTemplateStore(repo,
+		WithTemplateCacheTTL(time.Minute),
+		WithTemplateClock(func() time.Time { return now }),
+	)
+
+	msg, err := store.Template(ctx, "ntfy").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "custom", msg.Title)
+
+	// An unavailable store keeps the last loaded template
+	repo.err = stderrors.New("connection refused")
+	now = now.Add(2 * time.Minute)
+	msg, err = store.Template(ctx, "ntfy").Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "custom", msg.Title)
+
+	// Failed lookups are cached too, so an outage is not retried per message
+	store.Template(ctx, "ntfy")
+	assert.Equal(t, 2, repo.calls)
+
+	// Unchanged templates are not parsed again
+	repo.err = nil
+	now = now.Add(2 * time.Minute)
+	first := store.Template(ctx, "ntfy")
+	now = now.Add(2 * time.Minute)
+	assert.Same(t, first, store.Template(ctx, "ntfy"))
+}
+
+func TestTemplateStore_IncidentURL(t *testing.T) {
+	store := NewTemplateStore(nil, WithTemplateIncidentURL("https://status.example.com/alerts/{alert_id}"))
+
+	event := testEvent()
+	event.AlertID = "a/1"
+	msg, err := store.Template(context.Background(), "slack").Render(event)
+	require.NoError(t, err)
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code\nhttps://status.example.com/alerts/a%2F1", msg.Text)
+
+	// Events without an alert have no incident to link to
+	msg, err = store.Template(context.Background(), "slack").Render(testEvent())
+	require.NoError(t, err)
+	assert.NotContains(t, msg.Text, "https://")
+
+	msg, err = store.Preview("slack", `{{define "title"}}{{.IncidentURL}}{{end}}`, event)
+	require.NoError(t, err)
+	assert.Equal(t, "https://status.example.com/alerts/a%2F1", msg.Title)
+}
+
+func TestPreviewEvent(t *testing.T) {
+	check := SampleHealthCheckEvent()
+
+	event, ok := PreviewEvent(check, service.StatusOperational)
+	require.True(t, ok)
+	assert.Equal(t, EventDown, event.Type)
+	assert.Equal(t, int64(1532), event.Latency)
+	assert.Equal(t, 503, event.StatusCode)
+	assert.Equal(t, 25*time.Minute, event.OutageDuration())
+
+	event, ok = PreviewEvent(check, service.StatusDown)
+	require.True(t, ok)
+	assert.True(t, event.Repeat)
+
+	check.Status = service.StatusOperational
+	event, ok = PreviewEvent(check, service.StatusDown)
+	require.True(t, ok)
+	assert.Equal(t, EventRecovered, event.Type)
+
+	_, ok = PreviewEvent(check, service.StatusOperational)
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultMessageTemplate_Render(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     Event
+		wantTitle string
+		wantText  string
+	}{
+		{
+			name:      "down with error",
+			event:     testEvent(),
+			wantTitle: "Incident: API is down",
+			wantText:  "API changed from operational to down. Error: unexpected status code",
+		},
+		{
+			name: "recovered",
+			event: Event{
+				Type:           EventRecovered,
+				ServiceName:    "API",
+				Status:         "operational",
+				PreviousStatus: "down",
+			},
+			wantTitle: "Resolved: API is operational again",
+			wantText:  "API changed from down to operational.",
+		},
+		{
+			name: "recovered after an outage",
+			event: Event{
+				Type:           EventRecovered,
+				ServiceName:    "API",
+				Status:         "operational",
+				PreviousStatus: "down",
+				FailingSince:   time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+				Timestamp:      time.Date(2024, 1, 2, 3, 5, 20, 0, time.UTC),
+			},
+			wantTitle: "Resolved: API is operational again",
+			wantText:  "API changed from down to operational. Outage lasted 2h5m.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := DefaultMessageTemplate().Render(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, msg.Title)
+			assert.Equal(t, tt.wantText, msg.Text)
+		})
+	}
+}
+
+func TestParseMessageTemplate(t *testing.T) {
+	tmpl, err := ParseMessageTemplate(`{{define "title"}}[{{upper .Status}}] {{.ServiceSlug}}{{end}}`)
+	require.NoError(t, err)
+
+	msg, err := tmpl.Render(testEvent())
+	require.NoError(t, err)
+
+	assert.Equal(t, "[DOWN] api", msg.Title)
+	// The text template is not overridden and keeps the default
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code", msg.Text)
+
+	// Overrides must not leak into the defaults
+	msg, err = DefaultMessageTemplate().Render(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, "Incident: API is down", msg.Title)
+}
+
+func TestParseMessageTemplate_Errors(t *testing.T) {
+	_, err := ParseMessageTemplate(`{{define "title"}}{{.Status}`)
+	assert.Error(t, err)
+
+	tmpl, err := ParseMessageTemplate(`{{define "text"}}{{.Missing}}{{end}}`)
+	require.NoError(t, err)
+	_, err = tmpl.Render(testEvent())
+	assert.Error(t, err)
+}
+
+func TestMessageTemplate_IncidentURL(t *testing.T) {
+	event := testEvent()
+	event.AlertID = "42"
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "https://status.example.com/alerts/{alert_id}", want: "https://status.example.com/alerts/42"},
+		{pattern: "https://status.example.com/{service}/{event_id}", want: "https://status.example.com/api/evt-1"},
+		{pattern: "https://status.example.com/", want: "https://status.example.com/"},
+	}
+
+	for _, tt := range tests {
+		tmpl := DefaultMessageTemplate().WithIncidentURL(tt.pattern)
+		msg, err := tmpl.Render(event)
+		require.NoError(t, err)
+		assert.Equal(t, "API changed from operational to down. Error: unexpected status code\n"+tt.want, msg.Text, tt.pattern)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "45s", formatDuration(45*time.Second))
+	assert.Equal(t, "5m", formatDuration(5*time.Minute+10*time.Second))
+	assert.Equal(t, "2h", formatDuration(2*time.Hour))
+	assert.Equal(t, "26h30m", formatDuration(26*time.Hour+30*time.Minute))
+}
@@ -0,0 +1,257 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WebhookPayloadVersion is bumped whenever the payload shape changes incompatibly
+const WebhookPayloadVersion = "1"
+
+// Headers set on every webhook delivery
+const (
+	WebhookSignatureHeader = "X-Uptime-Signature"
+	WebhookTimestampHeader = "X-Uptime-Timestamp"
+	WebhookEventHeader     = "X-Uptime-Event"
+	WebhookDeliveryHeader  = "X-Uptime-Delivery"
+)
+
+// WebhookPayload is the JSON body POSTed to webhook receivers
+type WebhookPayload struct {
+	Version        string         `json:"version"`
+	ID             string         `json:"id"`
+	Type           EventType      `json:"type"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+	Service        WebhookService `json:"service"`
+	Status         string         `json:"status"`
+	PreviousStatus string         `json:"previous_status"`
+	Latency        int64          `json:"latency_ms"`
+	StatusCode     int            `json:"status_code,omitempty"`
+	Error          string         `json:"error,omitempty"`
+	Repeat         bool           `json:"repeat,omitempty"`
+	FailingSince   *time.Time     `json:"failing_since,omitempty"`
+	AlertID        string         `json:"alert_id,omitempty"`
+	Escalation     int            `json:"escalation_level,omitempty"`
+	Group          *WebhookGroup  `json:"group,omitempty"`
+	Message        *Message       `json:"message,omitempty"`
+}
+
+// WebhookService identifies the service in a webhook payload
+type WebhookService struct {
+	Name string `json:"name"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// WebhookGroup lists the services of a digest
+type WebhookGroup struct {
+	Key      string                `json:"key"`
+	Update   bool                  `json:"update,omitempty"`
+	Services []WebhookGroupService `json:"services"`
+}
+
+// WebhookGroupService is the state of one service in a digest. Changed is
+// false for services whose state is unchanged since the previous digest.
+type WebhookGroupService struct {
+	WebhookService
+	Type           EventType `json:"type"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status"`
+	Error          string    `json:"error,omitempty"`
+	Changed        bool      `json:"changed"`
+}
+
+// WebhookNotifier POSTs signed JSON payloads to an HTTP endpoint
+type WebhookNotifier struct {
+	url          string
+	secret       []byte
+	client       HTTPClient
+	headers      map[string]string
+	maxRetries   int
+	retryBackoff time.Duration
+	templates    TemplateSource
+}
+
+// WebhookOption is a function that configures a WebhookNotifier
+type WebhookOption func(*WebhookNotifier)
+
+// WithWebhookSecret sets the HMAC-SHA256 signing secret
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(w *WebhookNotifier) {
+		w.secret = []byte(secret)
+	}
+}
+
+// WithWebhookHTTPClient sets a custom HTTP client
+func WithWebhookHTTPClient(client HTTPClient) WebhookOption {
+	return func(w *WebhookNotifier) {
+		w.client = client
+	}
+}
+
+// WithWebhookHeaders sets additional request headers, e.g. for receiver authentication
+func WithWebhookHeaders(headers map[string]string) WebhookOption {
+	return func(w *WebhookNotifier) {
+		w.headers = headers
+	}
+}
+
+// WithWebhookRetry sets the number of retries and the initial backoff between them
+func WithWebhookRetry(maxRetries int, backoff time.Duration) WebhookOption {
+	return func(w *WebhookNotifier) {
+		if maxRetries >= 0 {
+			w.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			w.retryBackoff = backoff
+		}
+	}
+}
+
+// WithWebhookTemplates sets where the message template of the channel comes
+// from. Payloads carry the rendered message only when the channel has a
+// custom template.
+func WithWebhookTemplates(templates TemplateSource) WebhookOption {
+	return func(w *WebhookNotifier) {
+		w.templates = templates
+	}
+}
+
+// NewWebhookNotifier creates a webhook notifier for url
+func NewWebhookNotifier(url string, options ...WebhookOption) *WebhookNotifier {
+	w := &WebhookNotifier{
+		url: url,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries:   3,
+		retryBackoff: 500 * time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	return w
+}
+
+// Name identifies the channel
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Notify POSTs the event, retrying network errors, 429 and 5xx responses with exponential backoff
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	payload := NewWebhookPayload(event)
+	if w.templates != nil {
+		if tmpl := w.templates.Template(ctx, w.Name()); tmpl.custom {
+			msg, err := tmpl.Render(event)
+			if err != nil {
+				return err
+			}
+			payload.Message = &msg
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	return w.sender().post(ctx, w.url, body, func(req *http.Request) {
+		for k, v := range w.headers {
+			req.Header.Set(k, v)
+		}
+		req.Header.Set("User-Agent", "uptime-monitor-webhook/"+WebhookPayloadVersion)
+		req.Header.Set(WebhookEventHeader, string(event.Type))
+		req.Header.Set(WebhookDeliveryHeader, event.ID)
+
+		// Sign every attempt with a fresh timestamp
+		if len(w.secret) > 0 {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set(WebhookTimestampHeader, timestamp)
+			req.Header.Set(WebhookSignatureHeader, Sign(w.secret, timestamp, body))
+		}
+	})
+}
+
+// sender returns the retrying HTTP sender for this notifier
+func (w *WebhookNotifier) sender() *httpSender {
+	return &httpSender{
+		name:         "webhook",
+		client:       w.client,
+		maxRetries:   w.maxRetries,
+		retryBackoff: w.retryBackoff,
+	}
+}
+
+// NewWebhookPayload converts an event into the versioned webhook payload
+func NewWebhookPayload(event Event) WebhookPayload {
+	payload := WebhookPayload{
+		Version:    WebhookPayloadVersion,
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.Timestamp,
+		Service: WebhookService{
+			Name: event.ServiceName,
+			Slug: event.ServiceSlug,
+		},
+		Status:         event.Status,
+		PreviousStatus: event.PreviousStatus,
+		Latency:        event.Latency,
+		StatusCode:     event.StatusCode,
+		Error:          event.Error,
+		Repeat:         event.Repeat,
+		AlertID:        event.AlertID,
+		Escalation:     event.EscalationLevel,
+	}
+
+	if !event.FailingSince.IsZero() {
+		since := event.FailingSince
+		payload.FailingSince = &since
+	}
+
+	if len(event.Grouped) > 0 {
+		payload.Group = &WebhookGroup{
+			Key:    event.GroupKey,
+			Update: event.GroupUpdate,
+		}
+		for _, grouped := range event.Grouped {
+			payload.Group.Services = append(payload.Group.Services, WebhookGroupService{
+				WebhookService: WebhookService{
+					Name: grouped.ServiceName,
+					Slug: grouped.ServiceSlug,
+				},
+				Type:           grouped.Type,
+				Status:         grouped.Status,
+				PreviousStatus: grouped.PreviousStatus,
+				Error:          grouped.Error,
+				Changed:        !grouped.Repeat,
+			})
+		}
+	}
+
+	return payload
+}
+
+// Sign returns the signature header value for a delivery: the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>", prefixed with "sha256="
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is valid for the timestamp and body.
+// Receivers should also reject timestamps that are too old to prevent replays.
+func VerifySignature(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver records deliveries and replies with the queued status codes
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+	calls    atomic.Int32
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	call := int(r.calls.Add(1)) - 1
+
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	status := http.StatusOK
+	if call < len(r.statuses) {
+		status = r.statuses[call]
+	}
+	r.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+func testEvent() Event {
+	return Event{
+		ID:             "evt-1",
+		Type:           EventDown,
+		ServiceName:    "API",
+		ServiceSlug:    "api",
+		Status:         "down",
+		PreviousStatus: "operational",
+		StatusCode:     503,
+		Error:          "unexpected status code",
+		Timestamp:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	secret := "s3cret"
+	n := NewWebhookNotifier(server.URL,
+		WithWebhookSecret(secret),
+		WithWebhookHeaders(map[string]string{"Authorization": "Bearer token"}),
+	)
+
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	require.Len(t, receiver.requests, 1)
+
+	req := receiver.requests[0]
+	body := receiver.bodies[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	assert.Equal(t, "service.down", req.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "evt-1", req.Header.Get(WebhookDeliveryHeader))
+	assert.True(t, VerifySignature([]byte(secret), req.Header.Get(WebhookTimestampHeader), body, req.Header.Get(WebhookSignatureHeader)))
+	assert.False(t, VerifySignature([]byte("wrong"), req.Header.Get(WebhookTimestampHeader), body, req.Header.Get(WebhookSignatureHeader)))
+
+	assert.JSONEq(t, `{
+		"version": "1",
+		"id": "evt-1",
+		"type": "service.down",
+		"occurred_at": "2024-01-02T03:04:05Z",
+		"service": {"name": "API", "slug": "api"},
+		"status": "down",
+		"previous_status": "operational",
+		"latency_ms": 0,
+		"status_code": 503,
+		"error": "unexpected status code"
+	}`, string(body))
+}
+
+func TestWebhookNotifier_NoSecretOmitsSignature(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	require.NoError(t, NewWebhookNotifier(server.URL).Notify(context.Background(), testEvent()))
+
+	require.Len(t, receiver.requests, 1)
+	assert.Empty(t, receiver.requests[0].Header.Get(WebhookSignatureHeader))
+}
+
+func TestWebhookNotifier_CustomTemplate(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	// The built-in template adds nothing to the payload
+	require.NoError(t, NewWebhookNotifier(server.URL, WithWebhookTemplates(DefaultMessageTemplate())).Notify(context.Background(), testEvent()))
+
+	tmpl, err := ParseMessageTemplate(`{{define "title"}}{{.ServiceSlug}} {{.Status}}{{end}}`)
+	require.NoError(t, err)
+	require.NoError(t, NewWebhookNotifier(server.URL, WithWebhookTemplates(tmpl)).Notify(context.Background(), testEvent()))
+
+	require.Len(t, receiver.bodies, 2)
+	var plain, custom WebhookPayload
+	require.NoError(t, json.Unmarshal(receiver.bodies[0], &plain))
+	require.NoError(t, json.Unmarshal(receiver.bodies[1], &custom))
+	assert.Nil(t, plain.Message)
+	require.NotNil(t, custom.Message)
+	assert.Equal(t, "api down", custom.Message.Title)
+	assert.Equal(t, "API changed from operational to down. Error: unexpected status code", custom.Message.Text)
+}
+
+func TestWebhookNotifier_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name:      "retries server errors until success",
+			statuses:  []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusNoContent},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after max retries",
+			statuses:  []int{500, 500, 500, 500, 500},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "does not retry client errors",
+			statuses:  []int{http.StatusBadRequest},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &webhookReceiver{statuses: tt.statuses}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			n := NewWebhookNotifier(server.URL, WithWebhookRetry(2, time.Millisecond))
+			err := n.Notify(context.Background(), testEvent())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, receiver.calls.Load())
+		})
+	}
+}
+
+func TestWebhookNotifier_RetryStopsOnCancel(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{500, 500}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	n := NewWebhookNotifier(server.URL, WithWebhookRetry(5, time.Hour))
+	err := n.Notify(ctx, testEvent())
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), receiver.calls.Load())
+}
+
+func TestNewWebhookPayload(t *testing.T) {
+	payload := NewWebhookPayload(testEvent())
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	var decoded WebhookPayload
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, payload, decoded)
+	assert.Equal(t, WebhookPayloadVersion, decoded.Version)
+}
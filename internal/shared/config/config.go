@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,10 +14,13 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logging  LoggingConfig
-	Checker  CheckerConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Logging    LoggingConfig
+	Checker    CheckerConfig
+	Notifier   NotifierConfig
+	Tracing    TracingConfig
+	Middleware MiddlewareConfig
 }
 
 // ServerConfig holds server-specific configuration
@@ -26,8 +32,15 @@ type ServerConfig struct {
 	IdleTimeout       time.Duration
 }
 
+// Supported database drivers
+const (
+	DriverMongo    = "mongodb"
+	DriverPostgres = "postgres"
+)
+
 // DatabaseConfig holds database-specific configuration
 type DatabaseConfig struct {
+	Driver  string
 	URI     string
 	Name    string
 	Timeout time.Duration
@@ -41,7 +54,95 @@ type LoggingConfig struct {
 
 // CheckerConfig holds checker-specific configuration
 type CheckerConfig struct {
-	Interval time.Duration
+	Interval      time.Duration
+	BatchSize     int
+	FlushInterval time.Duration
+	MetricsAddr   string
+}
+
+// TracingConfig holds OpenTelemetry trace export configuration
+type TracingConfig struct {
+	Endpoint    string  // OTLP/HTTP traces URL; tracing is disabled when empty
+	SampleRatio float64 // Fraction of new traces that are recorded
+}
+
+// MiddlewareConfig selects the middlewares applied to API requests and holds
+// their settings
+type MiddlewareConfig struct {
+	Recovery        bool          // Answer 500 instead of dropping the connection when a handler panics
+	RequestID       bool          // Tag requests with X-Request-ID
+	RequestLogging  bool          // Log one line per request
+	SecurityHeaders bool          // Send CSP, HSTS and related headers
+	CORS            bool          // Answer cross-origin requests from browsers
+	RateLimit       float64       // Requests per second per client; rate limiting is disabled when zero
+	RequestTimeout  time.Duration // Deadline of request contexts; disabled when zero
+
+	RateLimitBurst     int      // Requests a client may send at once; defaults to one second of requests
+	RateLimitKeyHeader string   // Header keying clients, such as an API key; clients are keyed by IP when empty
+	TrustedProxies     []string // CIDR ranges or IPs of proxies whose forwarded headers are honoured
+
+	IPAllowlist   []string // CIDR ranges or IPs of the only clients allowed; all clients are allowed when empty
+	IPDenylist    []string // CIDR ranges or IPs of rejected clients; wins over the allowlist
+	IPFilterPaths []string // Route prefixes the IP lists apply to; every route when empty
+}
+
+// NotifierConfig holds notification channel configuration
+type NotifierConfig struct {
+	WebhookURL    string
+	WebhookSecret string
+
+	// Chat channels; the template files optionally override the message templates
+	SlackWebhookURL     string
+	SlackTemplateFile   string
+	TeamsWebhookURL     string
+	TeamsTemplateFile   string
+	DiscordWebhookURL   string
+	DiscordTemplateFile string
+
+	// Email over SMTP; a zero port selects the default for the TLS mode
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPFrom        string
+	SMTPTo          []string
+	SMTPTLS         string
+	SMTPTemplateDir string
+
+	// Paging; events are deduplicated by service slug so recoveries resolve the page
+	PagerDutyRoutingKey string
+	PagerDutyEventsURL  string
+	OpsgenieAPIKey      string
+	OpsgenieAPIURL      string
+
+	// Mobile push; each channel is enabled by its token or topic
+	TelegramBotToken string
+	TelegramChatID   string
+	TelegramAPIURL   string
+	NtfyURL          string
+	NtfyTopic        string
+	NtfyToken        string
+	PushoverAppToken string
+	PushoverUserKey  string
+	PushoverAPIURL   string
+
+	// Outbox; zero values select the dispatcher defaults
+	OutboxInterval    time.Duration
+	OutboxMaxAttempts int
+
+	// Grouping of failures into digests; a zero wait disables it and a zero
+	// interval selects the grouper default
+	GroupBy       []string
+	GroupWait     time.Duration
+	GroupInterval time.Duration
+
+	// ReminderInterval re-sends failures while they last; zero disables
+	// reminders for services whose policies set no repeat interval
+	ReminderInterval time.Duration
+
+	// IncidentURL links messages to their incident; {alert_id}, {event_id}
+	// and {service} are replaced by the event's values
+	IncidentURL string
 }
 
 // Option is a function that configures a Config
@@ -73,6 +174,13 @@ func WithDatabase(uri, name string, timeout time.Duration) Option {
 	}
 }
 
+// WithDatabaseDriver sets the storage backend used for service and status data
+func WithDatabaseDriver(driver string) Option {
+	return func(c *Config) {
+		c.Database.Driver = driver
+	}
+}
+
 // WithLogging sets logging configuration
 func WithLogging(level string, json bool) Option {
 	return func(c *Config) {
@@ -88,6 +196,118 @@ func WithCheckerInterval(interval time.Duration) Option {
 	}
 }
 
+// WithCheckerBatching sets how status logs are buffered before being written
+func WithCheckerBatching(batchSize int, flushInterval time.Duration) Option {
+	return func(c *Config) {
+		c.Checker.BatchSize = batchSize
+		c.Checker.FlushInterval = flushInterval
+	}
+}
+
+// WithCheckerMetricsAddr sets the address the checker serves /metrics on; empty disables it
+func WithCheckerMetricsAddr(addr string) Option {
+	return func(c *Config) {
+		c.Checker.MetricsAddr = addr
+	}
+}
+
+// WithWebhook sets the outgoing webhook URL and its HMAC signing secret
+func WithWebhook(url, secret string) Option {
+	return func(c *Config) {
+		c.Notifier.WebhookURL = url
+		c.Notifier.WebhookSecret = secret
+	}
+}
+
+// WithChatWebhooks sets the Slack, Microsoft Teams and Discord webhook URLs
+func WithChatWebhooks(slack, teams, discord string) Option {
+	return func(c *Config) {
+		c.Notifier.SlackWebhookURL = slack
+		c.Notifier.TeamsWebhookURL = teams
+		c.Notifier.DiscordWebhookURL = discord
+	}
+}
+
+// WithSMTP sets the SMTP relay and the email sender and recipients
+func WithSMTP(host string, port int, from string, to []string) Option {
+	return func(c *Config) {
+		c.Notifier.SMTPHost = host
+		c.Notifier.SMTPPort = port
+		c.Notifier.SMTPFrom = from
+		c.Notifier.SMTPTo = to
+	}
+}
+
+// WithPaging sets the PagerDuty routing key and the Opsgenie API key
+func WithPaging(pagerDutyRoutingKey, opsgenieAPIKey string) Option {
+	return func(c *Config) {
+		c.Notifier.PagerDutyRoutingKey = pagerDutyRoutingKey
+		c.Notifier.OpsgenieAPIKey = opsgenieAPIKey
+	}
+}
+
+// WithPush sets the Telegram bot and chat, the ntfy topic and the Pushover
+// application and user
+func WithPush(telegramBotToken, telegramChatID, ntfyTopic, pushoverAppToken, pushoverUserKey string) Option {
+	return func(c *Config) {
+		c.Notifier.TelegramBotToken = telegramBotToken
+		c.Notifier.TelegramChatID = telegramChatID
+		c.Notifier.NtfyTopic = ntfyTopic
+		c.Notifier.PushoverAppToken = pushoverAppToken
+		c.Notifier.PushoverUserKey = pushoverUserKey
+	}
+}
+
+// WithOutbox sets how often the notification outbox is polled and how many
+// attempts a delivery gets before it is dead-lettered
+func WithOutbox(interval time.Duration, maxAttempts int) Option {
+	return func(c *Config) {
+		c.Notifier.OutboxInterval = interval
+		c.Notifier.OutboxMaxAttempts = maxAttempts
+	}
+}
+
+// WithGrouping groups failures of services sharing the by keys into
+// digests sent after wait, with updates at most once per interval
+func WithGrouping(by []string, wait, interval time.Duration) Option {
+	return func(c *Config) {
+		c.Notifier.GroupBy = by
+		c.Notifier.GroupWait = wait
+		c.Notifier.GroupInterval = interval
+	}
+}
+
+// WithReminders re-sends failures every interval until they are
+// acknowledged or the service recovers
+func WithReminders(interval time.Duration) Option {
+	return func(c *Config) {
+		c.Notifier.ReminderInterval = interval
+	}
+}
+
+// WithIncidentURL sets the incident link pattern used in messages
+func WithIncidentURL(pattern string) Option {
+	return func(c *Config) {
+		c.Notifier.IncidentURL = pattern
+	}
+}
+
+// WithTracing exports traces to an OTLP/HTTP endpoint, sampling the given
+// fraction of new traces
+func WithTracing(endpoint string, sampleRatio float64) Option {
+	return func(c *Config) {
+		c.Tracing.Endpoint = endpoint
+		c.Tracing.SampleRatio = sampleRatio
+	}
+}
+
+// WithMiddleware sets the API middleware pipeline configuration
+func WithMiddleware(middleware MiddlewareConfig) Option {
+	return func(c *Config) {
+		c.Middleware = middleware
+	}
+}
+
 // FromEnvironment loads configuration from environment variables
 func FromEnvironment() Option {
 	return func(c *Config) {
@@ -97,7 +317,8 @@ func FromEnvironment() Option {
 		c.Server.WriteTimeout = getDurationEnv("WRITE_TIMEOUT", 15*time.Second)
 		c.Server.IdleTimeout = getDurationEnv("IDLE_TIMEOUT", 60*time.Second)
 
-		c.Database.URI = getEnv("MONGO_URI", "mongodb://localhost:27017")
+		c.Database.Driver = getEnv("DB_DRIVER", DriverMongo)
+		c.Database.URI = getEnv("MONGO_URI", getEnv("DB_URL", "mongodb://localhost:27017"))
 		c.Database.Name = getEnv("DB_NAME", "statuspage")
 		c.Database.Timeout = getDurationEnv("DB_TIMEOUT", 10*time.Second)
 
@@ -105,6 +326,63 @@ func FromEnvironment() Option {
 		c.Logging.JSON = getBoolEnv("LOG_JSON", false)
 
 		c.Checker.Interval = getDurationEnv("CHECK_INTERVAL", 2*time.Minute)
+		c.Checker.BatchSize = getIntEnv("CHECKER_BATCH_SIZE", 100)
+		c.Checker.FlushInterval = getDurationEnv("CHECKER_FLUSH_INTERVAL", 5*time.Second)
+		c.Checker.MetricsAddr = getEnv("CHECKER_METRICS_ADDR", ":9091")
+
+		c.Notifier.WebhookURL = getEnv("WEBHOOK_URL", "")
+		c.Notifier.WebhookSecret = getEnv("WEBHOOK_SECRET", "")
+		c.Notifier.SlackWebhookURL = getEnv("SLACK_WEBHOOK_URL", "")
+		c.Notifier.SlackTemplateFile = getEnv("SLACK_TEMPLATE_FILE", "")
+		c.Notifier.TeamsWebhookURL = getEnv("TEAMS_WEBHOOK_URL", "")
+		c.Notifier.TeamsTemplateFile = getEnv("TEAMS_TEMPLATE_FILE", "")
+		c.Notifier.DiscordWebhookURL = getEnv("DISCORD_WEBHOOK_URL", "")
+		c.Notifier.DiscordTemplateFile = getEnv("DISCORD_TEMPLATE_FILE", "")
+		c.Notifier.SMTPHost = getEnv("SMTP_HOST", "")
+		c.Notifier.SMTPPort = getIntEnv("SMTP_PORT", 0)
+		c.Notifier.SMTPUsername = getEnv("SMTP_USERNAME", "")
+		c.Notifier.SMTPPassword = getEnv("SMTP_PASSWORD", "")
+		c.Notifier.SMTPFrom = getEnv("SMTP_FROM", "")
+		c.Notifier.SMTPTo = splitList(getEnv("SMTP_TO", ""))
+		c.Notifier.SMTPTLS = getEnv("SMTP_TLS", "")
+		c.Notifier.SMTPTemplateDir = getEnv("SMTP_TEMPLATE_DIR", "")
+		c.Notifier.PagerDutyRoutingKey = getEnv("PAGERDUTY_ROUTING_KEY", "")
+		c.Notifier.PagerDutyEventsURL = getEnv("PAGERDUTY_EVENTS_URL", "")
+		c.Notifier.OpsgenieAPIKey = getEnv("OPSGENIE_API_KEY", "")
+		c.Notifier.OpsgenieAPIURL = getEnv("OPSGENIE_API_URL", "")
+		c.Notifier.TelegramBotToken = getEnv("TELEGRAM_BOT_TOKEN", "")
+		c.Notifier.TelegramChatID = getEnv("TELEGRAM_CHAT_ID", "")
+		c.Notifier.TelegramAPIURL = getEnv("TELEGRAM_API_URL", "")
+		c.Notifier.NtfyURL = getEnv("NTFY_URL", "")
+		c.Notifier.NtfyTopic = getEnv("NTFY_TOPIC", "")
+		c.Notifier.NtfyToken = getEnv("NTFY_TOKEN", "")
+		c.Notifier.PushoverAppToken = getEnv("PUSHOVER_APP_TOKEN", "")
+		c.Notifier.PushoverUserKey = getEnv("PUSHOVER_USER_KEY", "")
+		c.Notifier.PushoverAPIURL = getEnv("PUSHOVER_API_URL", "")
+		c.Notifier.OutboxInterval = getDurationEnv("NOTIFIER_OUTBOX_INTERVAL", 0)
+		c.Notifier.OutboxMaxAttempts = getIntEnv("NOTIFIER_OUTBOX_MAX_ATTEMPTS", 0)
+		c.Notifier.GroupBy = splitList(getEnv("NOTIFIER_GROUP_BY", ""))
+		c.Notifier.GroupWait = getDurationEnv("NOTIFIER_GROUP_WAIT", 0)
+		c.Notifier.GroupInterval = getDurationEnv("NOTIFIER_GROUP_INTERVAL", 0)
+		c.Notifier.ReminderInterval = getDurationEnv("NOTIFIER_REMINDER_INTERVAL", 0)
+		c.Notifier.IncidentURL = getEnv("NOTIFIER_INCIDENT_URL", "")
+
+		c.Tracing.Endpoint = getEnv("TRACING_ENDPOINT", "")
+		c.Tracing.SampleRatio = getFloatEnv("TRACING_SAMPLE_RATIO", 1)
+
+		c.Middleware.Recovery = getBoolEnv("MIDDLEWARE_RECOVERY", true)
+		c.Middleware.RequestID = getBoolEnv("MIDDLEWARE_REQUEST_ID", true)
+		c.Middleware.RequestLogging = getBoolEnv("MIDDLEWARE_REQUEST_LOGGING", true)
+		c.Middleware.SecurityHeaders = getBoolEnv("MIDDLEWARE_SECURITY_HEADERS", true)
+		c.Middleware.CORS = getBoolEnv("MIDDLEWARE_CORS", true)
+		c.Middleware.RateLimit = getFloatEnv("MIDDLEWARE_RATE_LIMIT", 0)
+		c.Middleware.RequestTimeout = getDurationEnv("MIDDLEWARE_REQUEST_TIMEOUT", 10*time.Second)
+		c.Middleware.RateLimitBurst = getIntEnv("MIDDLEWARE_RATE_LIMIT_BURST", 0)
+		c.Middleware.RateLimitKeyHeader = getEnv("MIDDLEWARE_RATE_LIMIT_KEY_HEADER", "")
+		c.Middleware.TrustedProxies = splitList(getEnv("MIDDLEWARE_TRUSTED_PROXIES", ""))
+		c.Middleware.IPAllowlist = splitList(getEnv("MIDDLEWARE_IP_ALLOWLIST", ""))
+		c.Middleware.IPDenylist = splitList(getEnv("MIDDLEWARE_IP_DENYLIST", ""))
+		c.Middleware.IPFilterPaths = splitList(getEnv("MIDDLEWARE_IP_FILTER_PATHS", ""))
 	}
 }
 
@@ -119,6 +397,7 @@ func New(options ...Option) *Config {
 			IdleTimeout:       60 * time.Second,
 		},
 		Database: DatabaseConfig{
+			Driver:  DriverMongo,
 			URI:     "mongodb://localhost:27017",
 			Name:    "statuspage",
 			Timeout: 10 * time.Second,
@@ -128,7 +407,21 @@ func New(options ...Option) *Config {
 			JSON:  false,
 		},
 		Checker: CheckerConfig{
-			Interval: 2 * time.Minute,
+			Interval:      2 * time.Minute,
+			BatchSize:     100,
+			FlushInterval: 5 * time.Second,
+			MetricsAddr:   ":9091",
+		},
+		Tracing: TracingConfig{
+			SampleRatio: 1,
+		},
+		Middleware: MiddlewareConfig{
+			Recovery:        true,
+			RequestID:       true,
+			RequestLogging:  true,
+			SecurityHeaders: true,
+			CORS:            true,
+			RequestTimeout:  10 * time.Second,
 		},
 	}
 
@@ -153,10 +446,65 @@ func LoadFromViper() *Config {
 	viper.AutomaticEnv()
 	
 	// Bind specific environment variables to viper keys
+	_ = viper.BindEnv("database.driver", "DB_DRIVER")
 	_ = viper.BindEnv("database.url", "MONGO_URI")
 	_ = viper.BindEnv("server.port", "PORT")
 	_ = viper.BindEnv("logging.level", "LOG_LEVEL")
 	_ = viper.BindEnv("checker.interval", "CHECK_INTERVAL")
+	_ = viper.BindEnv("checker.batch_size", "CHECKER_BATCH_SIZE")
+	_ = viper.BindEnv("checker.flush_interval", "CHECKER_FLUSH_INTERVAL")
+	_ = viper.BindEnv("checker.metrics_addr", "CHECKER_METRICS_ADDR")
+	_ = viper.BindEnv("notifier.webhook_url", "WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.webhook_secret", "WEBHOOK_SECRET")
+	_ = viper.BindEnv("notifier.slack_webhook_url", "SLACK_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.slack_template_file", "SLACK_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.teams_webhook_url", "TEAMS_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.teams_template_file", "TEAMS_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.discord_webhook_url", "DISCORD_WEBHOOK_URL")
+	_ = viper.BindEnv("notifier.discord_template_file", "DISCORD_TEMPLATE_FILE")
+	_ = viper.BindEnv("notifier.smtp_host", "SMTP_HOST")
+	_ = viper.BindEnv("notifier.smtp_port", "SMTP_PORT")
+	_ = viper.BindEnv("notifier.smtp_username", "SMTP_USERNAME")
+	_ = viper.BindEnv("notifier.smtp_password", "SMTP_PASSWORD")
+	_ = viper.BindEnv("notifier.smtp_from", "SMTP_FROM")
+	_ = viper.BindEnv("notifier.smtp_to", "SMTP_TO")
+	_ = viper.BindEnv("notifier.smtp_tls", "SMTP_TLS")
+	_ = viper.BindEnv("notifier.smtp_template_dir", "SMTP_TEMPLATE_DIR")
+	_ = viper.BindEnv("notifier.pagerduty_routing_key", "PAGERDUTY_ROUTING_KEY")
+	_ = viper.BindEnv("notifier.pagerduty_events_url", "PAGERDUTY_EVENTS_URL")
+	_ = viper.BindEnv("notifier.opsgenie_api_key", "OPSGENIE_API_KEY")
+	_ = viper.BindEnv("notifier.opsgenie_api_url", "OPSGENIE_API_URL")
+	_ = viper.BindEnv("notifier.telegram_bot_token", "TELEGRAM_BOT_TOKEN")
+	_ = viper.BindEnv("notifier.telegram_chat_id", "TELEGRAM_CHAT_ID")
+	_ = viper.BindEnv("notifier.telegram_api_url", "TELEGRAM_API_URL")
+	_ = viper.BindEnv("notifier.ntfy_url", "NTFY_URL")
+	_ = viper.BindEnv("notifier.ntfy_topic", "NTFY_TOPIC")
+	_ = viper.BindEnv("notifier.ntfy_token", "NTFY_TOKEN")
+	_ = viper.BindEnv("notifier.pushover_app_token", "PUSHOVER_APP_TOKEN")
+	_ = viper.BindEnv("notifier.pushover_user_key", "PUSHOVER_USER_KEY")
+	_ = viper.BindEnv("notifier.pushover_api_url", "PUSHOVER_API_URL")
+	_ = viper.BindEnv("notifier.outbox_interval", "NOTIFIER_OUTBOX_INTERVAL")
+	_ = viper.BindEnv("notifier.outbox_max_attempts", "NOTIFIER_OUTBOX_MAX_ATTEMPTS")
+	_ = viper.BindEnv("notifier.group_by", "NOTIFIER_GROUP_BY")
+	_ = viper.BindEnv("notifier.group_wait", "NOTIFIER_GROUP_WAIT")
+	_ = viper.BindEnv("notifier.group_interval", "NOTIFIER_GROUP_INTERVAL")
+	_ = viper.BindEnv("notifier.reminder_interval", "NOTIFIER_REMINDER_INTERVAL")
+	_ = viper.BindEnv("notifier.incident_url", "NOTIFIER_INCIDENT_URL")
+	_ = viper.BindEnv("tracing.endpoint", "TRACING_ENDPOINT")
+	_ = viper.BindEnv("tracing.sample_ratio", "TRACING_SAMPLE_RATIO")
+	_ = viper.BindEnv("middleware.recovery", "MIDDLEWARE_RECOVERY")
+	_ = viper.BindEnv("middleware.request_id", "MIDDLEWARE_REQUEST_ID")
+	_ = viper.BindEnv("middleware.request_logging", "MIDDLEWARE_REQUEST_LOGGING")
+	_ = viper.BindEnv("middleware.security_headers", "MIDDLEWARE_SECURITY_HEADERS")
+	_ = viper.BindEnv("middleware.cors", "MIDDLEWARE_CORS")
+	_ = viper.BindEnv("middleware.rate_limit", "MIDDLEWARE_RATE_LIMIT")
+	_ = viper.BindEnv("middleware.request_timeout", "MIDDLEWARE_REQUEST_TIMEOUT")
+	_ = viper.BindEnv("middleware.rate_limit_burst", "MIDDLEWARE_RATE_LIMIT_BURST")
+	_ = viper.BindEnv("middleware.rate_limit_key_header", "MIDDLEWARE_RATE_LIMIT_KEY_HEADER")
+	_ = viper.BindEnv("middleware.trusted_proxies", "MIDDLEWARE_TRUSTED_PROXIES")
+	_ = viper.BindEnv("middleware.ip_allowlist", "MIDDLEWARE_IP_ALLOWLIST")
+	_ = viper.BindEnv("middleware.ip_denylist", "MIDDLEWARE_IP_DENYLIST")
+	_ = viper.BindEnv("middleware.ip_filter_paths", "MIDDLEWARE_IP_FILTER_PATHS")
 
 	config := &Config{
 		Server: ServerConfig{
@@ -167,6 +515,7 @@ func LoadFromViper() *Config {
 			IdleTimeout:       viper.GetDuration("server.idle_timeout"),
 		},
 		Database: DatabaseConfig{
+			Driver:  viper.GetString("database.driver"),
 			URI:     viper.GetString("database.url"),
 			Name:    viper.GetString("database.name"),
 			Timeout: viper.GetDuration("database.timeout"),
@@ -176,7 +525,76 @@ func LoadFromViper() *Config {
 			JSON:  viper.GetBool("logging.json"),
 		},
 		Checker: CheckerConfig{
-			Interval: viper.GetDuration("checker.interval"),
+			Interval:      viper.GetDuration("checker.interval"),
+			BatchSize:     viper.GetInt("checker.batch_size"),
+			FlushInterval: viper.GetDuration("checker.flush_interval"),
+			MetricsAddr:   viper.GetString("checker.metrics_addr"),
+		},
+		Notifier: NotifierConfig{
+			WebhookURL:    viper.GetString("notifier.webhook_url"),
+			WebhookSecret: viper.GetString("notifier.webhook_secret"),
+
+			SlackWebhookURL:     viper.GetString("notifier.slack_webhook_url"),
+			SlackTemplateFile:   viper.GetString("notifier.slack_template_file"),
+			TeamsWebhookURL:     viper.GetString("notifier.teams_webhook_url"),
+			TeamsTemplateFile:   viper.GetString("notifier.teams_template_file"),
+			DiscordWebhookURL:   viper.GetString("notifier.discord_webhook_url"),
+			DiscordTemplateFile: viper.GetString("notifier.discord_template_file"),
+
+			SMTPHost:        viper.GetString("notifier.smtp_host"),
+			SMTPPort:        viper.GetInt("notifier.smtp_port"),
+			SMTPUsername:    viper.GetString("notifier.smtp_username"),
+			SMTPPassword:    viper.GetString("notifier.smtp_password"),
+			SMTPFrom:        viper.GetString("notifier.smtp_from"),
+			SMTPTo:          splitList(viper.GetStringSlice("notifier.smtp_to")...),
+			SMTPTLS:         viper.GetString("notifier.smtp_tls"),
+			SMTPTemplateDir: viper.GetString("notifier.smtp_template_dir"),
+
+			PagerDutyRoutingKey: viper.GetString("notifier.pagerduty_routing_key"),
+			PagerDutyEventsURL:  viper.GetString("notifier.pagerduty_events_url"),
+			OpsgenieAPIKey:      viper.GetString("notifier.opsgenie_api_key"),
+			OpsgenieAPIURL:      viper.GetString("notifier.opsgenie_api_url"),
+
+			TelegramBotToken: viper.GetString("notifier.telegram_bot_token"),
+			TelegramChatID:   viper.GetString("notifier.telegram_chat_id"),
+			TelegramAPIURL:   viper.GetString("notifier.telegram_api_url"),
+			NtfyURL:          viper.GetString("notifier.ntfy_url"),
+			NtfyTopic:        viper.GetString("notifier.ntfy_topic"),
+			NtfyToken:        viper.GetString("notifier.ntfy_token"),
+			PushoverAppToken: viper.GetString("notifier.pushover_app_token"),
+			PushoverUserKey:  viper.GetString("notifier.pushover_user_key"),
+			PushoverAPIURL:   viper.GetString("notifier.pushover_api_url"),
+
+			OutboxInterval:    viper.GetDuration("notifier.outbox_interval"),
+			OutboxMaxAttempts: viper.GetInt("notifier.outbox_max_attempts"),
+
+			GroupBy:       splitList(viper.GetStringSlice("notifier.group_by")...),
+			GroupWait:     viper.GetDuration("notifier.group_wait"),
+			GroupInterval: viper.GetDuration("notifier.group_interval"),
+
+			ReminderInterval: viper.GetDuration("notifier.reminder_interval"),
+			IncidentURL:      viper.GetString("notifier.incident_url"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    viper.GetString("tracing.endpoint"),
+			SampleRatio: viper.GetFloat64("tracing.sample_ratio"),
+		},
+		Middleware: MiddlewareConfig{
+			Recovery:        viper.GetBool("middleware.recovery"),
+			RequestID:       viper.GetBool("middleware.request_id"),
+			RequestLogging:  viper.GetBool("middleware.request_logging"),
+			SecurityHeaders: viper.GetBool("middleware.security_headers"),
+			CORS:            viper.GetBool("middleware.cors"),
+			RateLimit:       viper.GetFloat64("middleware.rate_limit"),
+			RequestTimeout:  viper.GetDuration("middleware.request_timeout"),
+
+			RateLimitBurst:     viper.GetInt("middleware.rate_limit_burst"),
+			RateLimitKeyHeader: viper.GetString("middleware.rate_limit_key_header"),
+			TrustedProxies:     splitList(viper.GetStringSlice("middleware.trusted_proxies")...),
+
+			IPAllowlist:   splitList(viper.GetStringSlice("middleware.ip_allowlist")...),
+			IPDenylist:    splitList(viper.GetStringSlice("middleware.ip_denylist")...),
+			IPFilterPaths: splitList(viper.GetStringSlice("middleware.ip_filter_paths")...),
 		},
 	}
 
@@ -193,6 +611,7 @@ func setViperDefaults() {
 	viper.SetDefault("server.idle_timeout", "60s")
 
 	// Database defaults
+	viper.SetDefault("database.driver", DriverMongo)
 	viper.SetDefault("database.url", "mongodb://localhost:27017")
 	viper.SetDefault("database.name", "statuspage")
 	viper.SetDefault("database.timeout", "10s")
@@ -203,6 +622,23 @@ func setViperDefaults() {
 
 	// Checker defaults
 	viper.SetDefault("checker.interval", "2m")
+	viper.SetDefault("checker.batch_size", 100)
+	viper.SetDefault("checker.flush_interval", "5s")
+	viper.SetDefault("checker.metrics_addr", ":9091")
+
+	// Tracing defaults
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Middleware defaults
+	viper.SetDefault("middleware.recovery", true)
+	viper.SetDefault("middleware.request_id", true)
+	viper.SetDefault("middleware.request_logging", true)
+	viper.SetDefault("middleware.security_headers", true)
+	viper.SetDefault("middleware.cors", true)
+	viper.SetDefault("middleware.rate_limit", 0.0)
+	viper.SetDefault("middleware.request_timeout", "10s")
+	viper.SetDefault("middleware.rate_limit_burst", 0)
+	viper.SetDefault("middleware.rate_limit_key_header", "")
 
 	// API defaults (for consistency with current flags)
 	viper.SetDefault("api.port", "8080")
@@ -249,11 +685,142 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database timeout must be positive")
 	}
 
+	switch c.Database.Driver {
+	case "", DriverMongo, DriverPostgres:
+	default:
+		return fmt.Errorf("invalid database driver: %s (must be one of: %s, %s)", c.Database.Driver, DriverMongo, DriverPostgres)
+	}
+
 	// Checker validation
 	if c.Checker.Interval <= 0 {
 		return fmt.Errorf("checker interval must be positive")
 	}
 
+	if c.Checker.BatchSize < 0 {
+		return fmt.Errorf("checker batch size cannot be negative")
+	}
+
+	if c.Checker.FlushInterval < 0 {
+		return fmt.Errorf("checker flush interval cannot be negative")
+	}
+
+	// Tracing validation
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid tracing endpoint URL: %s", c.Tracing.Endpoint)
+		}
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+
+	// Middleware validation
+	if c.Middleware.RateLimit < 0 {
+		return fmt.Errorf("rate limit cannot be negative")
+	}
+
+	if c.Middleware.RequestTimeout < 0 {
+		return fmt.Errorf("request timeout cannot be negative")
+	}
+
+	if c.Middleware.RateLimitBurst < 0 {
+		return fmt.Errorf("rate limit burst cannot be negative")
+	}
+
+	for _, proxy := range c.Middleware.TrustedProxies {
+		if !isCIDROrIP(proxy) {
+			return fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+	}
+
+	for _, ip := range c.Middleware.IPAllowlist {
+		if !isCIDROrIP(ip) {
+			return fmt.Errorf("invalid IP allowlist entry: %s", ip)
+		}
+	}
+
+	for _, ip := range c.Middleware.IPDenylist {
+		if !isCIDROrIP(ip) {
+			return fmt.Errorf("invalid IP denylist entry: %s", ip)
+		}
+	}
+
+	for _, p := range c.Middleware.IPFilterPaths {
+		if !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("IP filter path must start with /: %s", p)
+		}
+	}
+
+	// Notifier validation
+	webhooks := map[string]string{
+		"webhook":          c.Notifier.WebhookURL,
+		"Slack webhook":    c.Notifier.SlackWebhookURL,
+		"Teams webhook":    c.Notifier.TeamsWebhookURL,
+		"Discord webhook":  c.Notifier.DiscordWebhookURL,
+		"PagerDuty events": c.Notifier.PagerDutyEventsURL,
+		"Opsgenie API":     c.Notifier.OpsgenieAPIURL,
+		"Telegram API":     c.Notifier.TelegramAPIURL,
+		"ntfy server":      c.Notifier.NtfyURL,
+		"Pushover API":     c.Notifier.PushoverAPIURL,
+		"incident":         c.Notifier.IncidentURL,
+	}
+	for name, raw := range webhooks {
+		if raw == "" {
+			continue
+		}
+		if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid %s URL: %s", name, raw)
+		}
+	}
+
+	if c.Notifier.SMTPHost != "" {
+		if c.Notifier.SMTPFrom == "" {
+			return fmt.Errorf("SMTP from address cannot be empty when SMTP host is set")
+		}
+		if len(c.Notifier.SMTPTo) == 0 {
+			return fmt.Errorf("SMTP recipients cannot be empty when SMTP host is set")
+		}
+		switch c.Notifier.SMTPTLS {
+		case "", "starttls", "tls", "none":
+		default:
+			return fmt.Errorf("invalid SMTP TLS mode: %s (must be one of: starttls, tls, none)", c.Notifier.SMTPTLS)
+		}
+		if c.Notifier.SMTPPort < 0 || c.Notifier.SMTPPort > 65535 {
+			return fmt.Errorf("invalid SMTP port: %d", c.Notifier.SMTPPort)
+		}
+	}
+
+	if c.Notifier.TelegramBotToken != "" && c.Notifier.TelegramChatID == "" {
+		return fmt.Errorf("Telegram chat ID cannot be empty when a bot token is set")
+	}
+
+	if c.Notifier.PushoverAppToken != "" && c.Notifier.PushoverUserKey == "" {
+		return fmt.Errorf("Pushover user key cannot be empty when an application token is set")
+	}
+
+	if c.Notifier.OutboxInterval < 0 {
+		return fmt.Errorf("notifier outbox interval cannot be negative")
+	}
+
+	if c.Notifier.OutboxMaxAttempts < 0 {
+		return fmt.Errorf("notifier outbox max attempts cannot be negative")
+	}
+
+	if c.Notifier.GroupWait < 0 || c.Notifier.GroupInterval < 0 {
+		return fmt.Errorf("notifier group wait and interval cannot be negative")
+	}
+
+	if c.Notifier.ReminderInterval < 0 {
+		return fmt.Errorf("notifier reminder interval cannot be negative")
+	}
+
+	for _, key := range c.Notifier.GroupBy {
+		if key != "group" && key != "tags" {
+			return fmt.Errorf("invalid notifier group key: %s (must be one of: group, tags)", key)
+		}
+	}
+
 	// Logging validation
 	if c.Logging.Level == "" {
 		return fmt.Errorf("logging level cannot be empty")
@@ -291,6 +858,45 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// isCIDROrIP reports whether value is a CIDR range or a single IP address
+func isCIDROrIP(value string) bool {
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return true
+	}
+	return net.ParseIP(value) != nil
+}
+
+// splitList splits comma separated values, dropping empty entries
+func splitList(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getBoolEnv(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -27,6 +27,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://localhost:27017",
 					Name:    "statuspage",
 					Timeout: 10 * time.Second,
@@ -36,7 +37,19 @@ func TestConfig_New(t *testing.T) {
 					JSON:  false,
 				},
 				Checker: CheckerConfig{
-					Interval: 2 * time.Minute,
+					Interval:      2 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -55,6 +68,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       120 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://localhost:27017",
 					Name:    "statuspage",
 					Timeout: 10 * time.Second,
@@ -64,7 +78,19 @@ func TestConfig_New(t *testing.T) {
 					JSON:  false,
 				},
 				Checker: CheckerConfig{
-					Interval: 2 * time.Minute,
+					Interval:      2 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -82,6 +108,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://custom:27017",
 					Name:    "custom_db",
 					Timeout: 15 * time.Second,
@@ -91,7 +118,19 @@ func TestConfig_New(t *testing.T) {
 					JSON:  false,
 				},
 				Checker: CheckerConfig{
-					Interval: 2 * time.Minute,
+					Interval:      2 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -109,6 +148,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://localhost:27017",
 					Name:    "statuspage",
 					Timeout: 10 * time.Second,
@@ -118,7 +158,19 @@ func TestConfig_New(t *testing.T) {
 					JSON:  true,
 				},
 				Checker: CheckerConfig{
-					Interval: 2 * time.Minute,
+					Interval:      2 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -136,6 +188,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://localhost:27017",
 					Name:    "statuspage",
 					Timeout: 10 * time.Second,
@@ -145,7 +198,19 @@ func TestConfig_New(t *testing.T) {
 					JSON:  false,
 				},
 				Checker: CheckerConfig{
-					Interval: 5 * time.Minute,
+					Interval:      5 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -156,6 +221,8 @@ func TestConfig_New(t *testing.T) {
 				WithDatabase("mongodb://custom:27017", "custom_db", 15*time.Second),
 				WithLogging("debug", true),
 				WithCheckerInterval(5 * time.Minute),
+				WithCheckerMetricsAddr(""),
+				WithTracing("http://localhost:4318", 0.25),
 			},
 			expected: &Config{
 				Server: ServerConfig{
@@ -166,6 +233,7 @@ func TestConfig_New(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://custom:27017",
 					Name:    "custom_db",
 					Timeout: 15 * time.Second,
@@ -175,7 +243,22 @@ func TestConfig_New(t *testing.T) {
 					JSON:  true,
 				},
 				Checker: CheckerConfig{
-					Interval: 5 * time.Minute,
+					Interval:      5 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   "",
+				},
+				Tracing: TracingConfig{
+					Endpoint:    "http://localhost:4318",
+					SampleRatio: 0.25,
+				},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
@@ -209,6 +292,7 @@ func TestConfig_FromEnvironment(t *testing.T) {
 					IdleTimeout:       60 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://localhost:27017",
 					Name:    "statuspage",
 					Timeout: 10 * time.Second,
@@ -218,23 +302,47 @@ func TestConfig_FromEnvironment(t *testing.T) {
 					JSON:  false,
 				},
 				Checker: CheckerConfig{
-					Interval: 2 * time.Minute,
+					Interval:      2 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   ":9091",
+				},
+				Tracing: TracingConfig{SampleRatio: 1},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  true,
+					SecurityHeaders: true,
+					CORS:            true,
+					RequestTimeout:  10 * time.Second,
 				},
 			},
 		},
 		{
 			name: "custom values from env vars",
 			envVars: map[string]string{
-				"MONGO_URI":      "mongodb://custom:27017",
-				"DB_NAME":        "custom_db",
-				"PORT":           "9090",
-				"CHECK_INTERVAL": "5m",
-				"LOG_LEVEL":      "debug",
-				"LOG_JSON":       "true",
-				"READ_TIMEOUT":   "30s",
-				"WRITE_TIMEOUT":  "30s",
-				"IDLE_TIMEOUT":   "120s",
-				"DB_TIMEOUT":     "15s",
+				"MONGO_URI":                        "mongodb://custom:27017",
+				"DB_NAME":                          "custom_db",
+				"PORT":                             "9090",
+				"CHECK_INTERVAL":                   "5m",
+				"LOG_LEVEL":                        "debug",
+				"LOG_JSON":                         "true",
+				"READ_TIMEOUT":                     "30s",
+				"WRITE_TIMEOUT":                    "30s",
+				"IDLE_TIMEOUT":                     "120s",
+				"DB_TIMEOUT":                       "15s",
+				"CHECKER_METRICS_ADDR":             "127.0.0.1:9100",
+				"TRACING_ENDPOINT":                 "http://otel-collector:4318",
+				"TRACING_SAMPLE_RATIO":             "0.1",
+				"MIDDLEWARE_REQUEST_LOGGING":       "false",
+				"MIDDLEWARE_RATE_LIMIT":            "20",
+				"MIDDLEWARE_REQUEST_TIMEOUT":       "5s",
+				"MIDDLEWARE_RATE_LIMIT_BURST":      "40",
+				"MIDDLEWARE_RATE_LIMIT_KEY_HEADER": "X-API-Key",
+				"MIDDLEWARE_TRUSTED_PROXIES":       "10.0.0.0/8, 192.0.2.1",
+				"MIDDLEWARE_IP_ALLOWLIST":          "198.51.100.0/24,2001:db8::/32",
+				"MIDDLEWARE_IP_DENYLIST":           "198.51.100.7",
+				"MIDDLEWARE_IP_FILTER_PATHS":       "/api/v1/debug, /metrics",
 			},
 			expected: &Config{
 				Server: ServerConfig{
@@ -245,6 +353,7 @@ func TestConfig_FromEnvironment(t *testing.T) {
 					IdleTimeout:       120 * time.Second,
 				},
 				Database: DatabaseConfig{
+					Driver:  DriverMongo,
 					URI:     "mongodb://custom:27017",
 					Name:    "custom_db",
 					Timeout: 15 * time.Second,
@@ -254,7 +363,31 @@ func TestConfig_FromEnvironment(t *testing.T) {
 					JSON:  true,
 				},
 				Checker: CheckerConfig{
-					Interval: 5 * time.Minute,
+					Interval:      5 * time.Minute,
+					BatchSize:     100,
+					FlushInterval: 5 * time.Second,
+					MetricsAddr:   "127.0.0.1:9100",
+				},
+				Tracing: TracingConfig{
+					Endpoint:    "http://otel-collector:4318",
+					SampleRatio: 0.1,
+				},
+				Middleware: MiddlewareConfig{
+					Recovery:        true,
+					RequestID:       true,
+					RequestLogging:  false,
+					SecurityHeaders: true,
+					CORS:            true,
+					RateLimit:       20,
+					RequestTimeout:  5 * time.Second,
+
+					RateLimitBurst:     40,
+					RateLimitKeyHeader: "X-API-Key",
+					TrustedProxies:     []string{"10.0.0.0/8", "192.0.2.1"},
+
+					IPAllowlist:   []string{"198.51.100.0/24", "2001:db8::/32"},
+					IPDenylist:    []string{"198.51.100.7"},
+					IPFilterPaths: []string{"/api/v1/debug", "/metrics"},
 				},
 			},
 		},
@@ -342,6 +475,27 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid database driver",
+			config: &Config{
+				Server: ServerConfig{
+					Port:              "8080",
+					ReadTimeout:       15 * time.Second,
+					ReadHeaderTimeout: 5 * time.Second,
+					WriteTimeout:      15 * time.Second,
+					IdleTimeout:       60 * time.Second,
+				},
+				Database: DatabaseConfig{
+					Driver:  "mysql",
+					URI:     "mysql://localhost:3306",
+					Name:    "statuspage",
+					Timeout: 10 * time.Second,
+				},
+				Logging: LoggingConfig{Level: "info"},
+				Checker: CheckerConfig{Interval: 2 * time.Minute},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid checker interval",
 			config: &Config{
@@ -354,6 +508,187 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "negative checker batch size",
+			config:  New(WithCheckerBatching(-1, 5*time.Second)),
+			wantErr: true,
+		},
+		{
+			name:    "negative checker flush interval",
+			config:  New(WithCheckerBatching(100, -time.Second)),
+			wantErr: true,
+		},
+		{
+			name:    "zero checker batching uses writer defaults",
+			config:  New(WithCheckerBatching(0, 0)),
+			wantErr: false,
+		},
+		{
+			name:    "negative outbox interval",
+			config:  New(WithOutbox(-time.Second, 10)),
+			wantErr: true,
+		},
+		{
+			name:    "negative outbox max attempts",
+			config:  New(WithOutbox(2*time.Second, -1)),
+			wantErr: true,
+		},
+		{
+			name:    "valid grouping",
+			config:  New(WithGrouping([]string{"group", "tags"}, 30*time.Second, 5*time.Minute)),
+			wantErr: false,
+		},
+		{
+			name:    "invalid group key",
+			config:  New(WithGrouping([]string{"region"}, 30*time.Second, 0)),
+			wantErr: true,
+		},
+		{
+			name:    "negative group wait",
+			config:  New(WithGrouping(nil, -time.Second, 0)),
+			wantErr: true,
+		},
+		{
+			name:    "negative reminder interval",
+			config:  New(WithReminders(-time.Minute)),
+			wantErr: true,
+		},
+		{
+			name:    "valid webhook",
+			config:  New(WithWebhook("https://hooks.example.com/uptime", "secret")),
+			wantErr: false,
+		},
+		{
+			name:    "invalid webhook URL",
+			config:  New(WithWebhook("hooks.example.com/uptime", "")),
+			wantErr: true,
+		},
+		{
+			name:    "valid chat webhooks",
+			config:  New(WithChatWebhooks("https://hooks.slack.com/services/T/B/X", "https://example.webhook.office.com/x", "")),
+			wantErr: false,
+		},
+		{
+			name:    "invalid Discord webhook URL",
+			config:  New(WithChatWebhooks("", "", "discord.com/api/webhooks/1/x")),
+			wantErr: true,
+		},
+		{
+			name:    "valid SMTP",
+			config:  New(WithSMTP("smtp.example.com", 0, "alerts@example.com", []string{"ops@example.com"})),
+			wantErr: false,
+		},
+		{
+			name:    "SMTP without recipients",
+			config:  New(WithSMTP("smtp.example.com", 587, "alerts@example.com", nil)),
+			wantErr: true,
+		},
+		{
+			name:    "SMTP without sender",
+			config:  New(WithSMTP("smtp.example.com", 587, "", []string{"ops@example.com"})),
+			wantErr: true,
+		},
+		{
+			name:    "valid paging keys",
+			config:  New(WithPaging("routing-key", "genie-key")),
+			wantErr: false,
+		},
+		{
+			name: "invalid Opsgenie API URL",
+			config: New(WithPaging("", "genie-key"), func(c *Config) {
+				c.Notifier.OpsgenieAPIURL = "api.eu.opsgenie.com"
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "valid incident URL pattern",
+			config:  New(WithIncidentURL("https://status.example.com/alerts/{alert_id}")),
+			wantErr: false,
+		},
+		{
+			name:    "invalid incident URL",
+			config:  New(WithIncidentURL("/alerts/{alert_id}")),
+			wantErr: true,
+		},
+		{
+			name:    "valid push channels",
+			config:  New(WithPush("123:abc", "-100200", "uptime", "app-token", "user-key")),
+			wantErr: false,
+		},
+		{
+			name:    "Telegram without chat ID",
+			config:  New(WithPush("123:abc", "", "", "", "")),
+			wantErr: true,
+		},
+		{
+			name:    "Pushover without user key",
+			config:  New(WithPush("", "", "", "app-token", "")),
+			wantErr: true,
+		},
+		{
+			name: "invalid ntfy server URL",
+			config: New(WithPush("", "", "uptime", "", ""), func(c *Config) {
+				c.Notifier.NtfyURL = "ntfy.example.com"
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "valid tracing",
+			config:  New(WithTracing("https://otel.example.com:4318", 0.5)),
+			wantErr: false,
+		},
+		{
+			name:    "invalid tracing endpoint",
+			config:  New(WithTracing("otel-collector:4318", 1)),
+			wantErr: true,
+		},
+		{
+			name:    "tracing sample ratio above one",
+			config:  New(WithTracing("", 1.5)),
+			wantErr: true,
+		},
+		{
+			name: "negative rate limit",
+			config: New(func(c *Config) {
+				c.Middleware.RateLimit = -1
+			}),
+			wantErr: true,
+		},
+		{
+			name: "valid trusted proxies",
+			config: New(func(c *Config) {
+				c.Middleware.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1", "fd00::/8"}
+			}),
+			wantErr: false,
+		},
+		{
+			name: "invalid IP allowlist entry",
+			config: New(func(c *Config) {
+				c.Middleware.IPAllowlist = []string{"10.0.0.0/8", "office"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid IP denylist entry",
+			config: New(func(c *Config) {
+				c.Middleware.IPDenylist = []string{"2001:db8::/129"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "relative IP filter path",
+			config: New(func(c *Config) {
+				c.Middleware.IPFilterPaths = []string{"metrics"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid trusted proxy",
+			config: New(func(c *Config) {
+				c.Middleware.TrustedProxies = []string{"proxy.internal"}
+			}),
+			wantErr: true,
+		},
 		{
 			name: "negative checker interval",
 			config: &Config{
@@ -389,3 +724,9 @@ func TestConfig_Load_BackwardCompatibility(t *testing.T) {
 	assert.NotEmpty(t, config.Database.Name)
 	assert.True(t, config.Checker.Interval > 0)
 }
+
+func TestSplitList(t *testing.T) {
+	assert.Nil(t, splitList(""))
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, splitList(" a@example.com, ,b@example.com"))
+	assert.Equal(t, []string{"a", "b", "c"}, splitList("a,b", "c"))
+}
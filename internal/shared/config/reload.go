@@ -0,0 +1,25 @@
+package config
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Reload re-reads the config file, when one is used, and returns the
+// configuration loaded from viper. It fails when the file cannot be read or
+// the configuration is invalid.
+func Reload() (*Config, error) {
+	var notFound viper.ConfigFileNotFoundError
+	if err := viper.ReadInConfig(); err != nil && !stderrors.As(err, &notFound) {
+		return nil, fmt.Errorf("failed to re-read config file %s: %w", viper.ConfigFileUsed(), err)
+	}
+
+	cfg := LoadFromViper()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -155,16 +156,8 @@ func (l *ZapLogger) WithContext(ctx context.Context) Logger {
 	}
 
 	// Add context values
-	if ctx != nil {
-		if requestID := getContextValue(ctx, "request_id"); requestID != "" {
-			newLogger.fields["request_id"] = requestID
-		}
-		if userID := getContextValue(ctx, "user_id"); userID != "" {
-			newLogger.fields["user_id"] = userID
-		}
-		if operation := getContextValue(ctx, "operation"); operation != "" {
-			newLogger.fields["operation"] = operation
-		}
+	for k, v := range contextFields(ctx) {
+		newLogger.fields[k] = v
 	}
 
 	return newLogger
@@ -192,8 +185,20 @@ func (l *ZapLogger) WithFields(fields Fields) Logger {
 
 // logWithLevel is the internal method that handles the actual logging
 func (l *ZapLogger) logWithLevel(ctx context.Context, level zapcore.Level, message string, err error, fields Fields) {
+	// Merge logger fields, call fields and context fields, in increasing precedence
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	for k, v := range contextFields(ctx) {
+		merged[k] = v
+	}
+
 	// Create Zap fields slice
-	zapFields := make([]zap.Field, 0, len(fields)+2)
+	zapFields := make([]zap.Field, 0, len(merged)+2)
 
 	// Add message field
 	zapFields = append(zapFields, zap.String("message", sanitizeLogString(message)))
@@ -202,7 +207,7 @@ func (l *ZapLogger) logWithLevel(ctx context.Context, level zapcore.Level, messa
 	zapFields = append(zapFields, zap.String("level", level.String()))
 
 	// Add all fields
-	for k, v := range fields {
+	for k, v := range merged {
 		switch val := v.(type) {
 		case string:
 			zapFields = append(zapFields, zap.String(k, sanitizeLogString(val)))
@@ -221,19 +226,6 @@ func (l *ZapLogger) logWithLevel(ctx context.Context, level zapcore.Level, messa
 		}
 	}
 
-	// Add context fields if available
-	if ctx != nil {
-		if requestID := getContextValue(ctx, "request_id"); requestID != "" {
-			zapFields = append(zapFields, zap.String("request_id", requestID))
-		}
-		if userID := getContextValue(ctx, "user_id"); userID != "" {
-			zapFields = append(zapFields, zap.String("user_id", userID))
-		}
-		if operation := getContextValue(ctx, "operation"); operation != "" {
-			zapFields = append(zapFields, zap.String("operation", operation))
-		}
-	}
-
 	// Log with appropriate level
 	switch level {
 	case zapcore.DebugLevel:
@@ -254,8 +246,52 @@ func sanitizeLogString(s string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(s, "\n", ""), "\r", "")
 }
 
+// contextKey is the type of context keys owned by this package, so they
+// cannot collide with keys set by other packages
+type contextKey string
+
+// requestIDKey holds the ID of the request being served
+const requestIDKey contextKey = "request_id"
+
+// WithRequestID returns a copy of ctx carrying the request ID, which is
+// added to every line logged with the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or
+// an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	return getContextValue(ctx, requestIDKey)
+}
+
+// contextFields extracts the request values and the current trace and span
+// IDs from ctx
+func contextFields(ctx context.Context) Fields {
+	fields := make(Fields)
+	if ctx == nil {
+		return fields
+	}
+
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	for _, key := range []string{"user_id", "operation"} {
+		if val := getContextValue(ctx, key); val != "" {
+			fields[key] = val
+		}
+	}
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields["trace_id"] = spanCtx.TraceID().String()
+		fields["span_id"] = spanCtx.SpanID().String()
+	}
+
+	return fields
+}
+
 // getContextValue safely extracts a value from context
-func getContextValue(ctx context.Context, key string) string {
+func getContextValue(ctx context.Context, key interface{}) string {
 	if ctx == nil {
 		return ""
 	}
@@ -1,9 +1,14 @@
 package logger
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestZapLogger_Basic(t *testing.T) {
@@ -12,3 +17,45 @@ func TestZapLogger_Basic(t *testing.T) {
 	assert.NotNil(t, logger)
 	assert.IsType(t, &ZapLogger{}, logger)
 }
+
+func TestZapLogger_WithContext(t *testing.T) {
+	core, logs := observer.New(DEBUG)
+	base := &ZapLogger{logger: zap.New(core), fields: make(Fields)}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	ctx = WithRequestID(ctx, "req-1")
+
+	base.WithFields(Fields{"component": "api"}).WithContext(ctx).Info(context.Background(), "handled", Fields{"status": 200})
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "api", fields["component"])
+	assert.Equal(t, int64(200), fields["status"])
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), fields["span_id"])
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+	//nolint:staticcheck // a plain string key must not be mistaken for the request ID
+	assert.Empty(t, RequestIDFromContext(context.WithValue(context.Background(), "request_id", "spoofed")))
+	assert.Equal(t, "req-1", RequestIDFromContext(WithRequestID(context.Background(), "req-1")))
+	assert.Equal(t, "req-1", RequestIDFromContext(WithRequestID(context.Background(), "req-\n1")))
+}
+
+func TestZapLogger_LogsTraceOfCallContext(t *testing.T) {
+	core, logs := observer.New(DEBUG)
+	base := &ZapLogger{logger: zap.New(core), fields: make(Fields)}
+
+	base.Info(context.Background(), "untraced", nil)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "check")
+	defer span.End()
+	base.Warn(ctx, "traced", nil)
+
+	require.Equal(t, 2, logs.Len())
+	assert.NotContains(t, logs.All()[0].ContextMap(), "trace_id")
+	assert.Equal(t, span.SpanContext().TraceID().String(), logs.All()[1].ContextMap()["trace_id"])
+}
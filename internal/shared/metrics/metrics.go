@@ -0,0 +1,240 @@
+// Package metrics holds the Prometheus collectors exported by the api and
+// checker commands on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "uptime"
+
+// Metrics is a registry with the collectors of the monitor
+type Metrics struct {
+	registry *prometheus.Registry
+
+	checksTotal         *prometheus.CounterVec
+	probeLatency        *prometheus.HistogramVec
+	up                  *prometheus.GaugeVec
+	queueDepth          prometheus.Gauge
+	mongoWriteErrors    *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	statusLogsWritten   prometheus.Counter
+	statusLogsDropped   *prometheus.CounterVec
+	statusLogRetries    prometheus.Counter
+	statusLogFailures   prometheus.Counter
+	outboxEnqueued      *prometheus.CounterVec
+	outboxDelivered     *prometheus.CounterVec
+	outboxRetries       *prometheus.CounterVec
+	outboxDeadLettered  *prometheus.CounterVec
+	outboxClaimErrors   prometheus.Counter
+	outboxDeliveries    *prometheus.GaugeVec
+}
+
+// New creates the collectors on a fresh registry, together with the Go
+// runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		checksTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checks_total",
+			Help:      "Health checks run, by service and resulting status.",
+		}, []string{"service", "status"}),
+		probeLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "probe_latency_seconds",
+			Help:      "Latency of health check probes, by service.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"service"}),
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "service_up",
+			Help:      "Whether the last check of a service found it up (1) or down (0).",
+		}, []string{"service"}),
+		queueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "worker_queue_depth",
+			Help:      "Health checks waiting for a free worker.",
+		}),
+		mongoWriteErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mongo_write_errors_total",
+			Help:      "MongoDB write commands that failed or reported write errors, by command.",
+		}, []string{"command"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of API requests, by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+		statusLogsWritten: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_logs_written_total",
+			Help:      "Status logs the batch writer stored.",
+		}),
+		statusLogsDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_logs_dropped_total",
+			Help:      "Status logs the batch writer dropped, by reason.",
+		}, []string{"reason"}),
+		statusLogRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_log_write_retries_total",
+			Help:      "Status log batch writes retried after a transient error.",
+		}),
+		statusLogFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "status_log_write_failures_total",
+			Help:      "Status log batch writes that failed and dropped some or all of the batch.",
+		}),
+		outboxEnqueued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_enqueued_total",
+			Help:      "Notification deliveries written to the outbox, by channel.",
+		}, []string{"channel"}),
+		outboxDelivered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_delivered_total",
+			Help:      "Notification deliveries sent from the outbox, by channel.",
+		}, []string{"channel"}),
+		outboxRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_retries_total",
+			Help:      "Failed notification deliveries scheduled for another attempt, by channel.",
+		}, []string{"channel"}),
+		outboxDeadLettered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_dead_lettered_total",
+			Help:      "Notification deliveries dead-lettered, by channel.",
+		}, []string{"channel"}),
+		outboxClaimErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbox_claim_errors_total",
+			Help:      "Failed attempts to claim due deliveries from the outbox.",
+		}),
+		outboxDeliveries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "outbox_deliveries",
+			Help:      "Deliveries in the outbox, by state.",
+		}, []string{"state"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.checksTotal,
+		m.probeLatency,
+		m.up,
+		m.queueDepth,
+		m.mongoWriteErrors,
+		m.httpRequestDuration,
+		m.statusLogsWritten,
+		m.statusLogsDropped,
+		m.statusLogRetries,
+		m.statusLogFailures,
+		m.outboxEnqueued,
+		m.outboxDelivered,
+		m.outboxRetries,
+		m.outboxDeadLettered,
+		m.outboxClaimErrors,
+		m.outboxDeliveries,
+	)
+
+	return m
+}
+
+// Registry returns the registry the collectors are registered with
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the registry in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveCheck records the result of a health check. Degraded services
+// count as up.
+func (m *Metrics) ObserveCheck(service, status string, latency time.Duration) {
+	m.checksTotal.WithLabelValues(service, status).Inc()
+	m.probeLatency.WithLabelValues(service).Observe(latency.Seconds())
+
+	up := 1.0
+	if status == "down" {
+		up = 0
+	}
+	m.up.WithLabelValues(service).Set(up)
+}
+
+// AddQueued changes the number of checks waiting for a worker by delta
+func (m *Metrics) AddQueued(delta int) {
+	m.queueDepth.Add(float64(delta))
+}
+
+// MongoWriteError counts a failed MongoDB write command
+func (m *Metrics) MongoWriteError(command string) {
+	m.mongoWriteErrors.WithLabelValues(command).Inc()
+}
+
+// ObserveRequest records the duration of an API request. Route is the
+// pattern that matched the request, not its path, to bound cardinality.
+func (m *Metrics) ObserveRequest(method, route string, code int, duration time.Duration) {
+	m.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(code)).Observe(duration.Seconds())
+}
+
+// StatusLogsWritten counts n status logs stored by the batch writer
+func (m *Metrics) StatusLogsWritten(n int) {
+	m.statusLogsWritten.Add(float64(n))
+}
+
+// StatusLogsDropped counts n status logs the batch writer dropped for reason
+func (m *Metrics) StatusLogsDropped(reason string, n int) {
+	m.statusLogsDropped.WithLabelValues(reason).Add(float64(n))
+}
+
+// StatusLogWriteRetried counts a status log batch write retried after a
+// transient error
+func (m *Metrics) StatusLogWriteRetried() {
+	m.statusLogRetries.Inc()
+}
+
+// StatusLogWriteFailed counts a status log batch write that dropped logs
+func (m *Metrics) StatusLogWriteFailed() {
+	m.statusLogFailures.Inc()
+}
+
+// OutboxEnqueued counts a delivery written to the outbox for channel
+func (m *Metrics) OutboxEnqueued(channel string) {
+	m.outboxEnqueued.WithLabelValues(channel).Inc()
+}
+
+// OutboxDelivered counts a delivery sent on channel
+func (m *Metrics) OutboxDelivered(channel string) {
+	m.outboxDelivered.WithLabelValues(channel).Inc()
+}
+
+// OutboxRetried counts a failed delivery on channel scheduled for a retry
+func (m *Metrics) OutboxRetried(channel string) {
+	m.outboxRetries.WithLabelValues(channel).Inc()
+}
+
+// OutboxDeadLettered counts a delivery on channel moved to the dead-letter state
+func (m *Metrics) OutboxDeadLettered(channel string) {
+	m.outboxDeadLettered.WithLabelValues(channel).Inc()
+}
+
+// OutboxClaimFailed counts a failed claim of due deliveries
+func (m *Metrics) OutboxClaimFailed() {
+	m.outboxClaimErrors.Inc()
+}
+
+// SetOutboxDeliveries sets the number of deliveries in the outbox in state
+func (m *Metrics) SetOutboxDeliveries(state string, n int64) {
+	m.outboxDeliveries.WithLabelValues(state).Set(float64(n))
+}
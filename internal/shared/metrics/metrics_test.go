@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ObserveCheck(t *testing.T) {
+	m := New()
+
+	m.ObserveCheck("api", "operational", 120*time.Millisecond)
+	m.ObserveCheck("api", "degraded", 3*time.Second)
+	m.ObserveCheck("api", "down", 0)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.checksTotal.WithLabelValues("api", "operational")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.checksTotal.WithLabelValues("api", "degraded")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.checksTotal.WithLabelValues("api", "down")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.up.WithLabelValues("api")))
+
+	m.ObserveCheck("api", "degraded", time.Second)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.up.WithLabelValues("api")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.probeLatency))
+}
+
+func TestMetrics_AddQueued(t *testing.T) {
+	m := New()
+
+	m.AddQueued(3)
+	m.AddQueued(-1)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.queueDepth))
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New()
+	m.ObserveCheck("api", "operational", 50*time.Millisecond)
+	m.MongoWriteError("insert")
+	m.ObserveRequest(http.MethodGet, "/api/v1/status", http.StatusOK, 10*time.Millisecond)
+	m.StatusLogsWritten(3)
+	m.StatusLogsDropped("rejected", 2)
+	m.StatusLogWriteRetried()
+	m.StatusLogWriteFailed()
+	m.OutboxEnqueued("slack")
+	m.OutboxEnqueued("slack")
+	m.OutboxDelivered("slack")
+	m.OutboxRetried("slack")
+	m.OutboxDeadLettered("slack")
+	m.OutboxClaimFailed()
+	m.SetOutboxDeliveries("pending", 4)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `uptime_checks_total{service="api",status="operational"} 1`)
+	assert.Contains(t, body, `uptime_probe_latency_seconds_bucket{service="api",le="0.05"} 1`)
+	assert.Contains(t, body, `uptime_service_up{service="api"} 1`)
+	assert.Contains(t, body, `uptime_worker_queue_depth 0`)
+	assert.Contains(t, body, `uptime_mongo_write_errors_total{command="insert"} 1`)
+	assert.Contains(t, body, `uptime_http_request_duration_seconds_count{code="200",method="GET",route="/api/v1/status"} 1`)
+	assert.Contains(t, body, `uptime_status_logs_written_total 3`)
+	assert.Contains(t, body, `uptime_status_logs_dropped_total{reason="rejected"} 2`)
+	assert.Contains(t, body, `uptime_status_log_write_retries_total 1`)
+	assert.Contains(t, body, `uptime_status_log_write_failures_total 1`)
+	assert.Contains(t, body, `uptime_outbox_enqueued_total{channel="slack"} 2`)
+	assert.Contains(t, body, `uptime_outbox_delivered_total{channel="slack"} 1`)
+	assert.Contains(t, body, `uptime_outbox_retries_total{channel="slack"} 1`)
+	assert.Contains(t, body, `uptime_outbox_dead_lettered_total{channel="slack"} 1`)
+	assert.Contains(t, body, `uptime_outbox_claim_errors_total 1`)
+	assert.Contains(t, body, `uptime_outbox_deliveries{state="pending"} 4`)
+	assert.Contains(t, body, "go_goroutines")
+}
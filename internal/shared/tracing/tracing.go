@@ -0,0 +1,78 @@
+// Package tracing sets up OpenTelemetry trace export for the api and
+// checker commands.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
+)
+
+// InstrumentationName names the tracers of this module
+const InstrumentationName = "github.com/sukhera/uptime-monitor"
+
+// Tracer returns the tracer of this module from tp
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	return tp.Tracer(InstrumentationName)
+}
+
+// TracerFromContext returns the tracer of the provider that created the span
+// in ctx, so spans nest under their parent without threading the provider.
+// It does not record when ctx carries no span.
+func TracerFromContext(ctx context.Context) trace.Tracer {
+	return Tracer(trace.SpanFromContext(ctx).TracerProvider())
+}
+
+// NewProvider creates a tracer provider sending spans to exporter in batches.
+// Attributes from OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME override
+// serviceName.
+func NewProvider(ctx context.Context, exporter sdktrace.SpanExporter, serviceName string, sampleRatio float64) (*sdktrace.TracerProvider, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	), nil
+}
+
+// Setup exports spans over OTLP/HTTP to the configured endpoint and installs
+// the provider and W3C trace context propagation globally. Tracing stays a
+// no-op when no endpoint is configured. The returned function flushes
+// pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg config.TracingConfig, serviceName string) (func(context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider, err := NewProvider(ctx, exporter, serviceName, cfg.SampleRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/sukhera/uptime-monitor/internal/shared/config"
+)
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{SampleRatio: 1}, "uptime-api")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, previous, otel.GetTracerProvider())
+}
+
+func TestNewProvider(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+
+	provider, err := NewProvider(ctx, exporter, "uptime-checker", 1)
+	require.NoError(t, err)
+
+	// Spans started from a context use the provider of the span in it
+	parentCtx, parent := Tracer(provider).Start(ctx, "health_checks.run")
+	_, child := TracerFromContext(parentCtx).Start(parentCtx, "health_check")
+	child.End()
+	parent.End()
+
+	// Without a span in the context nothing is recorded
+	_, orphan := TracerFromContext(ctx).Start(ctx, "orphan")
+	orphan.End()
+
+	require.NoError(t, provider.ForceFlush(ctx))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "health_check", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[1].Resource.Attributes(), attribute.String("service.name", "uptime-checker"))
+}